@@ -1,8 +1,17 @@
 package api
 
 import (
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"thinkingModels/component/errs"
+	"thinkingModels/component/logger"
+	"thinkingModels/config"
 )
 
 type Base struct {
@@ -10,17 +19,42 @@ type Base struct {
 }
 
 type Response struct {
-	Code    int64  `json:"code"`
+	Code    int64  `json:"code"` // 0 成功，其余见 errs.Code
 	Msg     string `json:"msg"`
 	Data    any    `json:"data"`
-	TraceId any    `json:"trace_id"`
+	TraceId any    `json:"trace_id"` // 与响应头 X-Trace-Id 相同，反馈问题时提供该值便于排查
 }
 
-// 返回失败
+func init() {
+	// 参数校验提示使用 json 字段名
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterTagNameFunc(func(field reflect.StructField) string {
+			name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+			if name == "" || name == "-" {
+				return field.Name
+			}
+			return name
+		})
+	}
+}
+
+// 返回失败，ext[0] 可携带附加数据
+// 错误按 errs.Code 映射 HTTP 状态码与错误码，提示语按 Accept-Language 本地化；
+// 未归类的错误视为内部错误，生产环境不返回原始错误信息（原始错误记入访问日志）
 func (a *Base) Error(err error, ext ...any) {
-	tracdId := a.Ctx.Request.Header.Get("x-trace-id")
-	res := Response{TraceId: tracdId, Code: -1, Msg: err.Error()}
-	a.Ctx.JSON(200, res)
+	e := errs.From(err)
+	var numErr *strconv.NumError
+	if e.Code == errs.CodeInternal && errors.As(err, &numErr) { // 接口层解析路径参数失败
+		e = errs.Wrap(errs.CodeValidation, err)
+	}
+	_ = a.Ctx.Error(err)
+
+	lang := errs.Lang(a.Ctx.GetHeader("Accept-Language"))
+	res := Response{TraceId: logger.TraceId(a.Ctx), Code: int64(e.Code), Msg: e.Message(lang, !config.Config.IsProd())}
+	if len(ext) > 0 {
+		res.Data = ext[0]
+	}
+	a.Ctx.JSON(e.Code.Status(), res)
 }
 
 // 返回成功
@@ -35,12 +69,11 @@ func (a *Base) Success(data interface{}, ext ...any) {
 		code = ext[1].(int64)
 	}
 
-	tracdId := a.Ctx.Request.Header.Get("x-trace-id")
-	res := Response{TraceId: tracdId, Code: code, Msg: msg, Data: data}
+	res := Response{TraceId: logger.TraceId(a.Ctx), Code: code, Msg: msg, Data: data}
 	a.Ctx.JSON(200, res)
 }
 
-// 绑定参数
+// 绑定参数，绑定或校验失败返回参数错误
 func (a *Base) Bind(ctx *gin.Context, d any, bindings ...binding.Binding) error {
 	a.Ctx = ctx
 
@@ -50,7 +83,7 @@ func (a *Base) Bind(ctx *gin.Context, d any, bindings ...binding.Binding) error
 
 	err := a.Ctx.ShouldBind(d)
 	if err != nil {
-		return err
+		return errs.Wrap(errs.CodeValidation, err)
 	}
 	return nil
 }
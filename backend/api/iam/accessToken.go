@@ -0,0 +1,115 @@
+package iam
+
+import (
+	"github.com/gin-gonic/gin"
+	"thinkingModels/api"
+	"thinkingModels/domain/iam/accessToken"
+	"thinkingModels/logic/iam"
+)
+
+type AccessToken struct {
+	api.Base
+}
+
+func NewAccessToken() *AccessToken {
+	return &AccessToken{}
+}
+
+// Scopes 可授予的权限范围
+// @Summary 个人访问令牌权限范围
+// @Description 创建令牌时可选的权限范围，write 包含同资源的 read
+// @Tags 个人访问令牌
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} api.Response{data=[]accessToken.ScopeInfo} "成功"
+// @Router /user/tokens/scopes [get]
+func (a AccessToken) Scopes(ctx *gin.Context) {
+	_ = a.Bind(ctx, nil)
+
+	logic := iam.NewAccessTokenLogic(ctx)
+	a.Success(logic.Scopes())
+}
+
+// Create 创建个人访问令牌
+// @Summary 创建个人访问令牌
+// @Description 为当前用户创建长期有效的访问令牌，令牌原文只在本次返回，请妥善保存
+// @Tags 个人访问令牌
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body accessToken.CreateAccessToken true "令牌参数"
+// @Success 200 {object} api.Response{data=accessToken.CreateAccessTokenResponse} "创建成功"
+// @Router /user/tokens [post]
+func (a AccessToken) Create(ctx *gin.Context) {
+	req := &accessToken.CreateAccessToken{}
+	err := a.Bind(ctx, req)
+	if err != nil {
+		a.Error(err)
+		return
+	}
+
+	logic := iam.NewAccessTokenLogic(ctx)
+	res, err := logic.Create(req)
+	if err != nil {
+		a.Error(err)
+		return
+	}
+
+	a.Success(res, "创建成功")
+}
+
+// ListMy 我的个人访问令牌
+// @Summary 我的个人访问令牌
+// @Description 查询当前用户的个人访问令牌（不含令牌原文）
+// @Tags 个人访问令牌
+// @Produce json
+// @Security Bearer
+// @Param page query int false "页码"
+// @Param pageSize query int false "每页数量"
+// @Success 200 {object} api.Response{data=logic.ListReap} "查询成功"
+// @Router /user/tokens [get]
+func (a AccessToken) ListMy(ctx *gin.Context) {
+	req := &accessToken.SearchAccessToken{}
+	err := a.Bind(ctx, req)
+	if err != nil {
+		a.Error(err)
+		return
+	}
+
+	logic := iam.NewAccessTokenLogic(ctx)
+	res, err := logic.ListMy(req)
+	if err != nil {
+		a.Error(err)
+		return
+	}
+
+	a.Success(res, "查询成功")
+}
+
+// Revoke 吊销个人访问令牌
+// @Summary 吊销个人访问令牌
+// @Description 吊销后令牌立即失效
+// @Tags 个人访问令牌
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body accessToken.RevokeAccessToken true "令牌ID"
+// @Success 200 {object} api.Response "吊销成功"
+// @Router /user/tokens [delete]
+func (a AccessToken) Revoke(ctx *gin.Context) {
+	req := &accessToken.RevokeAccessToken{}
+	err := a.Bind(ctx, req)
+	if err != nil {
+		a.Error(err)
+		return
+	}
+
+	logic := iam.NewAccessTokenLogic(ctx)
+	err = logic.Revoke(req)
+	if err != nil {
+		a.Error(err)
+		return
+	}
+
+	a.Success(nil, "吊销成功")
+}
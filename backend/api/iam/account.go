@@ -0,0 +1,89 @@
+package iam
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"thinkingModels/api"
+	"thinkingModels/domain/iam/accountDeletion"
+	"thinkingModels/logic/iam"
+)
+
+type Account struct {
+	api.Base
+}
+
+func NewAccount() *Account {
+	return &Account{}
+}
+
+// Export 导出个人数据
+// @Summary 导出个人数据
+// @Description 下载 ZIP 归档：课题、全部分析版本、行动、跟进记录与本人创作的思维模型，每类数据提供 JSON 与 Markdown 两种格式；每小时最多导出5次
+// @Tags 个人数据
+// @Produce application/zip
+// @Security Bearer
+// @Success 200 {file} file "ZIP 归档"
+// @Router /user/export [get]
+func (a Account) Export(ctx *gin.Context) {
+	_ = a.Bind(ctx, nil)
+
+	logic := iam.NewAccountLogic(ctx)
+	name, data, err := logic.Export()
+	if err != nil {
+		a.Error(err)
+		return
+	}
+
+	ctx.Header("Content-Disposition", `attachment; filename="`+name+`"`)
+	ctx.Data(http.StatusOK, "application/zip", data)
+}
+
+// DeleteAccount 注销账号
+// @Summary 注销账号
+// @Description 校验密码（已启用两步验证时还需验证码）后立即停用账号并吊销全部登录凭据，返回后台清理任务ID；清理时公开的思维模型保留并匿名化，其余个人数据物理删除
+// @Tags 个人数据
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body accountDeletion.DeleteAccountRequest true "密码与验证码"
+// @Success 200 {object} api.Response{data=accountDeletion.AccountDeletionInfo} "已提交注销"
+// @Router /user/account [delete]
+func (a Account) DeleteAccount(ctx *gin.Context) {
+	req := &accountDeletion.DeleteAccountRequest{}
+	err := a.Bind(ctx, req)
+	if err != nil {
+		a.Error(err)
+		return
+	}
+
+	logic := iam.NewAccountLogic(ctx)
+	res, err := logic.DeleteAccount(req)
+	if err != nil {
+		a.Error(err)
+		return
+	}
+
+	a.Success(res, "已提交注销")
+}
+
+// DeletionStatus 注销进度
+// @Summary 注销进度
+// @Description 凭注销时返回的任务ID查询清理进度，无需登录
+// @Tags 个人数据
+// @Produce json
+// @Param jobId path string true "任务ID"
+// @Success 200 {object} api.Response{data=accountDeletion.AccountDeletionInfo} "查询成功"
+// @Router /auth/account-deletion/{jobId} [get]
+func (a Account) DeletionStatus(ctx *gin.Context) {
+	_ = a.Bind(ctx, nil)
+
+	logic := iam.NewAccountLogic(ctx)
+	res, err := logic.DeletionStatus(ctx.Param("jobId"))
+	if err != nil {
+		a.Error(err)
+		return
+	}
+
+	a.Success(res, "查询成功")
+}
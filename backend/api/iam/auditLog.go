@@ -0,0 +1,71 @@
+package iam
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"thinkingModels/api"
+	"thinkingModels/domain/iam/auditLog"
+	"thinkingModels/logic/iam"
+)
+
+// AuditLog 审计日志API控制器（只读）
+type AuditLog struct {
+	api.Base
+}
+
+// NewAuditLog 初始化AuditLog控制器
+func NewAuditLog() *AuditLog {
+	return &AuditLog{}
+}
+
+// List 查询审计日志
+// @Summary 查询审计日志
+// @Description 按操作人、动作、目标、IP、trace id、时间范围筛选，按时间倒序
+// @Tags 审计日志
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body auditLog.SearchAuditLog true "搜索条件"
+// @Success 200 {object} api.Response{data=logic.ListReap{list=[]auditLog.AuditLogInfo}} "查询成功"
+// @Router /iam/audit-log/list [post]
+func (a *AuditLog) List(ctx *gin.Context) {
+	req := &auditLog.SearchAuditLog{}
+	if err := a.Bind(ctx, req); err != nil {
+		a.Error(err)
+		return
+	}
+
+	logic := iam.NewAuditLogLogic(ctx)
+	res, err := logic.List(req)
+	if err != nil {
+		a.Error(err)
+		return
+	}
+	a.Success(res, "查询列表成功")
+}
+
+// Get 查询审计日志详情
+// @Summary 查询审计日志详情
+// @Tags 审计日志
+// @Produce json
+// @Security Bearer
+// @Param id path int true "审计日志ID"
+// @Success 200 {object} api.Response{data=auditLog.AuditLogInfo} "查询成功"
+// @Router /iam/audit-log/{id} [get]
+func (a *AuditLog) Get(ctx *gin.Context) {
+	a.Ctx = ctx
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		a.Error(err)
+		return
+	}
+
+	logic := iam.NewAuditLogLogic(ctx)
+	res, err := logic.Get(id)
+	if err != nil {
+		a.Error(err)
+		return
+	}
+	a.Success(res, "查询成功")
+}
@@ -0,0 +1,47 @@
+package iam
+
+import (
+	"github.com/gin-gonic/gin"
+	"thinkingModels/domain/iam/user"
+	"thinkingModels/logic/iam"
+)
+
+// Captcha 获取人机验证码
+// @Summary 获取人机验证码
+// @Description 生成滑块拼图或图形点选验证码，答案保存在服务端；登录、注册时回传 captchaId 与 captchaAnswer，验证码一次性有效
+// @Tags 用户认证
+// @Produce json
+// @Param kind query string false "验证码类型：slider=滑块拼图（默认），point=图形点选"
+// @Success 200 {object} api.Response{data=user.CaptchaResponse} "获取成功"
+// @Router /auth/captcha [get]
+func (a User) Captcha(ctx *gin.Context) {
+	req := &user.CaptchaRequest{}
+	err := a.Bind(ctx, req)
+	if err != nil {
+		a.Error(err)
+		return
+	}
+
+	logic := iam.NewUserLogic(ctx)
+	res, err := logic.Captcha(req)
+	if err != nil {
+		a.Error(err)
+		return
+	}
+
+	a.Success(res, "获取成功")
+}
+
+// CaptchaStatus 是否需要人机验证
+// @Summary 是否需要人机验证
+// @Description 查询当前IP登录、注册是否需要人机验证；登录失败响应中的 captchaRequired 同样提示下次登录需要验证
+// @Tags 用户认证
+// @Produce json
+// @Success 200 {object} api.Response{data=user.CaptchaStatus} "查询成功"
+// @Router /auth/captcha/status [get]
+func (a User) CaptchaStatus(ctx *gin.Context) {
+	_ = a.Bind(ctx, nil)
+
+	logic := iam.NewUserLogic(ctx)
+	a.Success(logic.CaptchaStatus(), "查询成功")
+}
@@ -0,0 +1,148 @@
+package iam
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"thinkingModels/api"
+	"thinkingModels/domain/iam/inviteCode"
+	"thinkingModels/logic/iam"
+)
+
+// InviteCode 邀请码管理API控制器
+type InviteCode struct {
+	api.Base
+}
+
+// NewInviteCode 初始化InviteCode控制器
+func NewInviteCode() *InviteCode {
+	return &InviteCode{}
+}
+
+// Create 创建邀请码
+// @Summary 创建邀请码
+// @Description 指定 code 时创建一个自定义邀请码，否则按 count 随机生成；邀请人默认为当前管理员
+// @Tags 邀请码管理
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body inviteCode.CreateInviteCode true "邀请码参数"
+// @Success 200 {object} api.Response{data=[]inviteCode.InviteCodeInfo} "创建成功"
+// @Router /iam/invite-code [post]
+func (a *InviteCode) Create(ctx *gin.Context) {
+	req := &inviteCode.CreateInviteCode{}
+	if err := a.Bind(ctx, req); err != nil {
+		a.Error(err)
+		return
+	}
+
+	logic := iam.NewInviteCodeLogic(ctx)
+	res, err := logic.Create(req)
+	if err != nil {
+		a.Error(err)
+		return
+	}
+	a.Success(res, "创建成功")
+}
+
+// Update 更新邀请码
+// @Summary 更新邀请码
+// @Description 修改次数上限、过期时间、状态与备注，邀请码与邀请人不可修改
+// @Tags 邀请码管理
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body inviteCode.UpdateInviteCode true "邀请码参数"
+// @Success 200 {object} api.Response{data=inviteCode.InviteCodeInfo} "更新成功"
+// @Router /iam/invite-code [put]
+func (a *InviteCode) Update(ctx *gin.Context) {
+	req := &inviteCode.UpdateInviteCode{}
+	if err := a.Bind(ctx, req); err != nil {
+		a.Error(err)
+		return
+	}
+
+	logic := iam.NewInviteCodeLogic(ctx)
+	res, err := logic.Update(req)
+	if err != nil {
+		a.Error(err)
+		return
+	}
+	a.Success(res, "更新成功")
+}
+
+// Get 查询邀请码详情
+// @Summary 查询邀请码详情
+// @Tags 邀请码管理
+// @Produce json
+// @Security Bearer
+// @Param id path int true "邀请码ID"
+// @Success 200 {object} api.Response{data=inviteCode.InviteCodeInfo} "查询成功"
+// @Router /iam/invite-code/{id} [get]
+func (a *InviteCode) Get(ctx *gin.Context) {
+	a.Ctx = ctx
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		a.Error(err)
+		return
+	}
+
+	logic := iam.NewInviteCodeLogic(ctx)
+	res, err := logic.Get(id)
+	if err != nil {
+		a.Error(err)
+		return
+	}
+	a.Success(res, "查询成功")
+}
+
+// List 查询邀请码列表
+// @Summary 查询邀请码列表
+// @Description 按邀请码、邀请人、状态筛选；邀请注册的用户可通过用户列表的 invitedBy / inviteCodeId 查询
+// @Tags 邀请码管理
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body inviteCode.SearchInviteCode true "搜索条件"
+// @Success 200 {object} api.Response{data=logic.ListReap{list=[]inviteCode.InviteCodeInfo}} "查询成功"
+// @Router /iam/invite-code/list [post]
+func (a *InviteCode) List(ctx *gin.Context) {
+	req := &inviteCode.SearchInviteCode{}
+	if err := a.Bind(ctx, req); err != nil {
+		a.Error(err)
+		return
+	}
+
+	logic := iam.NewInviteCodeLogic(ctx)
+	res, err := logic.List(req)
+	if err != nil {
+		a.Error(err)
+		return
+	}
+	a.Success(res, "查询列表成功")
+}
+
+// Del 删除邀请码
+// @Summary 删除邀请码
+// @Tags 邀请码管理
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body inviteCode.DelInviteCode true "删除请求参数"
+// @Success 200 {object} api.Response "删除成功"
+// @Router /iam/invite-code [delete]
+func (a *InviteCode) Del(ctx *gin.Context) {
+	req := &inviteCode.DelInviteCode{}
+	if err := a.Bind(ctx, req); err != nil {
+		a.Error(err)
+		return
+	}
+
+	logic := iam.NewInviteCodeLogic(ctx)
+	res, err := logic.Del(req)
+	if err != nil {
+		a.Error(err)
+		return
+	}
+	a.Success(res, "删除成功")
+}
@@ -0,0 +1,29 @@
+package iam
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"thinkingModels/logic/iam"
+)
+
+// Jwks JWT公钥集合
+// @Summary JWT公钥集合
+// @Description 标准 JWKS 格式（不包裹统一响应），发布 RS256/EdDSA 签名密钥的公钥，供其他服务按 Token 头部的 kid 校验本服务签发的 Token；HS256 密钥不公开
+// @Tags 用户认证
+// @Produce json
+// @Success 200 {object} jwtkey.JWKS "公钥集合"
+// @Router /.well-known/jwks.json [get]
+func (a User) Jwks(ctx *gin.Context) {
+	_ = a.Bind(ctx, nil)
+
+	logic := iam.NewUserLogic(ctx)
+	res, err := logic.Jwks()
+	if err != nil {
+		a.Error(err)
+		return
+	}
+
+	ctx.Header("Cache-Control", "public, max-age=300")
+	ctx.JSON(http.StatusOK, res)
+}
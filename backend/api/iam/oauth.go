@@ -0,0 +1,81 @@
+package iam
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"thinkingModels/api"
+	"thinkingModels/domain/iam/userIdentity"
+	"thinkingModels/logic/iam"
+)
+
+type Oauth struct {
+	api.Base
+}
+
+func NewOauth() *Oauth {
+	return &Oauth{}
+}
+
+// Providers 可用的第三方登录方式
+// @Summary 第三方登录方式
+// @Description 返回已配置的 OAuth2/OIDC 身份提供方
+// @Tags 用户认证
+// @Produce json
+// @Success 200 {object} api.Response{data=userIdentity.OauthProviders} "成功"
+// @Router /oauth2/providers [get]
+func (a Oauth) Providers(ctx *gin.Context) {
+	_ = a.Bind(ctx, nil)
+
+	logic := iam.NewOauthLogic(ctx)
+	a.Success(logic.Providers())
+}
+
+// Authorize 发起第三方登录
+// @Summary 发起第三方登录
+// @Description 生成 state 与 PKCE 参数，302 跳转到身份提供方授权页
+// @Tags 用户认证
+// @Param provider path string true "身份提供方"
+// @Success 302 "跳转到授权页"
+// @Failure 200 {object} api.Response "不支持的登录方式"
+// @Router /oauth2/{provider}/authorize [get]
+func (a Oauth) Authorize(ctx *gin.Context) {
+	_ = a.Bind(ctx, nil)
+
+	logic := iam.NewOauthLogic(ctx)
+	authUrl, err := logic.Authorize(ctx.Param("provider"))
+	if err != nil {
+		a.Error(err)
+		return
+	}
+	ctx.Redirect(http.StatusFound, authUrl)
+}
+
+// Callback 第三方登录回调
+// @Summary 第三方登录回调
+// @Description 使用授权码完成登录：按已绑定身份或已验证邮箱关联本地用户，未注册时自动创建，返回与密码登录相同的Token
+// @Tags 用户认证
+// @Produce json
+// @Param provider path string true "身份提供方"
+// @Param code query string false "授权码"
+// @Param state query string true "授权流程标识"
+// @Success 200 {object} api.Response{data=user.LoginResponse} "登录成功"
+// @Failure 200 {object} api.Response "登录请求已失效或授权失败"
+// @Router /oauth2/{provider}/callback [get]
+func (a Oauth) Callback(ctx *gin.Context) {
+	req := &userIdentity.OauthCallbackRequest{}
+	err := a.Bind(ctx, req)
+	if err != nil {
+		a.Error(err)
+		return
+	}
+
+	logic := iam.NewOauthLogic(ctx)
+	res, err := logic.Callback(ctx.Param("provider"), req)
+	if err != nil {
+		a.Error(err)
+		return
+	}
+
+	a.Success(res, "登录成功")
+}
@@ -0,0 +1,164 @@
+package iam
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"thinkingModels/api"
+	"thinkingModels/domain/iam/permission"
+	"thinkingModels/logic/iam"
+)
+
+// Permission 权限API控制器
+type Permission struct {
+	api.Base
+}
+
+// NewPermission 初始化Permission控制器
+func NewPermission() *Permission {
+	return &Permission{}
+}
+
+// Create 创建权限
+// @Summary 创建权限
+// @Tags 角色权限
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body permission.CreatePermission true "创建权限请求参数"
+// @Success 200 {object} api.Response{data=permission.PermissionEntity} "创建成功"
+// @Router /iam/permission [post]
+func (a *Permission) Create(ctx *gin.Context) {
+	req := &permission.CreatePermission{}
+	if err := a.Bind(ctx, req); err != nil {
+		a.Error(err)
+		return
+	}
+
+	logic := iam.NewPermissionLogic(ctx)
+	res, err := logic.Create(req)
+	if err != nil {
+		a.Error(err)
+		return
+	}
+	a.Success(res, "创建成功")
+}
+
+// Update 更新权限
+// @Summary 更新权限
+// @Tags 角色权限
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body permission.UpdatePermission true "更新权限请求参数"
+// @Success 200 {object} api.Response{data=permission.PermissionEntity} "更新成功"
+// @Router /iam/permission [put]
+func (a *Permission) Update(ctx *gin.Context) {
+	req := &permission.UpdatePermission{}
+	if err := a.Bind(ctx, req); err != nil {
+		a.Error(err)
+		return
+	}
+
+	logic := iam.NewPermissionLogic(ctx)
+	res, err := logic.Update(req)
+	if err != nil {
+		a.Error(err)
+		return
+	}
+	a.Success(res, "更新成功")
+}
+
+// Get 查询权限详情
+// @Summary 查询权限详情
+// @Tags 角色权限
+// @Produce json
+// @Security Bearer
+// @Param id path int true "权限ID"
+// @Success 200 {object} api.Response{data=permission.PermissionEntity} "查询成功"
+// @Router /iam/permission/{id} [get]
+func (a *Permission) Get(ctx *gin.Context) {
+	a.Ctx = ctx
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		a.Error(err)
+		return
+	}
+
+	logic := iam.NewPermissionLogic(ctx)
+	res, err := logic.Get(id)
+	if err != nil {
+		a.Error(err)
+		return
+	}
+	a.Success(res, "查询成功")
+}
+
+// List 查询权限列表
+// @Summary 查询权限列表
+// @Tags 角色权限
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body permission.SearchPermission true "搜索条件"
+// @Success 200 {object} api.Response{data=permission.ListReap} "查询成功"
+// @Router /iam/permission/list [post]
+func (a *Permission) List(ctx *gin.Context) {
+	req := &permission.SearchPermission{}
+	if err := a.Bind(ctx, req); err != nil {
+		a.Error(err)
+		return
+	}
+
+	logic := iam.NewPermissionLogic(ctx)
+	res, err := logic.List(req)
+	if err != nil {
+		a.Error(err)
+		return
+	}
+	a.Success(res, "查询列表成功")
+}
+
+// All 获取全部权限
+// @Summary 获取全部权限（角色分配权限时使用）
+// @Tags 角色权限
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} api.Response{data=permission.AllReap} "查询成功"
+// @Router /iam/permission/all [get]
+func (a *Permission) All(ctx *gin.Context) {
+	a.Ctx = ctx
+
+	logic := iam.NewPermissionLogic(ctx)
+	res, err := logic.All()
+	if err != nil {
+		a.Error(err)
+		return
+	}
+	a.Success(res, "查询成功")
+}
+
+// Del 删除权限
+// @Summary 删除权限
+// @Tags 角色权限
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body permission.DelPermission true "删除请求参数"
+// @Success 200 {object} api.Response "删除成功"
+// @Router /iam/permission [delete]
+func (a *Permission) Del(ctx *gin.Context) {
+	req := &permission.DelPermission{}
+	if err := a.Bind(ctx, req); err != nil {
+		a.Error(err)
+		return
+	}
+
+	logic := iam.NewPermissionLogic(ctx)
+	res, err := logic.Del(req)
+	if err != nil {
+		a.Error(err)
+		return
+	}
+	a.Success(res, "删除成功")
+}
@@ -0,0 +1,98 @@
+package iam
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"thinkingModels/domain/iam/user"
+	"thinkingModels/domain/iam/userFollow"
+	"thinkingModels/logic/iam"
+)
+
+// Profile 用户公开主页
+// @Summary 用户公开主页
+// @Description 返回昵称、头像、简介、粉丝数、加入时间、已发布思维模型（分页）及其汇总数据，不包含邮箱、手机号等隐私字段；禁用或待验证的用户视为不存在
+// @Tags 用户主页
+// @Produce json
+// @Security Bearer
+// @Param id path int true "用户ID"
+// @Param page query int false "已发布模型页码，默认1"
+// @Param pageSize query int false "已发布模型每页数量，默认10，最大50"
+// @Success 200 {object} api.Response{data=user.UserProfile} "查询成功"
+// @Failure 400 {object} api.Response "用户不存在"
+// @Router /user/profile/{id} [get]
+func (a User) Profile(ctx *gin.Context) {
+	req := &user.ProfileRequest{}
+	err := a.Bind(ctx, req)
+	if err != nil {
+		a.Error(err)
+		return
+	}
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		a.Error(err)
+		return
+	}
+
+	logic := iam.NewUserLogic(ctx)
+	res, err := logic.Profile(id, req)
+	if err != nil {
+		a.Error(err)
+		return
+	}
+
+	a.Success(res, "查询成功")
+}
+
+// Follow 关注用户
+// @Summary 关注用户
+// @Description 关注指定用户，重复关注直接返回成功；不能关注自己
+// @Tags 用户主页
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body userFollow.FollowRequest true "被关注的用户"
+// @Success 200 {object} api.Response "关注成功"
+// @Router /user/follow [post]
+func (a User) Follow(ctx *gin.Context) {
+	req := &userFollow.FollowRequest{}
+	err := a.Bind(ctx, req)
+	if err != nil {
+		a.Error(err)
+		return
+	}
+
+	logic := iam.NewUserLogic(ctx)
+	if err = logic.Follow(req); err != nil {
+		a.Error(err)
+		return
+	}
+
+	a.Success(nil, "关注成功")
+}
+
+// Unfollow 取消关注
+// @Summary 取消关注
+// @Tags 用户主页
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body userFollow.FollowRequest true "取消关注的用户"
+// @Success 200 {object} api.Response "已取消关注"
+// @Router /user/follow [delete]
+func (a User) Unfollow(ctx *gin.Context) {
+	req := &userFollow.FollowRequest{}
+	err := a.Bind(ctx, req)
+	if err != nil {
+		a.Error(err)
+		return
+	}
+
+	logic := iam.NewUserLogic(ctx)
+	if err = logic.Unfollow(req); err != nil {
+		a.Error(err)
+		return
+	}
+
+	a.Success(nil, "已取消关注")
+}
@@ -0,0 +1,170 @@
+package iam
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"thinkingModels/api"
+	"thinkingModels/domain/iam/role"
+	"thinkingModels/logic/iam"
+)
+
+// Role 角色API控制器
+type Role struct {
+	api.Base
+}
+
+// NewRole 初始化Role控制器
+func NewRole() *Role {
+	return &Role{}
+}
+
+// Create 创建角色
+// @Summary 创建角色
+// @Tags 角色权限
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body role.CreateRole true "创建角色请求参数"
+// @Success 200 {object} api.Response{data=role.RoleEntity} "创建成功"
+// @Router /iam/role [post]
+func (a *Role) Create(ctx *gin.Context) {
+	req := &role.CreateRole{}
+	if err := a.Bind(ctx, req); err != nil {
+		a.Error(err)
+		return
+	}
+
+	logic := iam.NewRoleLogic(ctx)
+	res, err := logic.Create(req)
+	if err != nil {
+		a.Error(err)
+		return
+	}
+	a.Success(res, "创建成功")
+}
+
+// Update 更新角色
+// @Summary 更新角色
+// @Tags 角色权限
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body role.UpdateRole true "更新角色请求参数"
+// @Success 200 {object} api.Response{data=role.RoleEntity} "更新成功"
+// @Router /iam/role [put]
+func (a *Role) Update(ctx *gin.Context) {
+	req := &role.UpdateRole{}
+	if err := a.Bind(ctx, req); err != nil {
+		a.Error(err)
+		return
+	}
+
+	logic := iam.NewRoleLogic(ctx)
+	res, err := logic.Update(req)
+	if err != nil {
+		a.Error(err)
+		return
+	}
+	a.Success(res, "更新成功")
+}
+
+// Get 查询角色详情
+// @Summary 查询角色详情（含已分配的权限ID）
+// @Tags 角色权限
+// @Produce json
+// @Security Bearer
+// @Param id path int true "角色ID"
+// @Success 200 {object} api.Response{data=role.RoleDetail} "查询成功"
+// @Router /iam/role/{id} [get]
+func (a *Role) Get(ctx *gin.Context) {
+	a.Ctx = ctx
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		a.Error(err)
+		return
+	}
+
+	logic := iam.NewRoleLogic(ctx)
+	res, err := logic.Get(id)
+	if err != nil {
+		a.Error(err)
+		return
+	}
+	a.Success(res, "查询成功")
+}
+
+// List 查询角色列表
+// @Summary 查询角色列表
+// @Tags 角色权限
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body role.SearchRole true "搜索条件"
+// @Success 200 {object} api.Response{data=role.ListReap} "查询成功"
+// @Router /iam/role/list [post]
+func (a *Role) List(ctx *gin.Context) {
+	req := &role.SearchRole{}
+	if err := a.Bind(ctx, req); err != nil {
+		a.Error(err)
+		return
+	}
+
+	logic := iam.NewRoleLogic(ctx)
+	res, err := logic.List(req)
+	if err != nil {
+		a.Error(err)
+		return
+	}
+	a.Success(res, "查询列表成功")
+}
+
+// Del 删除角色
+// @Summary 删除角色
+// @Tags 角色权限
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body role.DelRole true "删除请求参数"
+// @Success 200 {object} api.Response "删除成功"
+// @Router /iam/role [delete]
+func (a *Role) Del(ctx *gin.Context) {
+	req := &role.DelRole{}
+	if err := a.Bind(ctx, req); err != nil {
+		a.Error(err)
+		return
+	}
+
+	logic := iam.NewRoleLogic(ctx)
+	res, err := logic.Del(req)
+	if err != nil {
+		a.Error(err)
+		return
+	}
+	a.Success(res, "删除成功")
+}
+
+// AssignPermissions 为角色分配权限
+// @Summary 为角色分配权限（全量覆盖）
+// @Tags 角色权限
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body role.AssignRolePermissions true "分配权限请求参数"
+// @Success 200 {object} api.Response{data=role.RoleDetail} "分配成功"
+// @Router /iam/role/permissions [post]
+func (a *Role) AssignPermissions(ctx *gin.Context) {
+	req := &role.AssignRolePermissions{}
+	if err := a.Bind(ctx, req); err != nil {
+		a.Error(err)
+		return
+	}
+
+	logic := iam.NewRoleLogic(ctx)
+	res, err := logic.AssignPermissions(req)
+	if err != nil {
+		a.Error(err)
+		return
+	}
+	a.Success(res, "分配成功")
+}
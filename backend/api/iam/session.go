@@ -0,0 +1,86 @@
+package iam
+
+import (
+	"github.com/gin-gonic/gin"
+	"thinkingModels/api"
+	"thinkingModels/domain/iam/userSession"
+	"thinkingModels/logic/iam"
+)
+
+type Session struct {
+	api.Base
+}
+
+func NewSession() *Session {
+	return &Session{}
+}
+
+// ListMy 我的登录会话
+// @Summary 我的登录会话
+// @Description 列出当前用户在各设备上的有效登录会话，current 标记当前会话
+// @Tags 登录会话
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} api.Response{data=[]userSession.UserSessionInfo} "查询成功"
+// @Router /user/sessions [get]
+func (a Session) ListMy(ctx *gin.Context) {
+	_ = a.Bind(ctx, nil)
+
+	logic := iam.NewSessionLogic(ctx)
+	res, err := logic.ListMy()
+	if err != nil {
+		a.Error(err)
+		return
+	}
+
+	a.Success(res, "查询成功")
+}
+
+// Revoke 下线指定会话
+// @Summary 下线指定会话
+// @Description 作废该会话的 Refresh Token，并使其已签发的 Access Token 立即失效
+// @Tags 登录会话
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body userSession.RevokeUserSession true "会话ID"
+// @Success 200 {object} api.Response "下线成功"
+// @Router /user/sessions [delete]
+func (a Session) Revoke(ctx *gin.Context) {
+	req := &userSession.RevokeUserSession{}
+	err := a.Bind(ctx, req)
+	if err != nil {
+		a.Error(err)
+		return
+	}
+
+	logic := iam.NewSessionLogic(ctx)
+	err = logic.Revoke(req)
+	if err != nil {
+		a.Error(err)
+		return
+	}
+
+	a.Success(nil, "下线成功")
+}
+
+// RevokeOthers 下线其他会话
+// @Summary 下线其他会话
+// @Description 保留当前会话，下线其余全部会话
+// @Tags 登录会话
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} api.Response{data=int} "下线的会话数量"
+// @Router /user/sessions/revoke-others [post]
+func (a Session) RevokeOthers(ctx *gin.Context) {
+	_ = a.Bind(ctx, nil)
+
+	logic := iam.NewSessionLogic(ctx)
+	count, err := logic.RevokeOthers()
+	if err != nil {
+		a.Error(err)
+		return
+	}
+
+	a.Success(count, "下线成功")
+}
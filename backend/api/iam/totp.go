@@ -0,0 +1,165 @@
+package iam
+
+import (
+	"github.com/gin-gonic/gin"
+	"thinkingModels/domain/iam/user"
+	"thinkingModels/logic/iam"
+)
+
+// TotpStatus 两步验证状态
+// @Summary 两步验证状态
+// @Description 查询当前用户是否已启用两步验证及剩余恢复码数量
+// @Tags 两步验证
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} api.Response{data=user.TotpStatus} "查询成功"
+// @Router /user/2fa [get]
+func (a User) TotpStatus(ctx *gin.Context) {
+	_ = a.Bind(ctx, nil)
+
+	logic := iam.NewUserLogic(ctx)
+	res, err := logic.TotpStatus()
+	if err != nil {
+		a.Error(err)
+		return
+	}
+
+	a.Success(res, "查询成功")
+}
+
+// SetupTotp 生成两步验证密钥
+// @Summary 生成两步验证密钥
+// @Description 生成 TOTP 密钥与 otpauth 地址，需调用启用接口校验验证码后生效；重复调用会替换未启用的密钥
+// @Tags 两步验证
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} api.Response{data=user.TotpSetupResponse} "生成成功"
+// @Failure 400 {object} api.Response "已启用两步验证"
+// @Router /user/2fa/setup [post]
+func (a User) SetupTotp(ctx *gin.Context) {
+	_ = a.Bind(ctx, nil)
+
+	logic := iam.NewUserLogic(ctx)
+	res, err := logic.SetupTotp()
+	if err != nil {
+		a.Error(err)
+		return
+	}
+
+	a.Success(res, "生成成功")
+}
+
+// EnableTotp 启用两步验证
+// @Summary 启用两步验证
+// @Description 校验验证器App生成的验证码后启用两步验证，返回一次性恢复码（仅本次返回）
+// @Tags 两步验证
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body user.TotpCodeRequest true "验证码"
+// @Success 200 {object} api.Response{data=user.RecoveryCodesResponse} "启用成功"
+// @Failure 400 {object} api.Response "验证码错误或未生成密钥"
+// @Router /user/2fa/enable [post]
+func (a User) EnableTotp(ctx *gin.Context) {
+	req := &user.TotpCodeRequest{}
+	err := a.Bind(ctx, req)
+	if err != nil {
+		a.Error(err)
+		return
+	}
+
+	logic := iam.NewUserLogic(ctx)
+	res, err := logic.EnableTotp(req)
+	if err != nil {
+		a.Error(err)
+		return
+	}
+
+	a.Success(res, "启用成功")
+}
+
+// DisableTotp 关闭两步验证
+// @Summary 关闭两步验证
+// @Description 校验登录密码与验证码（或恢复码）后关闭两步验证，密钥与恢复码一并清除
+// @Tags 两步验证
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body user.DisableTotpRequest true "密码与验证码"
+// @Success 200 {object} api.Response "已关闭"
+// @Failure 400 {object} api.Response "密码或验证码错误"
+// @Router /user/2fa/disable [post]
+func (a User) DisableTotp(ctx *gin.Context) {
+	req := &user.DisableTotpRequest{}
+	err := a.Bind(ctx, req)
+	if err != nil {
+		a.Error(err)
+		return
+	}
+
+	logic := iam.NewUserLogic(ctx)
+	err = logic.DisableTotp(req)
+	if err != nil {
+		a.Error(err)
+		return
+	}
+
+	a.Success(nil, "已关闭")
+}
+
+// RegenerateRecoveryCodes 重新生成恢复码
+// @Summary 重新生成恢复码
+// @Description 校验验证码后重新生成恢复码，旧恢复码全部失效
+// @Tags 两步验证
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body user.TotpCodeRequest true "验证码"
+// @Success 200 {object} api.Response{data=user.RecoveryCodesResponse} "生成成功"
+// @Failure 400 {object} api.Response "验证码错误或未启用两步验证"
+// @Router /user/2fa/recovery-codes [post]
+func (a User) RegenerateRecoveryCodes(ctx *gin.Context) {
+	req := &user.TotpCodeRequest{}
+	err := a.Bind(ctx, req)
+	if err != nil {
+		a.Error(err)
+		return
+	}
+
+	logic := iam.NewUserLogic(ctx)
+	res, err := logic.RegenerateRecoveryCodes(req)
+	if err != nil {
+		a.Error(err)
+		return
+	}
+
+	a.Success(res, "生成成功")
+}
+
+// VerifyTwoFactor 两步验证登录
+// @Summary 两步验证登录
+// @Description 登录返回 twoFactorRequired 时，提交挑战令牌与验证码（或恢复码）完成登录；挑战令牌5分钟内有效，连续错误5次作废
+// @Tags 用户认证
+// @Accept json
+// @Produce json
+// @Param request body user.VerifyTwoFactorRequest true "挑战令牌与验证码"
+// @Success 200 {object} api.Response{data=user.LoginResponse} "登录成功"
+// @Failure 400 {object} api.Response "验证码错误或挑战令牌无效"
+// @Router /auth/2fa/verify [post]
+func (a User) VerifyTwoFactor(ctx *gin.Context) {
+	req := &user.VerifyTwoFactorRequest{}
+	err := a.Bind(ctx, req)
+	if err != nil {
+		a.Error(err)
+		return
+	}
+
+	logic := iam.NewUserLogic(ctx)
+	res, err := logic.VerifyTwoFactor(req)
+	if err != nil {
+		a.Error(err)
+		return
+	}
+
+	a.Success(res, "登录成功")
+}
@@ -6,8 +6,10 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"thinkingModels/api"
+	"thinkingModels/component/errs"
 	"thinkingModels/domain/iam/user"
 	"thinkingModels/logic/iam"
+	"thinkingModels/middleware"
 )
 
 type User struct {
@@ -18,17 +20,48 @@ func NewUser() *User {
 	return &User{}
 }
 
-// Create 创建用户（注册）
+// Register 用户注册
 // @Summary 用户注册
-// @Description 用户注册接口，创建新用户账号
+// @Description 用户自助注册，配置开启时需先通过人机验证（captchaId + captchaAnswer）；仅限邀请注册时需填写邀请码。
+// @Description 开启邮箱验证时账号处于待验证状态（verificationRequired=true），点击验证邮件中的链接后才能登录
 // @Tags 用户认证
 // @Accept json
 // @Produce json
 // @Param request body user.RegisterRequest true "注册请求参数"
-// @Success 200 {object} api.Response{data=user.UserInfo} "注册成功"
-// @Failure 400 {object} api.Response "参数错误"
+// @Success 200 {object} api.Response{data=user.RegisterResponse} "注册成功"
+// @Failure 400 {object} api.Response "参数错误、人机验证失败、邀请码无效或邮箱已被注册"
 // @Failure 409 {object} api.Response "用户名已存在"
 // @Router /auth/register [post]
+func (a User) Register(ctx *gin.Context) {
+	req := &user.RegisterRequest{}
+	err := a.Bind(ctx, req)
+	if err != nil {
+		a.Error(err)
+		return
+	}
+
+	logic := iam.NewUserLogic(ctx)
+	res, err := logic.Register(req)
+	if err != nil {
+		a.Error(err)
+		return
+	}
+
+	a.Success(res, "注册成功")
+}
+
+// Create 创建用户
+// @Summary 创建用户
+// @Description 管理员创建用户账号
+// @Tags 用户管理
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body user.RegisterRequest true "用户信息"
+// @Success 200 {object} api.Response{data=user.UserInfo} "创建成功"
+// @Failure 400 {object} api.Response "参数错误"
+// @Failure 409 {object} api.Response "用户名已存在"
+// @Router /user [post]
 func (a User) Create(ctx *gin.Context) {
 	// 参数校验
 	req := &user.RegisterRequest{}
@@ -74,11 +107,16 @@ func (a User) Update(ctx *gin.Context) {
 
 // Get 查询用户详情
 func (a User) Get(ctx *gin.Context) {
-	// TODO: 从路径参数获取id
-	// idStr := ctx.Param("id")
+	a.Ctx = ctx
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		a.Error(errs.Validation("用户ID格式无效"))
+		return
+	}
+
 	// 实例化逻辑层
 	logic := iam.NewUserLogic(ctx)
-	res, err := logic.Get(0) // TODO: 使用实际的id
+	res, err := logic.Get(id)
 	if err != nil {
 		a.Error(err)
 		return
@@ -160,14 +198,14 @@ func (a User) Info(ctx *gin.Context) {
 	// 从上下文获取当前用户ID（由JWT中间件设置）
 	userIDStr, exists := ctx.Get("currUserId")
 	if !exists {
-		a.Error(errors.New("未登录或token无效"))
+		a.Error(errs.Unauthorized("未登录或token无效"))
 		return
 	}
 
 	// 将字符串ID转换为uint64
 	userID, err := strconv.ParseUint(userIDStr.(string), 10, 64)
 	if err != nil {
-		a.Error(errors.New("用户ID格式无效"))
+		a.Error(errs.Validation("用户ID格式无效"))
 		return
 	}
 
@@ -191,8 +229,8 @@ func (a User) Info(ctx *gin.Context) {
 // @Produce json
 // @Param request body user.LoginRequest true "登录请求参数"
 // @Success 200 {object} api.Response{data=user.LoginResponse} "登录成功"
-// @Failure 400 {object} api.Response "参数错误"
-// @Failure 401 {object} api.Response "用户名或密码错误"
+// @Failure 400 {object} api.Response "参数错误或用户名密码错误"
+// @Failure 429 {object} api.Response "登录失败次数过多，已临时锁定"
 // @Router /auth/login [post]
 func (a User) Login(ctx *gin.Context) {
 	req := &user.LoginRequest{}
@@ -205,6 +243,12 @@ func (a User) Login(ctx *gin.Context) {
 	logic := iam.NewUserLogic(ctx)
 	res, err := logic.Login(req)
 	if err != nil {
+		// 登录失败时返回剩余尝试次数与需等待秒数
+		var loginErr *user.LoginFailedError
+		if errors.As(err, &loginErr) {
+			a.Error(err, loginErr)
+			return
+		}
 		a.Error(err)
 		return
 	}
@@ -214,83 +258,320 @@ func (a User) Login(ctx *gin.Context) {
 }
 
 // Logout 用户登出
+// @Summary 用户登出
+// @Description 吊销当前 Access Token 并作废其登录会话；Access Token 已过期时可通过请求体中的 Refresh Token 作废会话
+// @Tags 用户认证
+// @Accept json
+// @Produce json
+// @Param Authorization header string false "Bearer {token}"
+// @Param request body user.LogoutRequest false "登出请求参数"
+// @Success 200 {object} api.Response "登出成功"
+// @Router /auth/logout [post]
 func (a User) Logout(ctx *gin.Context) {
-	// 设置上下文
-	a.Ctx = ctx
+	// 请求体可选，忽略绑定错误
+	req := &user.LogoutRequest{}
+	_ = a.Bind(ctx, req)
 
-	// 从请求头获取Token
-	authHeader := ctx.GetHeader("Authorization")
-	if authHeader == "" {
-		a.Success(nil, "登出成功")
+	logic := iam.NewUserLogic(ctx)
+	err := logic.Logout(middleware.BearerToken(ctx), req)
+	if err != nil {
+		a.Error(err)
 		return
 	}
 
-	// 提取 token（Bearer token）
-	// 格式: Bearer <token>
-	token := ""
-	if len(authHeader) > 7 && authHeader[:7] == "Bearer " {
-		token = authHeader[7:]
-	} else {
-		token = authHeader
+	a.Success(nil, "登出成功")
+}
+
+// LogoutAll 退出全部设备
+// @Summary 退出全部设备
+// @Description 吊销当前用户此刻之前签发的全部 Access Token 与 Refresh Token
+// @Tags 用户认证
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} api.Response "已退出全部设备"
+// @Failure 401 {object} api.Response "未登录或token无效"
+// @Router /auth/logout-all [post]
+func (a User) LogoutAll(ctx *gin.Context) {
+	a.Ctx = ctx
+
+	userIDStr, exists := ctx.Get("currUserId")
+	if !exists {
+		a.Error(errs.Unauthorized("未登录或token无效"))
+		return
+	}
+	userID, err := strconv.ParseUint(userIDStr.(string), 10, 64)
+	if err != nil {
+		a.Error(errs.Validation("用户ID格式无效"))
+		return
 	}
 
-	// TODO: 将Token加入Redis黑名单，设置过期时间为token剩余有效期
-	// 这里暂时直接返回成功，实际业务中需要：
-	// 1. 解析token获取过期时间
-	// 2. 将token加入redis黑名单，key为 blacklisted_token:<token>
-	// 3. 设置过期时间为 token 剩余有效期
-	_ = token
+	logic := iam.NewUserLogic(ctx)
+	err = logic.LogoutAll(userID)
+	if err != nil {
+		a.Error(err)
+		return
+	}
 
-	a.Success(nil, "登出成功")
+	a.Success(nil, "已退出全部设备")
 }
 
 // Refresh 刷新Token
+// @Summary 刷新Token
+// @Description 使用 Refresh Token 换取新的 Token 对，旧 Refresh Token 立即失效；重复使用已失效的 Refresh Token 将撤销整个登录会话
+// @Tags 用户认证
+// @Accept json
+// @Produce json
+// @Param request body user.RefreshRequest true "刷新请求参数"
+// @Success 200 {object} api.Response{data=user.RefreshResponse} "刷新成功"
+// @Failure 401 {object} api.Response "Refresh Token无效或已撤销"
+// @Router /auth/refresh [post]
 func (a User) Refresh(ctx *gin.Context) {
-	// TODO: 实现Token刷新逻辑
-	a.Success(nil, "Token刷新成功")
+	req := &user.RefreshRequest{}
+	err := a.Bind(ctx, req)
+	if err != nil {
+		a.Error(err)
+		return
+	}
+
+	logic := iam.NewUserLogic(ctx)
+	res, err := logic.Refresh(req)
+	if err != nil {
+		a.Error(err)
+		return
+	}
+
+	a.Success(res, "Token刷新成功")
+}
+
+// ChangePassword 修改密码
+// @Summary 修改密码
+// @Description 校验原密码后设置新密码
+// @Tags 用户管理
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body user.UpdatePasswordRequest true "修改密码参数"
+// @Success 200 {object} api.Response "修改成功"
+// @Failure 400 {object} api.Response "参数错误或原密码错误"
+// @Failure 401 {object} api.Response "未登录或token无效"
+// @Router /user/password [post]
+func (a User) ChangePassword(ctx *gin.Context) {
+	req := &user.UpdatePasswordRequest{}
+	err := a.Bind(ctx, req)
+	if err != nil {
+		a.Error(err)
+		return
+	}
+
+	userIDStr, exists := ctx.Get("currUserId")
+	if !exists {
+		a.Error(errs.Unauthorized("未登录或token无效"))
+		return
+	}
+	userID, err := strconv.ParseUint(userIDStr.(string), 10, 64)
+	if err != nil {
+		a.Error(errs.Validation("用户ID格式无效"))
+		return
+	}
+
+	logic := iam.NewUserLogic(ctx)
+	err = logic.ChangePassword(userID, req)
+	if err != nil {
+		a.Error(err)
+		return
+	}
+
+	a.Success(nil, "密码修改成功")
+}
+
+// ForgotPassword 忘记密码
+// @Summary 忘记密码
+// @Description 向注册邮箱发送一次性重置链接，邮箱未注册时同样返回成功
+// @Tags 用户认证
+// @Accept json
+// @Produce json
+// @Param request body user.ForgotPasswordRequest true "忘记密码参数"
+// @Success 200 {object} api.Response "邮件已发送"
+// @Failure 400 {object} api.Response "参数错误"
+// @Router /auth/forgot-password [post]
+func (a User) ForgotPassword(ctx *gin.Context) {
+	req := &user.ForgotPasswordRequest{}
+	err := a.Bind(ctx, req)
+	if err != nil {
+		a.Error(err)
+		return
+	}
+
+	logic := iam.NewUserLogic(ctx)
+	err = logic.ForgotPassword(req)
+	if err != nil {
+		a.Error(err)
+		return
+	}
+
+	a.Success(nil, "如果该邮箱已注册，您将收到重置密码邮件")
+}
+
+// ResetPassword 重置密码
+// @Summary 重置密码
+// @Description 使用邮件中的重置令牌设置新密码，令牌一次性有效；重置后全部登录会话失效
+// @Tags 用户认证
+// @Accept json
+// @Produce json
+// @Param request body user.ResetPasswordRequest true "重置密码参数"
+// @Success 200 {object} api.Response "重置成功"
+// @Failure 400 {object} api.Response "重置链接无效或已过期"
+// @Router /auth/reset-password [post]
+func (a User) ResetPassword(ctx *gin.Context) {
+	req := &user.ResetPasswordRequest{}
+	err := a.Bind(ctx, req)
+	if err != nil {
+		a.Error(err)
+		return
+	}
+
+	logic := iam.NewUserLogic(ctx)
+	err = logic.ResetPassword(req)
+	if err != nil {
+		a.Error(err)
+		return
+	}
+
+	a.Success(nil, "密码重置成功，请重新登录")
+}
+
+// VerifyEmail 验证邮箱
+// @Summary 验证邮箱
+// @Description 使用验证邮件中的令牌验证注册邮箱，令牌一次性有效；待验证的账号随之激活
+// @Tags 用户认证
+// @Accept json
+// @Produce json
+// @Param request body user.VerifyEmailRequest true "验证参数"
+// @Success 200 {object} api.Response "验证成功"
+// @Failure 400 {object} api.Response "验证链接无效或已过期"
+// @Router /auth/verify-email [post]
+func (a User) VerifyEmail(ctx *gin.Context) {
+	req := &user.VerifyEmailRequest{}
+	err := a.Bind(ctx, req)
+	if err != nil {
+		a.Error(err)
+		return
+	}
+
+	logic := iam.NewUserLogic(ctx)
+	err = logic.VerifyEmail(req)
+	if err != nil {
+		a.Error(err)
+		return
+	}
+
+	a.Success(nil, "邮箱验证成功，请登录")
+}
+
+// ResendVerification 重新发送验证邮件
+// @Summary 重新发送验证邮件
+// @Description 向待验证账号的注册邮箱重新发送验证链接（之前的链接失效），邮箱未注册或已验证时同样返回成功；同一邮箱每小时最多5次
+// @Tags 用户认证
+// @Accept json
+// @Produce json
+// @Param request body user.ResendVerificationRequest true "邮箱"
+// @Success 200 {object} api.Response "邮件已发送"
+// @Failure 400 {object} api.Response "参数错误或发送过于频繁"
+// @Router /auth/resend-verification [post]
+func (a User) ResendVerification(ctx *gin.Context) {
+	req := &user.ResendVerificationRequest{}
+	err := a.Bind(ctx, req)
+	if err != nil {
+		a.Error(err)
+		return
+	}
+
+	logic := iam.NewUserLogic(ctx)
+	err = logic.ResendVerification(req)
+	if err != nil {
+		a.Error(err)
+		return
+	}
+
+	a.Success(nil, "如果该邮箱待验证，您将收到验证邮件")
+}
+
+// UnlockLogin 解除登录锁定
+// @Summary 解除登录锁定
+// @Description 清除用户名和/或IP的登录失败记录与锁定状态
+// @Tags 用户管理
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body user.UnlockLoginRequest true "解锁参数"
+// @Success 200 {object} api.Response "解锁成功"
+// @Failure 403 {object} api.Response "无权限"
+// @Router /user/unlock [post]
+func (a User) UnlockLogin(ctx *gin.Context) {
+	req := &user.UnlockLoginRequest{}
+	err := a.Bind(ctx, req)
+	if err != nil {
+		a.Error(err)
+		return
+	}
+
+	logic := iam.NewUserLogic(ctx)
+	err = logic.UnlockLogin(req)
+	if err != nil {
+		a.Error(err)
+		return
+	}
+
+	a.Success(nil, "解锁成功")
 }
 
 // Codes 获取用户权限码列表
+// @Summary 获取当前用户权限码
+// @Description 根据当前用户的角色计算权限码，超级管理员返回全部权限码
+// @Tags 用户认证
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} api.Response{data=[]string} "获取成功"
+// @Router /auth/codes [get]
 func (a User) Codes(ctx *gin.Context) {
 	// 设置上下文
 	a.Ctx = ctx
 
-	// 从上下文获取当前用户角色信息
-	roleIds, exists := ctx.Get("currRoleIds")
-	if !exists {
-		// 如果没有角色信息，返回空数组
-		a.Success([]string{}, "获取成功")
-		return
-	}
-
-	// 根据角色ID解析权限码
-	// 这里可以根据实际业务从数据库查询权限码
-	// 简单实现：默认返回一些常用权限码
-	codes := []string{
-		"ACCOUNT",
-		"ACCOUNT_ADD",
-		"ACCOUNT_EDIT",
-		"ACCOUNT_DELETE",
-		"ACCOUNT_VIEW",
-		"ROLE",
-		"ROLE_ADD",
-		"ROLE_EDIT",
-		"ROLE_DELETE",
-		"ROLE_VIEW",
-		"DICT",
-		"DICT_ADD",
-		"DICT_EDIT",
-		"DICT_DELETE",
-		"DICT_VIEW",
-	}
-
-	// 如果有特定角色，可以添加更多权限码
-	if roleIdsStr, ok := roleIds.(string); ok && roleIdsStr != "" {
-		// 管理员角色拥有所有权限
-		if roleIdsStr == "1" || roleIdsStr == "admin" {
-			codes = append(codes, "ADMIN", "SYSTEM", "SYSTEM_SETTING")
-		}
+	logic := iam.NewUserLogic(ctx)
+	codes, err := logic.Codes(ctx.GetString("currRoleIds"))
+	if err != nil {
+		a.Error(err)
+		return
 	}
 
 	a.Success(codes, "获取成功")
 }
+
+// AssignRoles 为用户分配角色
+// @Summary 为用户分配角色（全量覆盖）
+// @Description 新角色在用户下次登录或刷新Token后生效
+// @Tags 用户管理
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body user.AssignUserRoles true "分配角色请求参数"
+// @Success 200 {object} api.Response{data=user.UserInfo} "分配成功"
+// @Router /user/roles [post]
+func (a User) AssignRoles(ctx *gin.Context) {
+	req := &user.AssignUserRoles{}
+	err := a.Bind(ctx, req)
+	if err != nil {
+		a.Error(err)
+		return
+	}
+
+	logic := iam.NewUserLogic(ctx)
+	res, err := logic.AssignRoles(req)
+	if err != nil {
+		a.Error(err)
+		return
+	}
+
+	a.Success(res, "分配成功")
+}
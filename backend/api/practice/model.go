@@ -222,6 +222,36 @@ func (a *Model) Unpublish(ctx *gin.Context) {
 	a.Success(res, "下架成功")
 }
 
+// Share 设置思维模型跨企业共享
+// @Summary 设置思维模型共享
+// @Description 已发布模型设为共享后对所有企业可见（只读）
+// @Tags 思维模型
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body model.ShareModel true "共享请求参数"
+// @Success 200 {object} api.Response{data=model.ModelInfo} "设置成功"
+// @Failure 400 {object} api.Response "参数错误"
+// @Failure 401 {object} api.Response "未登录"
+// @Failure 403 {object} api.Response "无权限"
+// @Router /thinking/model/share [post]
+func (a *Model) Share(ctx *gin.Context) {
+	a.Ctx = ctx
+	req := &model.ShareModel{}
+	if err := a.Bind(ctx, req); err != nil {
+		a.Error(err)
+		return
+	}
+
+	logic := thinking.NewModelLogic(ctx)
+	res, err := logic.Share(req)
+	if err != nil {
+		a.Error(err)
+		return
+	}
+	a.Success(res, "设置成功")
+}
+
 // Fork 引用创建思维模型
 func (a *Model) Fork(ctx *gin.Context) {
 	a.Ctx = ctx
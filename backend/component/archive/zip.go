@@ -0,0 +1,83 @@
+package archive
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+)
+
+// ========== ZIP 归档 ==========
+// 用于导出数据：按路径写入文件，同名文件自动追加序号，文件名中的非法字符会被替换。
+
+// maxNameRunes 单个文件名（不含扩展名）的最大字符数
+const maxNameRunes = 60
+
+// Writer ZIP 写入器
+type Writer struct {
+	zw    *zip.Writer
+	names map[string]int
+	mod   time.Time
+}
+
+// NewWriter 创建写入器，文件修改时间统一为创建时间
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{zw: zip.NewWriter(w), names: map[string]int{}, mod: time.Now()}
+}
+
+// AddFile 写入文件，name 为归档内路径（以 / 分隔）
+func (w *Writer) AddFile(name string, data []byte) error {
+	name = w.unique(name)
+	f, err := w.zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: w.mod})
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(data)
+	return err
+}
+
+// AddJSON 以缩进格式写入 JSON 文件
+func (w *Writer) AddJSON(name string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return w.AddFile(name, data)
+}
+
+// Close 写入目录并结束归档
+func (w *Writer) Close() error {
+	return w.zw.Close()
+}
+
+// unique 同名文件追加序号，如 a.md、a-2.md
+func (w *Writer) unique(name string) string {
+	w.names[name]++
+	n := w.names[name]
+	if n == 1 {
+		return name
+	}
+	ext := path.Ext(name)
+	return w.unique(fmt.Sprintf("%s-%d%s", strings.TrimSuffix(name, ext), n, ext))
+}
+
+// FileName 由多个片段拼接出安全的文件名（不含扩展名），如 FileName("12", "我的课题")
+func FileName(parts ...string) string {
+	name := strings.Join(parts, "-")
+	name = strings.Map(func(r rune) rune {
+		if r < 0x20 || strings.ContainsRune(`/\:*?"<>|`, r) {
+			return '_'
+		}
+		return r
+	}, strings.TrimSpace(name))
+	if runes := []rune(name); len(runes) > maxNameRunes {
+		name = string(runes[:maxNameRunes])
+	}
+	if name == "" || name == "." || name == ".." {
+		name = "_"
+	}
+	return name
+}
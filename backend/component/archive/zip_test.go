@@ -0,0 +1,42 @@
+package archive
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	assert.Nil(t, w.AddJSON("data.json", map[string]int{"a": 1}))
+	assert.Nil(t, w.AddFile("topics/a.md", []byte("# A")))
+	assert.Nil(t, w.AddFile("topics/a.md", []byte("# A2")))
+	assert.Nil(t, w.AddFile("topics/a-2.md", []byte("# A3")))
+	assert.Nil(t, w.Close())
+
+	r, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	assert.Nil(t, err)
+	files := map[string]string{}
+	for _, f := range r.File {
+		rc, err := f.Open()
+		assert.Nil(t, err)
+		data, _ := io.ReadAll(rc)
+		rc.Close()
+		files[f.Name] = string(data)
+	}
+	assert.Equal(t, "{\n  \"a\": 1\n}", files["data.json"])
+	assert.Equal(t, "# A", files["topics/a.md"])
+	assert.Equal(t, "# A2", files["topics/a-2.md"])
+	assert.Equal(t, "# A3", files["topics/a-2-2.md"])
+}
+
+func TestFileName(t *testing.T) {
+	assert.Equal(t, "12-a_b_c", FileName("12", "a/b:c"))
+	assert.Equal(t, "_", FileName(""))
+	assert.Equal(t, "_", FileName(".."))
+	assert.Len(t, []rune(FileName(string(make([]rune, 100)))), maxNameRunes)
+}
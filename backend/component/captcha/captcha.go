@@ -0,0 +1,274 @@
+package captcha
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"math/rand/v2"
+	"strings"
+)
+
+// ========== 人机验证码 ==========
+// 生成滑块拼图与图形点选两种挑战，图片以 PNG data URI 返回给前端；
+// 正确答案(Answer)只保存在服务端，客户端提交(Submission)后用 Answer.Verify 校验。
+
+const (
+	KindSlider = "slider" // 滑块拼图：把拼图拖到缺口位置
+	KindPoint  = "point"  // 图形点选：按提示依次点击图形
+
+	Width        = 320 // 图片宽度
+	SliderHeight = 160 // 滑块图片高度
+	PointHeight  = 200 // 点选图片高度
+	PieceSize    = 42  // 拼图边长
+	ShapeRadius  = 18  // 点选图形半径
+
+	pointTargets = 3 // 需要依次点击的图形数量
+)
+
+var ErrKindInvalid = errors.New("不支持的验证码类型")
+
+// Point 图片坐标（像素，原点为左上角）
+type Point struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+// Challenge 下发给客户端的挑战（不含答案）
+type Challenge struct {
+	Kind      string `json:"kind"`                // slider | point
+	Image     string `json:"image"`               // 背景图 data URI
+	Width     int    `json:"width"`               // 背景图宽度
+	Height    int    `json:"height"`              // 背景图高度
+	Piece     string `json:"piece,omitempty"`     // 滑块：拼图 data URI
+	PieceY    int    `json:"pieceY,omitempty"`    // 滑块：拼图纵坐标
+	PieceSize int    `json:"pieceSize,omitempty"` // 滑块：拼图边长
+	HintText  string `json:"hintText,omitempty"`  // 点选：点击提示
+}
+
+// Answer 服务端保存的正确答案
+type Answer struct {
+	Kind   string  `json:"kind"`
+	X      int     `json:"x,omitempty"`      // 滑块：缺口横坐标
+	Points []Point `json:"points,omitempty"` // 点选：依次点击的图形中心
+}
+
+// Submission 客户端提交的答案
+type Submission struct {
+	X      int     `json:"x"`      // 滑块：拼图左侧偏移
+	Points []Point `json:"points"` // 点选：依次点击的坐标
+}
+
+// New 按类型生成挑战与答案，类型为空时使用滑块
+func New(kind string) (*Challenge, *Answer, error) {
+	switch kind {
+	case KindSlider, "":
+		return NewSlider()
+	case KindPoint:
+		return NewPoint()
+	}
+	return nil, nil, ErrKindInvalid
+}
+
+// Verify 校验提交的答案，tolerance 为允许的像素误差
+func (a *Answer) Verify(s *Submission, tolerance int) bool {
+	if s == nil {
+		return false
+	}
+	switch a.Kind {
+	case KindSlider:
+		return abs(s.X-a.X) <= tolerance
+	case KindPoint:
+		if len(s.Points) != len(a.Points) {
+			return false
+		}
+		r := ShapeRadius + tolerance
+		for i, p := range a.Points {
+			dx, dy := s.Points[i].X-p.X, s.Points[i].Y-p.Y
+			if dx*dx+dy*dy > r*r {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// NewSlider 生成滑块拼图：背景图中挖出缺口，拼图为缺口处的原图
+func NewSlider() (*Challenge, *Answer, error) {
+	bg := background(Width, SliderHeight)
+	x := PieceSize + 10 + rand.IntN(Width-2*PieceSize-20)
+	y := 10 + rand.IntN(SliderHeight-PieceSize-20)
+	rect := image.Rect(x, y, x+PieceSize, y+PieceSize)
+
+	// 拼图：复制缺口区域并描白边
+	piece := image.NewRGBA(image.Rect(0, 0, PieceSize, PieceSize))
+	draw.Draw(piece, piece.Bounds(), bg, rect.Min, draw.Src)
+	for i := 0; i < PieceSize; i++ {
+		for _, p := range []image.Point{{i, 0}, {i, 1}, {i, PieceSize - 1}, {i, PieceSize - 2}, {0, i}, {1, i}, {PieceSize - 1, i}, {PieceSize - 2, i}} {
+			piece.Set(p.X, p.Y, color.White)
+		}
+	}
+
+	// 缺口：压暗原区域
+	draw.Draw(bg, rect, image.NewUniform(color.RGBA{A: 150}), image.Point{}, draw.Over)
+
+	bgUri, err := dataUri(bg)
+	if err != nil {
+		return nil, nil, err
+	}
+	pieceUri, err := dataUri(piece)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &Challenge{
+		Kind:      KindSlider,
+		Image:     bgUri,
+		Width:     Width,
+		Height:    SliderHeight,
+		Piece:     pieceUri,
+		PieceY:    y,
+		PieceSize: PieceSize,
+	}, &Answer{Kind: KindSlider, X: x}, nil
+}
+
+// NewPoint 生成图形点选：随机摆放全部图形，要求依次点击其中几个
+func NewPoint() (*Challenge, *Answer, error) {
+	bg := background(Width, PointHeight)
+	order := rand.Perm(len(shapes))
+	centers := make([]Point, 0, len(shapes))
+	for range shapes {
+		centers = append(centers, freePosition(centers))
+	}
+	for i, shapeIndex := range order {
+		fillShape(bg, shapes[shapeIndex].inside, centers[i], randomColor(0, 140))
+	}
+
+	names := make([]string, 0, pointTargets)
+	targets := make([]Point, 0, pointTargets)
+	for i := 0; i < pointTargets; i++ {
+		names = append(names, shapes[order[i]].name)
+		targets = append(targets, centers[i])
+	}
+	// 点击顺序与摆放顺序无关
+	rand.Shuffle(pointTargets, func(i, j int) {
+		names[i], names[j] = names[j], names[i]
+		targets[i], targets[j] = targets[j], targets[i]
+	})
+
+	bgUri, err := dataUri(bg)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &Challenge{
+		Kind:     KindPoint,
+		Image:    bgUri,
+		Width:    Width,
+		Height:   PointHeight,
+		HintText: "请依次点击：" + strings.Join(names, "、"),
+	}, &Answer{Kind: KindPoint, Points: targets}, nil
+}
+
+// shapes 点选图形，inside 判断相对中心的偏移是否落在图形内
+var shapes = []struct {
+	name   string
+	inside func(dx, dy, r int) bool
+}{
+	{"圆形", func(dx, dy, r int) bool { return dx*dx+dy*dy <= r*r }},
+	{"正方形", func(dx, dy, r int) bool { return abs(dx)*10 <= r*8 && abs(dy)*10 <= r*8 }},
+	{"三角形", func(dx, dy, r int) bool { return dy*10 <= r*7 && dy >= -r && abs(dx)*17 <= (dy+r)*10 }},
+	{"菱形", func(dx, dy, r int) bool { return abs(dx)+abs(dy) <= r }},
+	{"十字", func(dx, dy, r int) bool { return (abs(dx)*3 <= r && abs(dy) <= r) || (abs(dy)*3 <= r && abs(dx) <= r) }},
+}
+
+// freePosition 随机选取与已有图形不重叠的中心点
+func freePosition(used []Point) Point {
+	margin := ShapeRadius + 4
+	var p Point
+	for try := 0; try < 100; try++ {
+		p = Point{X: margin + rand.IntN(Width-2*margin), Y: margin + rand.IntN(PointHeight-2*margin)}
+		ok := true
+		for _, u := range used {
+			dx, dy := p.X-u.X, p.Y-u.Y
+			if dx*dx+dy*dy < (3*ShapeRadius)*(3*ShapeRadius) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			break
+		}
+	}
+	return p
+}
+
+// fillShape 以 center 为中心填充图形
+func fillShape(img *image.RGBA, inside func(dx, dy, r int) bool, center Point, c color.RGBA) {
+	for dy := -ShapeRadius; dy <= ShapeRadius; dy++ {
+		for dx := -ShapeRadius; dx <= ShapeRadius; dx++ {
+			if inside(dx, dy, ShapeRadius) {
+				img.SetRGBA(center.X+dx, center.Y+dy, c)
+			}
+		}
+	}
+}
+
+// background 随机渐变底色加干扰圆点
+func background(w, h int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	from, to := randomColor(150, 255), randomColor(150, 255)
+	for x := 0; x < w; x++ {
+		c := color.RGBA{
+			R: lerp(from.R, to.R, x, w),
+			G: lerp(from.G, to.G, x, w),
+			B: lerp(from.B, to.B, x, w),
+			A: 255,
+		}
+		for y := 0; y < h; y++ {
+			img.SetRGBA(x, y, c)
+		}
+	}
+	for i := 0; i < 40; i++ {
+		c := randomColor(80, 230)
+		c.A = 120
+		cx, cy, r := rand.IntN(w), rand.IntN(h), 3+rand.IntN(10)
+		for dy := -r; dy <= r; dy++ {
+			for dx := -r; dx <= r; dx++ {
+				if dx*dx+dy*dy <= r*r && image.Pt(cx+dx, cy+dy).In(img.Rect) {
+					draw.Draw(img, image.Rect(cx+dx, cy+dy, cx+dx+1, cy+dy+1), image.NewUniform(c), image.Point{}, draw.Over)
+				}
+			}
+		}
+	}
+	return img
+}
+
+// randomColor 各通道取值在 [min, max) 之间的不透明颜色
+func randomColor(min, max int) color.RGBA {
+	channel := func() uint8 { return uint8(min + rand.IntN(max-min)) }
+	return color.RGBA{R: channel(), G: channel(), B: channel(), A: 255}
+}
+
+// lerp 按 i/n 在 a、b 之间线性插值
+func lerp(a, b uint8, i, n int) uint8 {
+	return uint8(int(a) + (int(b)-int(a))*i/n)
+}
+
+// dataUri 编码为 PNG data URI
+func dataUri(img image.Image) (string, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return "", err
+	}
+	return "data:image/png;base64," + base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
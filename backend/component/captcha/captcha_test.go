@@ -0,0 +1,65 @@
+package captcha
+
+import (
+	"encoding/base64"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// decodeImage 解析 data URI 中的 PNG，返回宽高
+func decodeImage(t *testing.T, uri string) (int, int) {
+	assert.True(t, strings.HasPrefix(uri, "data:image/png;base64,"))
+	data, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(uri, "data:image/png;base64,"))
+	assert.Nil(t, err)
+	img, err := png.Decode(strings.NewReader(string(data)))
+	assert.Nil(t, err)
+	return img.Bounds().Dx(), img.Bounds().Dy()
+}
+
+func TestSlider(t *testing.T) {
+	challenge, answer, err := New(KindSlider)
+	assert.Nil(t, err)
+	assert.Equal(t, KindSlider, challenge.Kind)
+
+	w, h := decodeImage(t, challenge.Image)
+	assert.Equal(t, []int{Width, SliderHeight}, []int{w, h})
+	w, h = decodeImage(t, challenge.Piece)
+	assert.Equal(t, []int{PieceSize, PieceSize}, []int{w, h})
+	assert.True(t, answer.X >= PieceSize && answer.X+PieceSize <= Width)
+	assert.True(t, challenge.PieceY >= 0 && challenge.PieceY+PieceSize <= SliderHeight)
+
+	assert.True(t, answer.Verify(&Submission{X: answer.X + 3}, 5))
+	assert.False(t, answer.Verify(&Submission{X: answer.X + 6}, 5))
+	assert.False(t, answer.Verify(nil, 5))
+}
+
+func TestPoint(t *testing.T) {
+	challenge, answer, err := New(KindPoint)
+	assert.Nil(t, err)
+	assert.Equal(t, KindPoint, challenge.Kind)
+	assert.Len(t, answer.Points, pointTargets)
+	assert.Equal(t, pointTargets, strings.Count(challenge.HintText, "、")+1)
+
+	w, h := decodeImage(t, challenge.Image)
+	assert.Equal(t, []int{Width, PointHeight}, []int{w, h})
+
+	// 在图形范围内点击即可
+	clicks := make([]Point, 0, len(answer.Points))
+	for _, p := range answer.Points {
+		clicks = append(clicks, Point{X: p.X + ShapeRadius/2, Y: p.Y - ShapeRadius/2})
+	}
+	assert.True(t, answer.Verify(&Submission{Points: clicks}, 5))
+
+	// 顺序错误、数量不符
+	reversed := []Point{clicks[2], clicks[1], clicks[0]}
+	assert.False(t, answer.Verify(&Submission{Points: reversed}, 5))
+	assert.False(t, answer.Verify(&Submission{Points: clicks[:2]}, 5))
+}
+
+func TestNew_InvalidKind(t *testing.T) {
+	_, _, err := New("audio")
+	assert.ErrorIs(t, err, ErrKindInvalid)
+}
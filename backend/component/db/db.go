@@ -4,8 +4,10 @@ import (
 	"context"
 	"database/sql"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"thinkingModels/component/metrics"
 	"thinkingModels/config"
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
@@ -13,49 +15,91 @@ import (
 )
 
 var (
-	globalDB *gorm.DB
-	once     sync.Once
+	globalDB atomic.Pointer[gorm.DB]
+	mu       sync.Mutex
 )
 
 func InitDb() *gorm.DB {
-	once.Do(func() { // 关键：保证只初始化一次
-		config := config.Config.Mysql
-		sqlDB, err := sql.Open("mysql", config.DbSource)
-		if err != nil {
-			panic("数据库连接失败:" + err.Error())
-		}
+	if db := globalDB.Load(); db != nil {
+		return db
+	}
+
+	// 首次使用时连接，保证只初始化一次；连接失败时 panic 且不缓存结果，数据库恢复后重新连接，无需重启服务
+	mu.Lock()
+	defer mu.Unlock()
+	if globalDB.Load() == nil {
+		globalDB.Store(connect())
+	}
+	return globalDB.Load()
+}
+
+// connect 建立连接池，失败时 panic
+func connect() *gorm.DB {
+	slowQuery := config.Config.Log.SlowQuery
+	config := config.Config.Mysql
+	sqlDB, err := sql.Open("mysql", config.DbSource)
+	if err != nil {
+		panic("数据库连接失败:" + err.Error())
+	}
+
+	// 验证数据库实际连通性（重要！）
+	if err = sqlDB.Ping(); err != nil {
+		sqlDB.Close()
+		panic("数据库连接失败（Ping）: " + err.Error())
+	}
 
-		// 验证数据库实际连通性（重要！）
-		if err = sqlDB.Ping(); err != nil {
-			panic("数据库连接失败（Ping）: " + err.Error())
-		}
+	// 连接池参数（见 config.yaml 的 mysql 配置）
+	sqlDB.SetMaxIdleConns(config.MaxIdleConns)                                    // 空闲连接数（建议为CPU核数*2）
+	sqlDB.SetMaxOpenConns(config.MaxOpenConns)                                    // 最大打开连接数（不超过数据库max_connections）
+	sqlDB.SetConnMaxLifetime(time.Duration(config.ConnMaxLifetime) * time.Second) // 略小于数据库wait_timeout（默认28800秒）
 
-		// 连接池参数优化（根据数据库配置调整）
-		sqlDB.SetMaxIdleConns(50)                     // 空闲连接数（建议为CPU核数*2）
-		sqlDB.SetMaxOpenConns(100)                    // 最大打开连接数（不超过数据库max_connections）
-		sqlDB.SetConnMaxLifetime(28700 * time.Second) // 略小于数据库wait_timeout（默认28800秒）
+	// 初始化GORM
+	Db, err := gorm.Open(
+		mysql.New(mysql.Config{Conn: sqlDB}),
+		&gorm.Config{
+			NamingStrategy: schema.NamingStrategy{SingularTable: true},
+			Logger:         newSqlLogger(slowQuery),
+		},
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	// 租户隔离
+	if err = Db.Use(&TenantPlugin{}); err != nil {
+		panic(err)
+	}
 
-		// 初始化GORM
-		Db, err := gorm.Open(
-			mysql.New(mysql.Config{Conn: sqlDB}),
-			&gorm.Config{
-				NamingStrategy: schema.NamingStrategy{SingularTable: true},
-			},
-		)
-		if err != nil {
-			panic(err)
-		}
+	// 语句耗时与连接池统计
+	if err = Db.Use(&MetricsPlugin{}); err != nil {
+		panic(err)
+	}
+	metrics.RegisterDb(sqlDB)
 
-		// 重新初始化db的context
-		globalDB = Db.WithContext(context.Background())
-	})
+	// 重新初始化db的context
+	return Db.WithContext(context.Background())
+}
 
-	// 兜底判断
-	if globalDB == nil {
-		panic("数据库连接失败,请检查数据库链接配置")
+// Ping 检查数据库连通性（就绪探针）
+func Ping(ctx context.Context) error {
+	sqlDB, err := InitDb().DB()
+	if err != nil {
+		return err
 	}
+	return sqlDB.PingContext(ctx)
+}
 
-	return globalDB
+// Close 关闭连接池（优雅退出时调用，未初始化时忽略）
+func Close() error {
+	db := globalDB.Load()
+	if db == nil {
+		return nil
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
 }
 
 // 清除分页和偏移量
@@ -0,0 +1,76 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"gorm.io/gorm"
+	gormLogger "gorm.io/gorm/logger"
+	"thinkingModels/component/logger"
+)
+
+// sqlLogger GORM 日志：慢查询记为 warn、执行出错记为 error（记录不存在除外），均带请求的 trace id
+type sqlLogger struct {
+	slowThreshold time.Duration // 0 表示不记录慢查询
+	level         gormLogger.LogLevel
+}
+
+func newSqlLogger(slowMs int) *sqlLogger {
+	return &sqlLogger{slowThreshold: time.Duration(slowMs) * time.Millisecond, level: gormLogger.Warn}
+}
+
+// LogMode 设置日志级别（db.Debug() 时为 Info，记录全部 SQL）
+func (l *sqlLogger) LogMode(level gormLogger.LogLevel) gormLogger.Interface {
+	clone := *l
+	clone.level = level
+	return &clone
+}
+
+func (l *sqlLogger) Info(ctx context.Context, msg string, args ...any) {
+	if l.level >= gormLogger.Info {
+		logger.Ctx(ctx).InfoContext(ctx, fmt.Sprintf(msg, args...))
+	}
+}
+
+func (l *sqlLogger) Warn(ctx context.Context, msg string, args ...any) {
+	if l.level >= gormLogger.Warn {
+		logger.Ctx(ctx).WarnContext(ctx, fmt.Sprintf(msg, args...))
+	}
+}
+
+func (l *sqlLogger) Error(ctx context.Context, msg string, args ...any) {
+	if l.level >= gormLogger.Error {
+		logger.Ctx(ctx).ErrorContext(ctx, fmt.Sprintf(msg, args...))
+	}
+}
+
+// Trace 每条 SQL 执行后调用
+func (l *sqlLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	if l.level <= gormLogger.Silent {
+		return
+	}
+
+	elapsed := time.Since(begin)
+	var level slog.Level
+	var msg string
+	switch {
+	case err != nil && !errors.Is(err, gorm.ErrRecordNotFound) && l.level >= gormLogger.Error:
+		level, msg = slog.LevelError, "sql error"
+	case l.slowThreshold > 0 && elapsed >= l.slowThreshold && l.level >= gormLogger.Warn:
+		level, msg = slog.LevelWarn, "slow sql"
+	case l.level >= gormLogger.Info:
+		level, msg = slog.LevelInfo, "sql"
+	default:
+		return
+	}
+
+	sql, rows := fc()
+	attrs := []slog.Attr{slog.Duration("elapsed", elapsed), slog.Int64("rows", rows), slog.String("sql", sql)}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+	logger.Ctx(ctx).LogAttrs(ctx, level, msg, attrs...)
+}
@@ -0,0 +1,77 @@
+package db
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"thinkingModels/component/metrics"
+)
+
+// 语句耗时统计
+// 在各类回调前后计时，按操作类型、表名与结果（ok/not_found/error）记录到 tm_db_query_duration_seconds
+
+const metricsStartKey = "metrics:start"
+
+// MetricsPlugin 语句耗时插件
+type MetricsPlugin struct{}
+
+// Name 插件名
+func (p *MetricsPlugin) Name() string {
+	return "metrics"
+}
+
+// Initialize 注册回调
+func (p *MetricsPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	processors := []struct {
+		operation string
+		before    func(name string, fn func(*gorm.DB)) error
+		after     func(name string, fn func(*gorm.DB)) error
+	}{
+		{"create", cb.Create().Before("*").Register, cb.Create().After("*").Register},
+		{"query", cb.Query().Before("*").Register, cb.Query().After("*").Register},
+		{"update", cb.Update().Before("*").Register, cb.Update().After("*").Register},
+		{"delete", cb.Delete().Before("*").Register, cb.Delete().After("*").Register},
+		{"row", cb.Row().Before("*").Register, cb.Row().After("*").Register},
+		{"raw", cb.Raw().Before("*").Register, cb.Raw().After("*").Register},
+	}
+	for _, proc := range processors {
+		if err := proc.before("metrics:before_"+proc.operation, p.start); err != nil {
+			return err
+		}
+		if err := proc.after("metrics:after_"+proc.operation, p.observe(proc.operation)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// start 记录开始时间
+func (p *MetricsPlugin) start(tx *gorm.DB) {
+	tx.InstanceSet(metricsStartKey, time.Now())
+}
+
+// observe 记录耗时
+func (p *MetricsPlugin) observe(operation string) func(*gorm.DB) {
+	return func(tx *gorm.DB) {
+		value, ok := tx.InstanceGet(metricsStartKey)
+		if !ok {
+			return
+		}
+		start, _ := value.(time.Time)
+
+		table := tx.Statement.Table
+		if table == "" {
+			table = "unknown"
+		}
+		status := "ok"
+		switch {
+		case errors.Is(tx.Error, gorm.ErrRecordNotFound):
+			status = "not_found"
+		case tx.Error != nil:
+			status = "error"
+		}
+		metrics.DbQueryDuration.WithLabelValues(operation, table, status).Observe(time.Since(start).Seconds())
+	}
+}
@@ -0,0 +1,156 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 多租户隔离
+// 实体包含 enterprise_id 字段即视为租户数据：查询/更新/删除自动追加企业过滤，创建时自动写入当前企业
+// 实体同时包含 is_shared 字段时，is_shared=1 的数据对所有企业只读可见（如官方模型、公共分类）
+// 企业ID取自请求上下文中的 currEnterpriseId（由鉴权中间件写入），未登录请求视为企业0
+// 非请求上下文（如后台任务）不做过滤
+
+const (
+	tenantColumn    = "enterprise_id"
+	sharedColumn    = "is_shared"
+	ignoreTenantKey = "tenant:ignore"
+)
+
+var ErrTenantUpsert = errors.New("租户数据不支持冲突更新写入")
+
+// CtxDb 返回携带请求上下文的数据库连接，租户隔离依赖该上下文
+func CtxDb(ctx *gin.Context) *gorm.DB {
+	if ctx == nil {
+		return InitDb()
+	}
+	return InitDb().WithContext(ctx)
+}
+
+// IgnoreTenant 跳过租户隔离（仅限系统级任务或平台管理员显式使用）
+func IgnoreTenant(tx *gorm.DB) *gorm.DB {
+	return tx.Set(ignoreTenantKey, true)
+}
+
+// TenantPlugin 租户隔离插件
+type TenantPlugin struct{}
+
+// Name 插件名
+func (p *TenantPlugin) Name() string {
+	return "tenant"
+}
+
+// Initialize 注册回调
+func (p *TenantPlugin) Initialize(db *gorm.DB) error {
+	if err := db.Callback().Create().Before("gorm:create").Register("tenant:create", p.stamp); err != nil {
+		return err
+	}
+	if err := db.Callback().Query().Before("gorm:query").Register("tenant:query", p.filterRead); err != nil {
+		return err
+	}
+	if err := db.Callback().Row().Before("gorm:row").Register("tenant:row", p.filterRead); err != nil {
+		return err
+	}
+	if err := db.Callback().Update().Before("gorm:update").Register("tenant:update", p.filterWrite); err != nil {
+		return err
+	}
+	return db.Callback().Delete().Before("gorm:delete").Register("tenant:delete", p.filterWrite)
+}
+
+// stamp 创建时写入当前企业ID
+func (p *TenantPlugin) stamp(tx *gorm.DB) {
+	enterpriseId, ok := p.scope(tx)
+	if !ok {
+		return
+	}
+	// 冲突更新会覆盖其他企业的同主键数据，租户表禁止使用
+	if _, exists := tx.Statement.Clauses["ON CONFLICT"]; exists {
+		tx.AddError(ErrTenantUpsert)
+		return
+	}
+	tx.Statement.SetColumn(tenantColumn, enterpriseId, true)
+}
+
+// filterRead 查询仅返回本企业数据及共享数据
+func (p *TenantPlugin) filterRead(tx *gorm.DB) {
+	enterpriseId, ok := p.scope(tx)
+	if !ok {
+		return
+	}
+	stmt := tx.Statement
+	tenant := stmt.Quote(clause.Column{Table: p.table(stmt), Name: tenantColumn})
+	if stmt.Schema.LookUpField(sharedColumn) != nil {
+		shared := stmt.Quote(clause.Column{Table: p.table(stmt), Name: sharedColumn})
+		stmt.AddClause(clause.Where{Exprs: []clause.Expression{
+			clause.Expr{SQL: "(" + tenant + " = ? OR " + shared + " = ?)", Vars: []any{enterpriseId, 1}},
+		}})
+		return
+	}
+	stmt.AddClause(clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: tenant + " = ?", Vars: []any{enterpriseId}}}})
+}
+
+// filterWrite 更新/删除仅作用于本企业数据，共享数据不可跨企业修改
+func (p *TenantPlugin) filterWrite(tx *gorm.DB) {
+	enterpriseId, ok := p.scope(tx)
+	if !ok {
+		return
+	}
+	stmt := tx.Statement
+	tenant := stmt.Quote(clause.Column{Table: p.table(stmt), Name: tenantColumn})
+	stmt.AddClause(clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: tenant + " = ?", Vars: []any{enterpriseId}}}})
+}
+
+// scope 判断当前语句是否需要租户隔离，并返回企业ID
+func (p *TenantPlugin) scope(tx *gorm.DB) (uint64, bool) {
+	if tx.Error != nil || tx.Statement.Schema == nil || tx.Statement.Schema.LookUpField(tenantColumn) == nil {
+		return 0, false
+	}
+	if ignore, ok := tx.Get(ignoreTenantKey); ok && ignore == true {
+		return 0, false
+	}
+	return EnterpriseIdFromContext(tx.Statement.Context)
+}
+
+// table 当前语句的表名
+func (p *TenantPlugin) table(stmt *gorm.Statement) string {
+	if stmt.Table != "" {
+		return stmt.Table
+	}
+	return stmt.Schema.Table
+}
+
+// TenantScope 手动追加企业过滤，用于结果集不是实体、租户插件无法识别的查询（如聚合统计）
+// shared 为 true 时同时包含共享数据；非请求上下文不做过滤
+func TenantScope(ctx *gin.Context, shared bool) func(*gorm.DB) *gorm.DB {
+	return func(tx *gorm.DB) *gorm.DB {
+		if ctx == nil {
+			return tx
+		}
+		enterpriseId, ok := EnterpriseIdFromContext(ctx)
+		if !ok {
+			return tx
+		}
+		if shared {
+			return tx.Where("("+tenantColumn+" = ? OR "+sharedColumn+" = 1)", enterpriseId)
+		}
+		return tx.Where(tenantColumn+" = ?", enterpriseId)
+	}
+}
+
+// EnterpriseIdFromContext 从请求上下文解析企业ID，非请求上下文返回 false
+func EnterpriseIdFromContext(ctx context.Context) (uint64, bool) {
+	if ctx == nil {
+		return 0, false
+	}
+	c, ok := ctx.Value(gin.ContextKey).(*gin.Context)
+	if !ok || c == nil {
+		return 0, false
+	}
+	enterpriseId, _ := strconv.ParseUint(c.GetString("currEnterpriseId"), 10, 64)
+	return enterpriseId, true
+}
@@ -0,0 +1,103 @@
+package db
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+type tenantTopic struct {
+	Id           uint64
+	Title        string
+	EnterpriseId uint64
+}
+
+func (tenantTopic) TableName() string { return "topics" }
+
+type tenantModel struct {
+	Id           uint64
+	Name         string
+	EnterpriseId uint64
+	IsShared     bool
+}
+
+func (tenantModel) TableName() string { return "thinking_models" }
+
+// dryRunDb 不连接数据库，只生成SQL
+func dryRunDb(t *testing.T) *gorm.DB {
+	gdb, err := gorm.Open(mysql.New(mysql.Config{DSN: "dry:run@tcp(127.0.0.1:0)/dry", SkipInitializeWithVersion: true}), &gorm.Config{DryRun: true, DisableAutomaticPing: true, SkipDefaultTransaction: true})
+	assert.Nil(t, err)
+	assert.Nil(t, gdb.Use(&TenantPlugin{}))
+	return gdb
+}
+
+func tenantCtx(enterpriseId string) *gin.Context {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	if enterpriseId != "" {
+		c.Set("currEnterpriseId", enterpriseId)
+	}
+	return c
+}
+
+func TestTenantPlugin(t *testing.T) {
+	gdb := dryRunDb(t)
+
+	// 查询追加企业过滤
+	stmt := gdb.WithContext(tenantCtx("7")).Find(&[]tenantTopic{}).Statement
+	assert.Contains(t, stmt.SQL.String(), "`topics`.`enterprise_id` = ?")
+	assert.Equal(t, []any{uint64(7)}, stmt.Vars)
+
+	// 可共享表查询包含共享数据
+	stmt = gdb.WithContext(tenantCtx("7")).Find(&[]tenantModel{}).Statement
+	assert.Contains(t, stmt.SQL.String(), "(`thinking_models`.`enterprise_id` = ? OR `thinking_models`.`is_shared` = ?)")
+
+	// 更新不包含共享数据
+	stmt = gdb.WithContext(tenantCtx("7")).Model(&tenantModel{Id: 1}).Update("name", "x").Statement
+	assert.Contains(t, stmt.SQL.String(), "`thinking_models`.`enterprise_id` = ?")
+	assert.NotContains(t, stmt.SQL.String(), "is_shared")
+
+	// 创建时写入企业ID
+	row := &tenantTopic{Title: "t", EnterpriseId: 99}
+	gdb.WithContext(tenantCtx("7")).Create(row)
+	assert.Equal(t, uint64(7), row.EnterpriseId)
+
+	// 未登录请求视为企业0
+	stmt = gdb.WithContext(tenantCtx("")).Find(&[]tenantTopic{}).Statement
+	assert.Equal(t, []any{uint64(0)}, stmt.Vars)
+
+	// 非请求上下文与显式跳过不做过滤
+	stmt = gdb.Find(&[]tenantTopic{}).Statement
+	assert.NotContains(t, stmt.SQL.String(), "enterprise_id")
+	stmt = IgnoreTenant(gdb.WithContext(tenantCtx("7"))).Find(&[]tenantTopic{}).Statement
+	assert.NotContains(t, stmt.SQL.String(), "enterprise_id")
+}
+
+// TestTenantScope 聚合查询手动过滤：不同企业只统计本企业及共享的模型
+func TestTenantScope(t *testing.T) {
+	gdb := dryRunDb(t)
+	var stats struct{ ModelCount int64 }
+	sum := func(c *gin.Context) *gorm.Statement {
+		return gdb.Table("thinking_models").Scopes(TenantScope(c, true)).
+			Select("COUNT(*) AS model_count").Where("author_id = ?", 1).Find(&stats).Statement
+	}
+
+	// 企业7、企业8各自只看到本企业数据和共享数据
+	stmt := sum(tenantCtx("7"))
+	assert.Contains(t, stmt.SQL.String(), "(enterprise_id = ? OR is_shared = 1)")
+	assert.Equal(t, []any{1, uint64(7)}, stmt.Vars)
+	stmt = sum(tenantCtx("8"))
+	assert.Equal(t, []any{1, uint64(8)}, stmt.Vars)
+
+	// 不含共享数据
+	stmt = gdb.Table("tags").Scopes(TenantScope(tenantCtx("7"), false)).Find(&[]map[string]any{}).Statement
+	assert.Contains(t, stmt.SQL.String(), "enterprise_id = ?")
+	assert.NotContains(t, stmt.SQL.String(), "is_shared")
+
+	// 非请求上下文不做过滤
+	stmt = sum(nil)
+	assert.NotContains(t, stmt.SQL.String(), "enterprise_id")
+}
@@ -0,0 +1,116 @@
+package errs
+
+import (
+	"errors"
+
+	"github.com/go-sql-driver/mysql"
+	"gorm.io/gorm"
+)
+
+// ========== 业务错误 ==========
+// 领域层、逻辑层返回 *Error 表示可预期的业务错误，接口层据此决定 HTTP 状态码、错误码与提示语。
+// 未归类的错误（SQL、网络等）一律视为内部错误：生产环境只返回通用提示，原始错误仅记录日志。
+
+// Code 错误码，数值稳定，客户端可据此区分错误类型；前三位与 HTTP 状态码一致
+type Code int
+
+const (
+	CodeOK           Code = 0
+	CodeValidation   Code = 40000 // 参数或业务校验不通过
+	CodeUnauthorized Code = 40100 // 未登录或访问凭证失效（客户端据此刷新令牌或重新登录）
+	CodeForbidden    Code = 40300 // 无权限
+	CodeNotFound     Code = 40400 // 资源不存在
+	CodeConflict     Code = 40900 // 资源冲突：重复或当前状态不允许该操作
+	CodeRateLimited  Code = 42900 // 请求过于频繁
+	CodeInternal     Code = 50000 // 服务器内部错误
+)
+
+// Status 对应的 HTTP 状态码
+func (c Code) Status() int {
+	if c < 10000 {
+		return 500
+	}
+	return int(c) / 100
+}
+
+// Error 业务错误
+type Error struct {
+	Code  Code
+	Msg   string // 返回给客户端的提示，为空时按错误码使用默认提示
+	cause error  // 内部原因，只记录日志，生产环境不返回
+}
+
+func (e *Error) Error() string {
+	switch {
+	case e.cause == nil:
+		return e.Msg
+	case e.Msg == "":
+		return e.cause.Error()
+	default:
+		return e.Msg + ": " + e.cause.Error()
+	}
+}
+
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+// Is 错误码与提示相同即视为同一错误，Wrap 之后仍可用 errors.Is 与哨兵错误比较
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code && t.Msg == e.Msg
+}
+
+// Wrap 附带内部原因，返回新的错误（哨兵错误本身不变）
+func (e *Error) Wrap(cause error) *Error {
+	return &Error{Code: e.Code, Msg: e.Msg, cause: cause}
+}
+
+// New 指定错误码与提示
+func New(code Code, msg string) *Error {
+	return &Error{Code: code, Msg: msg}
+}
+
+// Wrap 以默认提示包装内部原因
+func Wrap(code Code, cause error) *Error {
+	return &Error{Code: code, cause: cause}
+}
+
+func Validation(msg string) *Error   { return New(CodeValidation, msg) }
+func Unauthorized(msg string) *Error { return New(CodeUnauthorized, msg) }
+func Forbidden(msg string) *Error    { return New(CodeForbidden, msg) }
+func NotFound(msg string) *Error     { return New(CodeNotFound, msg) }
+func Conflict(msg string) *Error     { return New(CodeConflict, msg) }
+func RateLimited(msg string) *Error  { return New(CodeRateLimited, msg) }
+
+// Internal 内部错误，客户端只看到通用提示
+func Internal(cause error) *Error { return Wrap(CodeInternal, cause) }
+
+// Coder 自定义错误类型（如携带附加数据的登录失败错误）实现该接口即可指定错误码，提示取其 Error()
+type Coder interface {
+	ErrCode() Code
+}
+
+// From 将任意错误归类为 *Error
+func From(err error) *Error {
+	if err == nil {
+		return nil
+	}
+
+	var e *Error
+	if errors.As(err, &e) {
+		return e
+	}
+	var coder Coder
+	if errors.As(err, &coder) {
+		return &Error{Code: coder.ErrCode(), Msg: err.Error()}
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return Wrap(CodeNotFound, err)
+	}
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) && mysqlErr.Number == 1062 { // 唯一索引冲突
+		return Wrap(CodeConflict, err)
+	}
+	return Internal(err)
+}
@@ -0,0 +1,90 @@
+package errs
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+type limitedErr struct{}
+
+func (limitedErr) Error() string { return "请稍后再试" }
+func (limitedErr) ErrCode() Code { return CodeRateLimited }
+
+func TestFrom(t *testing.T) {
+	assert.Nil(t, From(nil))
+
+	notFound := NotFound("用户不存在")
+	assert.Same(t, notFound, From(notFound))
+	assert.Same(t, notFound, From(fmt.Errorf("load: %w", notFound)))
+
+	e := From(limitedErr{})
+	assert.Equal(t, CodeRateLimited, e.Code)
+	assert.Equal(t, "请稍后再试", e.Msg)
+
+	assert.Equal(t, CodeNotFound, From(gorm.ErrRecordNotFound).Code)
+	assert.Equal(t, CodeConflict, From(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry"}).Code)
+	assert.Equal(t, CodeInternal, From(&mysql.MySQLError{Number: 1054, Message: "Unknown column"}).Code)
+	assert.Equal(t, CodeInternal, From(errors.New("dial tcp: connection refused")).Code)
+}
+
+func TestWrap(t *testing.T) {
+	sentinel := Validation("第三方登录失败")
+	cause := errors.New("invalid_grant")
+	wrapped := sentinel.Wrap(cause)
+
+	assert.True(t, errors.Is(wrapped, sentinel))
+	assert.True(t, errors.Is(wrapped, cause))
+	assert.False(t, errors.Is(wrapped, Validation("其他错误")))
+	assert.Equal(t, "第三方登录失败: invalid_grant", wrapped.Error())
+	assert.Nil(t, sentinel.Unwrap(), "哨兵错误不受影响")
+}
+
+func TestStatus(t *testing.T) {
+	assert.Equal(t, 400, CodeValidation.Status())
+	assert.Equal(t, 401, CodeUnauthorized.Status())
+	assert.Equal(t, 403, CodeForbidden.Status())
+	assert.Equal(t, 404, CodeNotFound.Status())
+	assert.Equal(t, 409, CodeConflict.Status())
+	assert.Equal(t, 429, CodeRateLimited.Status())
+	assert.Equal(t, 500, CodeInternal.Status())
+	assert.Equal(t, 500, Code(-1).Status())
+}
+
+func TestMessage(t *testing.T) {
+	assert.Equal(t, LangEn, Lang("en-US,en;q=0.9"))
+	assert.Equal(t, LangZh, Lang("zh-CN,en;q=0.8"))
+	assert.Equal(t, LangZh, Lang(""))
+
+	// 内部错误：生产环境只返回默认提示
+	internal := Internal(errors.New("Error 1054: Unknown column 'x'"))
+	assert.Equal(t, "服务器内部错误", internal.Message(LangZh, false))
+	assert.Equal(t, "Internal server error", internal.Message(LangEn, false))
+	assert.Equal(t, "服务器内部错误: Error 1054: Unknown column 'x'", internal.Message(LangZh, true))
+
+	// 业务提示原样返回
+	assert.Equal(t, "用户不存在", NotFound("用户不存在").Message(LangEn, false))
+	assert.Equal(t, "邮件发送失败", New(CodeInternal, "邮件发送失败").Wrap(errors.New("smtp")).Message(LangZh, false))
+}
+
+func TestValidationMessage(t *testing.T) {
+	type req struct {
+		Username string `validate:"required"`
+		Password string `validate:"min=6"`
+		Status   int    `validate:"oneof=1 2"`
+		Progress int    `validate:"max=100"`
+	}
+	err := validator.New().Struct(&req{Password: "123", Status: 3, Progress: 101})
+	e := Wrap(CodeValidation, err)
+
+	assert.Equal(t, "Username不能为空；Password长度不能少于6个字符；Status必须是[1 2]中的一个；Progress不能大于100", e.Message(LangZh, false))
+	assert.Equal(t, "Username is required; Password must be at least 6 characters; Status must be one of [1 2]; Progress must be at most 100", e.Message(LangEn, true))
+
+	// 非校验器错误（如JSON格式错误）使用默认提示
+	assert.Equal(t, "请求参数错误", Wrap(CodeValidation, errors.New("unexpected EOF")).Message(LangZh, false))
+}
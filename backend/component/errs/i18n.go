@@ -0,0 +1,138 @@
+package errs
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// ========== 提示语本地化 ==========
+// 按 Accept-Language 选择语言，目前支持中文（默认）与英文。
+// 业务错误的提示由调用方给出（中文），错误码默认提示与参数校验提示按语言返回。
+
+const (
+	LangZh = "zh"
+	LangEn = "en"
+)
+
+var defaultMessages = map[Code][2]string{
+	CodeValidation:   {"请求参数错误", "Invalid request parameters"},
+	CodeUnauthorized: {"未登录或登录已失效", "Not logged in or session expired"},
+	CodeForbidden:    {"无权访问", "Access denied"},
+	CodeNotFound:     {"资源不存在", "Resource not found"},
+	CodeConflict:     {"数据已存在或状态已变更", "Resource already exists or has changed"},
+	CodeRateLimited:  {"请求过于频繁，请稍后再试", "Too many requests, please try again later"},
+	CodeInternal:     {"服务器内部错误", "Internal server error"},
+}
+
+// Lang 从 Accept-Language 取首选语言
+func Lang(acceptLanguage string) string {
+	first := strings.TrimSpace(strings.SplitN(acceptLanguage, ",", 2)[0])
+	if strings.HasPrefix(strings.ToLower(first), "en") {
+		return LangEn
+	}
+	return LangZh
+}
+
+// DefaultMessage 错误码的默认提示
+func DefaultMessage(code Code, lang string) string {
+	msgs, ok := defaultMessages[code]
+	if !ok {
+		msgs = defaultMessages[CodeInternal]
+	}
+	if lang == LangEn {
+		return msgs[1]
+	}
+	return msgs[0]
+}
+
+// Message 返回给客户端的提示
+// debug 为 true（非生产环境）时附带内部原因，便于联调
+func (e *Error) Message(lang string, debug bool) string {
+	msg := e.Msg
+	if msg == "" {
+		var verrs validator.ValidationErrors
+		if errors.As(e.cause, &verrs) {
+			return validationMessage(verrs, lang)
+		}
+		msg = DefaultMessage(e.Code, lang)
+	}
+	if debug && e.cause != nil {
+		msg += ": " + e.cause.Error()
+	}
+	return msg
+}
+
+// validationMessage 参数校验错误逐项翻译，多项以分号连接
+func validationMessage(verrs validator.ValidationErrors, lang string) string {
+	msgs := make([]string, 0, len(verrs))
+	for _, fe := range verrs {
+		msgs = append(msgs, fieldMessage(fe, lang))
+	}
+	if lang == LangEn {
+		return strings.Join(msgs, "; ")
+	}
+	return strings.Join(msgs, "；")
+}
+
+func fieldMessage(fe validator.FieldError, lang string) string {
+	field, param := fe.Field(), fe.Param()
+	isLen := fe.Kind() == reflect.String || fe.Kind() == reflect.Slice || fe.Kind() == reflect.Map
+
+	if lang == LangEn {
+		switch {
+		case fe.Tag() == "required":
+			return fmt.Sprintf("%s is required", field)
+		case fe.Tag() == "email":
+			return fmt.Sprintf("%s must be a valid email", field)
+		case fe.Tag() == "url":
+			return fmt.Sprintf("%s must be a valid URL", field)
+		case fe.Tag() == "oneof":
+			return fmt.Sprintf("%s must be one of [%s]", field, param)
+		case isLen && (fe.Tag() == "min" || fe.Tag() == "gte"):
+			return fmt.Sprintf("%s must be at least %s characters", field, param)
+		case isLen && (fe.Tag() == "max" || fe.Tag() == "lte"):
+			return fmt.Sprintf("%s must be at most %s characters", field, param)
+		case isLen && fe.Tag() == "len":
+			return fmt.Sprintf("%s must be %s characters", field, param)
+		case fe.Tag() == "min" || fe.Tag() == "gte":
+			return fmt.Sprintf("%s must be at least %s", field, param)
+		case fe.Tag() == "max" || fe.Tag() == "lte":
+			return fmt.Sprintf("%s must be at most %s", field, param)
+		case fe.Tag() == "gt":
+			return fmt.Sprintf("%s must be greater than %s", field, param)
+		case fe.Tag() == "lt":
+			return fmt.Sprintf("%s must be less than %s", field, param)
+		}
+		return fmt.Sprintf("%s is invalid", field)
+	}
+
+	switch {
+	case fe.Tag() == "required":
+		return fmt.Sprintf("%s不能为空", field)
+	case fe.Tag() == "email":
+		return fmt.Sprintf("%s必须是有效的邮箱", field)
+	case fe.Tag() == "url":
+		return fmt.Sprintf("%s必须是有效的URL", field)
+	case fe.Tag() == "oneof":
+		return fmt.Sprintf("%s必须是[%s]中的一个", field, param)
+	case isLen && (fe.Tag() == "min" || fe.Tag() == "gte"):
+		return fmt.Sprintf("%s长度不能少于%s个字符", field, param)
+	case isLen && (fe.Tag() == "max" || fe.Tag() == "lte"):
+		return fmt.Sprintf("%s长度不能超过%s个字符", field, param)
+	case isLen && fe.Tag() == "len":
+		return fmt.Sprintf("%s长度必须为%s个字符", field, param)
+	case fe.Tag() == "min" || fe.Tag() == "gte":
+		return fmt.Sprintf("%s不能小于%s", field, param)
+	case fe.Tag() == "max" || fe.Tag() == "lte":
+		return fmt.Sprintf("%s不能大于%s", field, param)
+	case fe.Tag() == "gt":
+		return fmt.Sprintf("%s必须大于%s", field, param)
+	case fe.Tag() == "lt":
+		return fmt.Sprintf("%s必须小于%s", field, param)
+	}
+	return fmt.Sprintf("%s格式不正确", field)
+}
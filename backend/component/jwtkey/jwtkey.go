@@ -0,0 +1,264 @@
+package jwtkey
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"slices"
+	"sync"
+
+	"github.com/golang-jwt/jwt/v5"
+	"thinkingModels/config"
+)
+
+// ========== JWT 签名密钥 ==========
+// 密钥集合来自 config.Config.Jwt：signingKey 指定的密钥用于签发，全部密钥均可用于校验，
+// 轮换时先新增密钥并切换 signingKey，旧密钥保留到其签发的 Token 全部过期后再删除。
+// 签发的 Token 头部带 kid，校验时按 kid 选取密钥；不带 kid 的旧 Token 依次尝试同算法的密钥。
+// 非对称密钥(RS256/EdDSA)的公钥通过 JWKS 发布，HS256 密钥不对外公开。
+
+const (
+	AlgHS256 = "HS256"
+	AlgRS256 = "RS256"
+	AlgEdDSA = "EdDSA"
+
+	minSecretLen = 32   // HS256 密钥最小长度(字节)
+	minRSABits   = 2048 // RSA 密钥最小位数
+)
+
+var (
+	ErrNoSigningKey = errors.New("未配置JWT签名密钥")
+	ErrKeyNotFound  = errors.New("token签名密钥不存在")
+)
+
+// Key 单个签名密钥
+type Key struct {
+	Kid    string
+	Method jwt.SigningMethod
+	sign   any // 签名密钥，为空表示仅用于校验
+	verify any // 校验密钥
+}
+
+// KeySet 密钥集合
+type KeySet struct {
+	signing *Key
+	keys    []*Key
+}
+
+// JWKS JSON Web Key Set
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWK JSON Web Key（公钥）
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`   // RSA 模数
+	E   string `json:"e,omitempty"`   // RSA 指数
+	Crv string `json:"crv,omitempty"` // OKP 曲线
+	X   string `json:"x,omitempty"`   // OKP 公钥
+}
+
+var (
+	defaultOnce sync.Once
+	defaultSet  *KeySet
+	defaultErr  error
+)
+
+// Default 按配置加载的密钥集合（首次使用时加载）
+func Default() (*KeySet, error) {
+	defaultOnce.Do(func() {
+		defaultSet, defaultErr = New(config.Config.Jwt.SigningKey, config.Config.Jwt.Keys)
+	})
+	return defaultSet, defaultErr
+}
+
+// New 由配置创建密钥集合，signingKid 必须对应一个带私钥(或密钥)的配置
+func New(signingKid string, confs []config.JwtKey) (*KeySet, error) {
+	set := &KeySet{}
+	for _, conf := range confs {
+		key, err := loadKey(conf)
+		if err != nil {
+			return nil, fmt.Errorf("JWT密钥 %s: %w", conf.Kid, err)
+		}
+		if set.Find(key.Kid) != nil {
+			return nil, fmt.Errorf("JWT密钥 %s: kid 重复", key.Kid)
+		}
+		set.keys = append(set.keys, key)
+	}
+
+	set.signing = set.Find(signingKid)
+	if set.signing == nil {
+		return nil, ErrNoSigningKey
+	}
+	if set.signing.sign == nil {
+		return nil, fmt.Errorf("JWT密钥 %s: 未配置私钥，不能用于签发", signingKid)
+	}
+	return set, nil
+}
+
+// Find 按 kid 查找密钥
+func (s *KeySet) Find(kid string) *Key {
+	for _, key := range s.keys {
+		if key.Kid == kid {
+			return key
+		}
+	}
+	return nil
+}
+
+// Sign 使用当前签发密钥签名，头部写入 kid
+func (s *KeySet) Sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(s.signing.Method, claims)
+	token.Header["kid"] = s.signing.Kid
+	return token.SignedString(s.signing.sign)
+}
+
+// Keyfunc 供 jwt.Parse 使用：按 kid 选取校验密钥，算法须与密钥一致
+func (s *KeySet) Keyfunc(token *jwt.Token) (any, error) {
+	alg := token.Method.Alg()
+	if kid, ok := token.Header["kid"].(string); ok {
+		key := s.Find(kid)
+		if key == nil || key.Method.Alg() != alg {
+			return nil, ErrKeyNotFound
+		}
+		return key.verify, nil
+	}
+
+	// 不带 kid 的旧 Token
+	keys := jwt.VerificationKeySet{}
+	for _, key := range s.keys {
+		if key.Method.Alg() == alg {
+			keys.Keys = append(keys.Keys, key.verify)
+		}
+	}
+	if len(keys.Keys) == 0 {
+		return nil, ErrKeyNotFound
+	}
+	return keys, nil
+}
+
+// Methods 已配置密钥使用的签名算法，用于 jwt.WithValidMethods
+func (s *KeySet) Methods() []string {
+	methods := make([]string, 0, len(s.keys))
+	for _, key := range s.keys {
+		if !slices.Contains(methods, key.Method.Alg()) {
+			methods = append(methods, key.Method.Alg())
+		}
+	}
+	return methods
+}
+
+// JWKS 非对称密钥的公钥集合
+func (s *KeySet) JWKS() *JWKS {
+	res := &JWKS{Keys: make([]JWK, 0, len(s.keys))}
+	for _, key := range s.keys {
+		switch pub := key.verify.(type) {
+		case *rsa.PublicKey:
+			res.Keys = append(res.Keys, JWK{
+				Kty: "RSA",
+				Kid: key.Kid,
+				Use: "sig",
+				Alg: AlgRS256,
+				N:   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+				E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+			})
+		case ed25519.PublicKey:
+			res.Keys = append(res.Keys, JWK{
+				Kty: "OKP",
+				Kid: key.Kid,
+				Use: "sig",
+				Alg: AlgEdDSA,
+				Crv: "Ed25519",
+				X:   base64.RawURLEncoding.EncodeToString(pub),
+			})
+		}
+	}
+	return res
+}
+
+// loadKey 解析单个密钥配置
+func loadKey(conf config.JwtKey) (*Key, error) {
+	if conf.Kid == "" {
+		return nil, errors.New("kid 不能为空")
+	}
+	key := &Key{Kid: conf.Kid}
+
+	if conf.Alg == AlgHS256 {
+		if len(conf.Secret) < minSecretLen {
+			return nil, fmt.Errorf("HS256 密钥长度不能少于%d字节", minSecretLen)
+		}
+		key.Method = jwt.SigningMethodHS256
+		key.sign = []byte(conf.Secret)
+		key.verify = key.sign
+		return key, nil
+	}
+
+	privatePem, err := readPem(conf.PrivateKey, conf.PrivateKeyFile)
+	if err != nil {
+		return nil, err
+	}
+	publicPem, err := readPem(conf.PublicKey, conf.PublicKeyFile)
+	if err != nil {
+		return nil, err
+	}
+	if privatePem == nil && publicPem == nil {
+		return nil, errors.New("未配置私钥或公钥")
+	}
+
+	switch conf.Alg {
+	case AlgRS256:
+		key.Method = jwt.SigningMethodRS256
+		if privatePem != nil {
+			private, err := jwt.ParseRSAPrivateKeyFromPEM(privatePem)
+			if err != nil {
+				return nil, err
+			}
+			key.sign, key.verify = private, &private.PublicKey
+		} else {
+			key.verify, err = jwt.ParseRSAPublicKeyFromPEM(publicPem)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if key.verify.(*rsa.PublicKey).N.BitLen() < minRSABits {
+			return nil, fmt.Errorf("RSA 密钥不能少于%d位", minRSABits)
+		}
+	case AlgEdDSA:
+		key.Method = jwt.SigningMethodEdDSA
+		if privatePem != nil {
+			private, err := jwt.ParseEdPrivateKeyFromPEM(privatePem)
+			if err != nil {
+				return nil, err
+			}
+			key.sign = private
+			key.verify = private.(ed25519.PrivateKey).Public()
+		} else {
+			key.verify, err = jwt.ParseEdPublicKeyFromPEM(publicPem)
+			if err != nil {
+				return nil, err
+			}
+		}
+	default:
+		return nil, errors.New("不支持的签名算法: " + conf.Alg)
+	}
+	return key, nil
+}
+
+// readPem 读取内联或文件中的 PEM，均未配置时返回 nil
+func readPem(inline, file string) ([]byte, error) {
+	if inline != "" {
+		return []byte(inline), nil
+	}
+	if file != "" {
+		return os.ReadFile(file)
+	}
+	return nil, nil
+}
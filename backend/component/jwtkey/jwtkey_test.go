@@ -0,0 +1,156 @@
+package jwtkey
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"thinkingModels/config"
+)
+
+const testSecret = "0123456789abcdef0123456789abcdef"
+
+// pkcs8Pem 私钥编码为 PKCS#8 PEM
+func pkcs8Pem(t *testing.T, key any) string {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	assert.Nil(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+}
+
+// pkixPem 公钥编码为 PKIX PEM
+func pkixPem(t *testing.T, key any) string {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	assert.Nil(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+func testClaims() jwt.RegisteredClaims {
+	return jwt.RegisteredClaims{Subject: "1", ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute))}
+}
+
+func parse(set *KeySet, token string) error {
+	_, err := jwt.ParseWithClaims(token, &jwt.RegisteredClaims{}, set.Keyfunc, jwt.WithValidMethods(set.Methods()))
+	return err
+}
+
+func TestSignAndVerify(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.Nil(t, err)
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.Nil(t, err)
+
+	keys := []config.JwtKey{
+		{Kid: "hs", Alg: AlgHS256, Secret: testSecret},
+		{Kid: "rs", Alg: AlgRS256, PrivateKey: pkcs8Pem(t, rsaKey)},
+		{Kid: "ed", Alg: AlgEdDSA, PrivateKey: pkcs8Pem(t, edKey)},
+	}
+	for _, signing := range []string{"hs", "rs", "ed"} {
+		set, err := New(signing, keys)
+		assert.Nil(t, err)
+		token, err := set.Sign(testClaims())
+		assert.Nil(t, err)
+
+		parsed, _, err := jwt.NewParser().ParseUnverified(token, &jwt.RegisteredClaims{})
+		assert.Nil(t, err)
+		assert.Equal(t, signing, parsed.Header["kid"])
+		assert.Nil(t, parse(set, token), signing)
+	}
+}
+
+// TestRotation 切换签发密钥后，旧密钥签发的 Token 仍可校验；删除旧密钥后失效
+func TestRotation(t *testing.T) {
+	old := config.JwtKey{Kid: "2026-01", Alg: AlgHS256, Secret: testSecret}
+	next := config.JwtKey{Kid: "2026-10", Alg: AlgHS256, Secret: testSecret + "-next"}
+
+	before, err := New("2026-01", []config.JwtKey{old})
+	assert.Nil(t, err)
+	token, err := before.Sign(testClaims())
+	assert.Nil(t, err)
+
+	rotating, err := New("2026-10", []config.JwtKey{next, old})
+	assert.Nil(t, err)
+	assert.Nil(t, parse(rotating, token))
+
+	after, err := New("2026-10", []config.JwtKey{next})
+	assert.Nil(t, err)
+	assert.ErrorIs(t, parse(after, token), ErrKeyNotFound)
+}
+
+// TestLegacyToken 不带 kid 的旧 Token 按同算法的密钥依次尝试
+func TestLegacyToken(t *testing.T) {
+	set, err := New("new", []config.JwtKey{
+		{Kid: "new", Alg: AlgHS256, Secret: testSecret + "-new"},
+		{Kid: "legacy", Alg: AlgHS256, Secret: testSecret},
+	})
+	assert.Nil(t, err)
+
+	legacy, err := jwt.NewWithClaims(jwt.SigningMethodHS256, testClaims()).SignedString([]byte(testSecret))
+	assert.Nil(t, err)
+	assert.Nil(t, parse(set, legacy))
+
+	forged, err := jwt.NewWithClaims(jwt.SigningMethodHS256, testClaims()).SignedString([]byte(testSecret + "-forged"))
+	assert.Nil(t, err)
+	assert.NotNil(t, parse(set, forged))
+}
+
+// TestAlgMismatch kid 对应的密钥算法与 Token 不一致时拒绝（防止用公钥作为 HMAC 密钥）
+func TestAlgMismatch(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.Nil(t, err)
+	set, err := New("rs", []config.JwtKey{
+		{Kid: "rs", Alg: AlgRS256, PrivateKey: pkcs8Pem(t, rsaKey)},
+		{Kid: "hs", Alg: AlgHS256, Secret: testSecret},
+	})
+	assert.Nil(t, err)
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, testClaims())
+	token.Header["kid"] = "rs"
+	forged, err := token.SignedString([]byte(pkixPem(t, &rsaKey.PublicKey)))
+	assert.Nil(t, err)
+	assert.NotNil(t, parse(set, forged))
+}
+
+func TestJWKS(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.Nil(t, err)
+	edPub, _, err := ed25519.GenerateKey(rand.Reader)
+	assert.Nil(t, err)
+
+	set, err := New("hs", []config.JwtKey{
+		{Kid: "hs", Alg: AlgHS256, Secret: testSecret},
+		{Kid: "rs", Alg: AlgRS256, PublicKey: pkixPem(t, &rsaKey.PublicKey)},
+		{Kid: "ed", Alg: AlgEdDSA, PublicKey: pkixPem(t, edPub)},
+	})
+	assert.Nil(t, err)
+
+	jwks := set.JWKS()
+	assert.Len(t, jwks.Keys, 2) // HS256 密钥不公开
+	assert.Equal(t, "RSA", jwks.Keys[0].Kty)
+	assert.Equal(t, "rs", jwks.Keys[0].Kid)
+	assert.Equal(t, "AQAB", jwks.Keys[0].E)
+	assert.Equal(t, "OKP", jwks.Keys[1].Kty)
+	assert.Equal(t, "Ed25519", jwks.Keys[1].Crv)
+}
+
+func TestNew_Invalid(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.Nil(t, err)
+	publicOnly := config.JwtKey{Kid: "rs", Alg: AlgRS256, PublicKey: pkixPem(t, &rsaKey.PublicKey)}
+
+	_, err = New("missing", []config.JwtKey{{Kid: "hs", Alg: AlgHS256, Secret: testSecret}})
+	assert.ErrorIs(t, err, ErrNoSigningKey)
+	_, err = New("rs", []config.JwtKey{publicOnly}) // 只有公钥不能签发
+	assert.NotNil(t, err)
+	_, err = New("hs", []config.JwtKey{{Kid: "hs", Alg: AlgHS256, Secret: "short"}})
+	assert.NotNil(t, err)
+	_, err = New("hs", []config.JwtKey{{Kid: "hs", Alg: AlgHS256, Secret: testSecret}, {Kid: "hs", Alg: AlgHS256, Secret: testSecret}})
+	assert.NotNil(t, err)
+	_, err = New("x", []config.JwtKey{{Kid: "x", Alg: "none"}})
+	assert.NotNil(t, err)
+}
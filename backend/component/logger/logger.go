@@ -0,0 +1,91 @@
+package logger
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"log"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ========== 结构化日志 ==========
+// 基于 slog，请求的 trace id 由中间件写入请求上下文，之后各层通过 Ctx(ctx) 取得带 trace_id 的日志器，
+// GORM、redis 的慢查询日志也从各自的上下文读取 trace id，便于按 trace id 串联一次请求的全部日志。
+
+// TraceHeader 请求/响应头中的 trace id，客户端未携带时由服务端生成
+const TraceHeader = "X-Trace-Id"
+
+type (
+	traceKey  struct{}
+	loggerKey struct{}
+)
+
+// Init 按配置初始化默认日志器，标准库 log 的输出同样转为结构化日志
+func Init(level, format string) {
+	slog.SetDefault(slog.New(newHandler(os.Stdout, level, format)))
+}
+
+func newHandler(w io.Writer, level, format string) slog.Handler {
+	var lvl slog.Level
+	if err := lvl.UnmarshalText([]byte(level)); err != nil {
+		lvl = slog.LevelInfo
+	}
+	opts := &slog.HandlerOptions{Level: lvl}
+	if strings.EqualFold(format, "json") {
+		return slog.NewJSONHandler(w, opts)
+	}
+	return slog.NewTextHandler(w, opts)
+}
+
+// NewTraceId 生成 trace id（32位十六进制）
+func NewTraceId() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// WithTraceId 将 trace id 及带 trace_id 的日志器写入上下文
+func WithTraceId(ctx context.Context, traceId string) context.Context {
+	ctx = context.WithValue(ctx, traceKey{}, traceId)
+	return context.WithValue(ctx, loggerKey{}, slog.Default().With("trace_id", traceId))
+}
+
+// TraceId 从上下文读取 trace id，没有时返回空串
+func TraceId(ctx context.Context) string {
+	if ctx = unwrap(ctx); ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(traceKey{}).(string)
+	return id
+}
+
+// Ctx 上下文中的日志器（带 trace_id），没有时返回默认日志器
+func Ctx(ctx context.Context) *slog.Logger {
+	if ctx = unwrap(ctx); ctx != nil {
+		if l, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok {
+			return l
+		}
+	}
+	return slog.Default()
+}
+
+// unwrap gin.Context 取其请求上下文（gin.Context.Value 默认不回退到请求上下文）
+func unwrap(ctx context.Context) context.Context {
+	if c, ok := ctx.(*gin.Context); ok {
+		if c == nil || c.Request == nil {
+			return nil
+		}
+		return c.Request.Context()
+	}
+	return ctx
+}
+
+// StdLogger 输出到结构化日志的标准库日志器，用于只接受 *log.Logger 的组件（如 http.Server.ErrorLog）
+func StdLogger(level slog.Level) *log.Logger {
+	return slog.NewLogLogger(slog.Default().Handler(), level)
+}
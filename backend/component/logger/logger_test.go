@@ -0,0 +1,55 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTraceId(t *testing.T) {
+	id := NewTraceId()
+	assert.Len(t, id, 32)
+	assert.NotEqual(t, id, NewTraceId())
+
+	ctx := WithTraceId(context.Background(), id)
+	assert.Equal(t, id, TraceId(ctx))
+	assert.Equal(t, "", TraceId(context.Background()))
+
+	// gin.Context 从请求上下文读取
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest("GET", "/", nil).WithContext(ctx)
+	assert.Equal(t, id, TraceId(c))
+
+	var nilCtx *gin.Context
+	assert.Equal(t, "", TraceId(nilCtx))
+	assert.Equal(t, slog.Default(), Ctx(nilCtx))
+}
+
+func TestCtx(t *testing.T) {
+	old := slog.Default()
+	defer slog.SetDefault(old)
+
+	buf := &bytes.Buffer{}
+	slog.SetDefault(slog.New(newHandler(buf, "debug", "json")))
+
+	ctx := WithTraceId(context.Background(), "trace-0001")
+	Ctx(ctx).Info("hello", "user_id", 7)
+
+	line := map[string]any{}
+	assert.Nil(t, json.Unmarshal(buf.Bytes(), &line))
+	assert.Equal(t, "trace-0001", line["trace_id"])
+	assert.Equal(t, "hello", line["msg"])
+	assert.Equal(t, float64(7), line["user_id"])
+
+	// 级别过滤，非法级别按 info 处理
+	buf.Reset()
+	slog.New(newHandler(buf, "warn", "text")).Info("ignored")
+	slog.New(newHandler(buf, "bogus", "text")).Debug("ignored")
+	assert.Equal(t, 0, buf.Len())
+}
@@ -0,0 +1,154 @@
+package mail
+
+import (
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"thinkingModels/config"
+)
+
+// Message 邮件内容
+type Message struct {
+	To      []string
+	Subject string
+	Body    string // 纯文本正文
+}
+
+// Mailer 邮件投递接口
+type Mailer interface {
+	Send(msg *Message) error
+}
+
+// NewMailer 按配置创建邮件投递实现，默认写入本地 outbox
+func NewMailer() Mailer {
+	conf := config.Config.Mail
+	if conf.Driver == "smtp" {
+		return &SMTPMailer{
+			Host:     conf.Host,
+			Port:     conf.Port,
+			Username: conf.Username,
+			Password: conf.Password,
+			From:     conf.From,
+		}
+	}
+	dir := conf.OutboxDir
+	if dir == "" {
+		dir = filepath.Join(os.TempDir(), "thinkingModels-outbox")
+	}
+	return &OutboxMailer{Dir: dir}
+}
+
+// ========== SMTP ==========
+
+// SMTPMailer 通过SMTP投递，465端口使用隐式TLS，其余端口在服务端支持时升级STARTTLS
+type SMTPMailer struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+}
+
+// Send 发送邮件
+func (m *SMTPMailer) Send(msg *Message) error {
+	if m.Host == "" || m.From == "" {
+		return errors.New("SMTP未配置")
+	}
+	if len(msg.To) == 0 {
+		return errors.New("收件人不能为空")
+	}
+
+	addr := net.JoinHostPort(m.Host, strconv.Itoa(m.Port))
+	var client *smtp.Client
+	var err error
+	if m.Port == 465 {
+		conn, dialErr := tls.Dial("tcp", addr, &tls.Config{ServerName: m.Host})
+		if dialErr != nil {
+			return dialErr
+		}
+		client, err = smtp.NewClient(conn, m.Host)
+	} else {
+		client, err = smtp.Dial(addr)
+		if err == nil {
+			if ok, _ := client.Extension("STARTTLS"); ok {
+				err = client.StartTLS(&tls.Config{ServerName: m.Host})
+			}
+		}
+	}
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if m.Username != "" {
+		if err = client.Auth(smtp.PlainAuth("", m.Username, m.Password, m.Host)); err != nil {
+			return err
+		}
+	}
+	if err = client.Mail(m.From); err != nil {
+		return err
+	}
+	for _, to := range msg.To {
+		if err = client.Rcpt(to); err != nil {
+			return err
+		}
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err = w.Write(encode(m.From, msg)); err != nil {
+		return err
+	}
+	if err = w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+// ========== Outbox ==========
+
+// OutboxMailer 将邮件写入本地目录（.eml），用于开发与测试
+type OutboxMailer struct {
+	Dir string
+}
+
+// Send 写入邮件文件
+func (m *OutboxMailer) Send(msg *Message) error {
+	if len(msg.To) == 0 {
+		return errors.New("收件人不能为空")
+	}
+	if err := os.MkdirAll(m.Dir, 0o755); err != nil {
+		return err
+	}
+	b := make([]byte, 4)
+	_, _ = rand.Read(b)
+	name := fmt.Sprintf("%s-%s.eml", time.Now().Format("20060102150405"), hex.EncodeToString(b))
+	return os.WriteFile(filepath.Join(m.Dir, name), encode("outbox@localhost", msg), 0o644)
+}
+
+// encode 组装 RFC 5322 邮件报文
+func encode(from string, msg *Message) []byte {
+	var sb strings.Builder
+	sb.WriteString("From: " + from + "\r\n")
+	sb.WriteString("To: " + strings.Join(msg.To, ", ") + "\r\n")
+	sb.WriteString("Subject: " + mime.BEncoding.Encode("UTF-8", msg.Subject) + "\r\n")
+	sb.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\r\n")
+	sb.WriteString("MIME-Version: 1.0\r\n")
+	sb.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	sb.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	sb.WriteString("\r\n")
+	sb.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+	return []byte(sb.String())
+}
@@ -0,0 +1,26 @@
+package mail
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutboxMailer(t *testing.T) {
+	dir := t.TempDir()
+	mailer := &OutboxMailer{Dir: dir}
+
+	err := mailer.Send(&Message{To: []string{"a@example.com"}, Subject: "重置密码", Body: "line1\nline2"})
+	assert.Nil(t, err)
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.eml"))
+	assert.Len(t, files, 1)
+	data, _ := os.ReadFile(files[0])
+	assert.Contains(t, string(data), "To: a@example.com\r\n")
+	assert.Contains(t, string(data), "Subject: =?UTF-8?b?")
+	assert.Contains(t, string(data), "\r\n\r\nline1\r\nline2")
+
+	assert.NotNil(t, mailer.Send(&Message{Subject: "no recipient"}))
+}
@@ -0,0 +1,134 @@
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// ========== Prometheus 指标 ==========
+// 指标统一登记在 Registry，由 /metrics（地址与访问控制见 config.yaml 的 metrics 配置）导出。
+// HTTP 指标按路由模板（如 /thinking/model/:id）统计，避免路径参数导致序列数膨胀。
+
+const namespace = "tm"
+
+var Registry = prometheus.NewRegistry()
+
+var (
+	HttpRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace, Name: "http_requests_total", Help: "HTTP 请求数",
+	}, []string{"method", "route", "status"})
+
+	HttpDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace, Name: "http_request_duration_seconds", Help: "HTTP 请求耗时",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route"})
+
+	DbQueryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace, Name: "db_query_duration_seconds", Help: "GORM 语句耗时",
+		Buckets: []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"operation", "table", "status"})
+
+	ModelsPublished = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace, Name: "models_published_total", Help: "发布的思维模型数",
+	})
+
+	AnalysesCreated = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace, Name: "analyses_created_total", Help: "新建的分析记录数",
+	}, []string{"module"})
+
+	ActionsCompleted = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace, Name: "actions_completed_total", Help: "完成的行动项数",
+	})
+
+	AiTokens = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace, Name: "ai_tokens_total", Help: "AI 调用消耗的 Token 数",
+	}, []string{"model", "type"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		HttpRequests, HttpDuration, DbQueryDuration,
+		ModelsPublished, AnalysesCreated, ActionsCompleted, AiTokens,
+	)
+}
+
+// Handler 指标导出
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry})
+}
+
+// RegisterDb 登记数据库连接池统计（连接建立后调用）
+func RegisterDb(db *sql.DB) {
+	_ = Registry.Register(collectors.NewDBStatsCollector(db, "mysql"))
+}
+
+// RegisterRedisPool 登记 redis 连接池统计
+func RegisterRedisPool(active, idle func() int) {
+	Registry.MustRegister(
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: namespace, Name: "redis_pool_active_connections", Help: "redis 连接池活跃连接数（含空闲）",
+		}, func() float64 { return float64(active()) }),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: namespace, Name: "redis_pool_idle_connections", Help: "redis 连接池空闲连接数",
+		}, func() float64 { return float64(idle()) }),
+	)
+}
+
+// AI 调用由前端发起，模型名来自请求，超过 maxAiModels 个不同模型后其余记为 other，避免序列数无限增长
+const (
+	maxAiModels  = 20
+	otherAiModel = "other"
+)
+
+var (
+	aiModelsMu sync.Mutex
+	aiModels   = make(map[string]struct{})
+)
+
+// AiUsage 一次 AI 调用的 Token 消耗（随 save-with-ai 请求上报）
+type AiUsage struct {
+	Model        string `json:"model" binding:"max=64"`
+	InputTokens  int    `json:"inputTokens" binding:"min=0,max=1000000"`
+	OutputTokens int    `json:"outputTokens" binding:"min=0,max=1000000"`
+}
+
+// AddAiTokens 记录一次 AI 调用的 Token 消耗
+func AddAiTokens(usage *AiUsage) {
+	if usage == nil {
+		return
+	}
+	model := aiModelLabel(usage.Model)
+	if usage.InputTokens > 0 {
+		AiTokens.WithLabelValues(model, "input").Add(float64(usage.InputTokens))
+	}
+	if usage.OutputTokens > 0 {
+		AiTokens.WithLabelValues(model, "output").Add(float64(usage.OutputTokens))
+	}
+}
+
+// aiModelLabel 模型名标签
+func aiModelLabel(model string) string {
+	model = strings.ToLower(strings.TrimSpace(model))
+	if model == "" {
+		return otherAiModel
+	}
+
+	aiModelsMu.Lock()
+	defer aiModelsMu.Unlock()
+	if _, ok := aiModels[model]; ok {
+		return model
+	}
+	if len(aiModels) >= maxAiModels {
+		return otherAiModel
+	}
+	aiModels[model] = struct{}{}
+	return model
+}
@@ -0,0 +1,30 @@
+package metrics
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddAiTokens(t *testing.T) {
+	AddAiTokens(&AiUsage{Model: " GPT-4o ", InputTokens: 120, OutputTokens: 30})
+	AddAiTokens(&AiUsage{Model: "gpt-4o", InputTokens: 80})
+	AddAiTokens(nil)
+
+	assert.Equal(t, 200.0, testutil.ToFloat64(AiTokens.WithLabelValues("gpt-4o", "input")))
+	assert.Equal(t, 30.0, testutil.ToFloat64(AiTokens.WithLabelValues("gpt-4o", "output")))
+}
+
+func TestAiModelLabel(t *testing.T) {
+	aiModels = make(map[string]struct{})
+	t.Cleanup(func() { aiModels = make(map[string]struct{}) })
+
+	assert.Equal(t, otherAiModel, aiModelLabel(" "))
+	for i := 0; i < maxAiModels; i++ {
+		assert.Equal(t, "model-"+strconv.Itoa(i), aiModelLabel("model-"+strconv.Itoa(i)))
+	}
+	assert.Equal(t, otherAiModel, aiModelLabel("one-more"), "超过上限的模型名记为 other")
+	assert.Equal(t, "model-0", aiModelLabel("MODEL-0"), "已登记的模型不受上限影响")
+}
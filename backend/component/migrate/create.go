@@ -0,0 +1,42 @@
+package migrate
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// versionWidth 版本号位数，不足补0，保证文件按名称排序即按版本排序
+const versionWidth = 4
+
+var nameFilter = regexp.MustCompile(`[^a-z0-9]+`)
+
+// Create 在 dir 下生成下一个版本的 up/down 空脚本，返回生成的文件路径
+func Create(dir, name string) (up, down string, err error) {
+	name = strings.Trim(nameFilter.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if name == "" {
+		return "", "", fmt.Errorf("迁移名称只能包含字母、数字与下划线")
+	}
+
+	migrations, err := Load(os.DirFS(dir))
+	if err != nil {
+		return "", "", err
+	}
+	var version uint64 = 1
+	if n := len(migrations); n > 0 {
+		version = migrations[n-1].Version + 1
+	}
+
+	base := fmt.Sprintf("%0*d_%s", versionWidth, version, name)
+	up = filepath.Join(dir, base+".up.sql")
+	down = filepath.Join(dir, base+".down.sql")
+	if err = os.WriteFile(up, []byte("-- "+name+"\n"), 0o644); err != nil {
+		return "", "", err
+	}
+	if err = os.WriteFile(down, []byte("-- 回滚 "+name+"\n"), 0o644); err != nil {
+		return "", "", err
+	}
+	return up, down, nil
+}
@@ -0,0 +1,375 @@
+package migrate
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// ========== 数据库迁移 ==========
+// 迁移脚本按版本号顺序执行，执行记录保存在 schema_migrations 表。
+// 每个版本执行前先写入 dirty=1 的记录，成功后置为 0；执行失败时记录保持 dirty，
+// 此时拒绝继续迁移，需人工修复表结构后通过 Force 指定当前实际版本。
+// MySQL 的 DDL 不支持事务，同一迁移中的多条语句需要连接开启 multiStatements。
+
+const (
+	table    = "schema_migrations"
+	lockName = "thinkingModels:schema_migrations"
+	lockWait = 30 // 等待其他实例迁移完成的秒数
+)
+
+var (
+	ErrDirty   = errors.New("数据库迁移处于失败状态")
+	ErrPending = errors.New("数据库存在未执行的迁移")
+	ErrLocked  = errors.New("其他实例正在执行数据库迁移")
+)
+
+// fileName 迁移文件名：{版本号}_{名称}.up.sql / {版本号}_{名称}.down.sql
+var fileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// Migration 单个版本的迁移脚本
+type Migration struct {
+	Version uint64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// State 迁移的执行状态
+type State struct {
+	Migration
+	Applied   bool
+	Dirty     bool
+	AppliedAt time.Time
+	Unknown   bool // 数据库中已执行但当前版本不存在的迁移（如新版本执行后回滚了代码）
+}
+
+// record schema_migrations 中的记录
+type record struct {
+	version   uint64
+	name      string
+	dirty     bool
+	appliedAt time.Time
+}
+
+// Migrator 迁移执行器
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration // 按版本号升序
+}
+
+// Load 读取迁移脚本，版本号重复或缺少 up 脚本时报错
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[uint64]*Migration)
+	for _, entry := range entries {
+		match := fileName.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+		version, err := strconv.ParseUint(match[1], 10, 64)
+		if err != nil || version == 0 {
+			return nil, fmt.Errorf("迁移文件 %s 版本号无效", entry.Name())
+		}
+		content, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		}
+		if m.Name != match[2] {
+			return nil, fmt.Errorf("迁移版本 %d 重复: %s 与 %s", version, m.Name, match[2])
+		}
+		if match[3] == "up" {
+			m.Up = string(content)
+		} else {
+			m.Down = string(content)
+		}
+	}
+
+	list := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" {
+			return nil, fmt.Errorf("迁移 %d_%s 缺少 up 脚本", m.Version, m.Name)
+		}
+		list = append(list, *m)
+	}
+	slices.SortFunc(list, func(a, b Migration) int { return cmp.Compare(a.Version, b.Version) })
+	return list, nil
+}
+
+// New 实例化迁移执行器
+func New(db *sql.DB, fsys fs.FS) (*Migrator, error) {
+	migrations, err := Load(fsys)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// Latest 最新的迁移版本，即当前代码期望的数据库版本
+func (m *Migrator) Latest() uint64 {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// Status 全部迁移的执行状态，按版本号升序
+func (m *Migrator) Status(ctx context.Context) ([]State, error) {
+	records, err := m.records(ctx, m.db)
+	if err != nil {
+		return nil, err
+	}
+	return states(m.migrations, records), nil
+}
+
+// Check 检查数据库是否已执行全部迁移（启动与就绪探针使用），不会创建迁移记录表
+func (m *Migrator) Check(ctx context.Context) error {
+	list, err := m.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	var pending []string
+	for _, s := range list {
+		if s.Dirty {
+			return fmt.Errorf("%w: 版本 %d", ErrDirty, s.Version)
+		}
+		if !s.Applied {
+			pending = append(pending, strconv.FormatUint(s.Version, 10))
+		}
+	}
+	if len(pending) > 0 {
+		return fmt.Errorf("%w: %v，期望版本 %d", ErrPending, pending, m.Latest())
+	}
+	return nil
+}
+
+// Up 按版本号顺序执行未执行的迁移，steps 为 0 时执行全部，返回本次执行的迁移
+func (m *Migrator) Up(ctx context.Context, steps int) (done []Migration, err error) {
+	err = m.locked(ctx, func(conn *sql.Conn) error {
+		list, err := m.prepare(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for _, s := range list {
+			if s.Applied || s.Unknown {
+				continue
+			}
+			if steps > 0 && len(done) >= steps {
+				break
+			}
+			if err := m.apply(ctx, conn, s.Migration); err != nil {
+				return err
+			}
+			done = append(done, s.Migration)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Down 按版本号倒序回滚已执行的迁移，steps 为 0 时回滚全部，返回本次回滚的迁移
+func (m *Migrator) Down(ctx context.Context, steps int) (done []Migration, err error) {
+	err = m.locked(ctx, func(conn *sql.Conn) error {
+		list, err := m.prepare(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for _, s := range slices.Backward(list) {
+			if !s.Applied {
+				continue
+			}
+			if steps > 0 && len(done) >= steps {
+				break
+			}
+			if s.Unknown {
+				return fmt.Errorf("迁移版本 %d 不在当前代码中，无法回滚", s.Version)
+			}
+			if err := m.revert(ctx, conn, s.Migration); err != nil {
+				return err
+			}
+			done = append(done, s.Migration)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Force 将数据库标记为指定版本：小于等于该版本的迁移记为已执行，其余记录删除，不执行任何脚本
+// 用于迁移失败后人工修复表结构，或将手工建表的数据库纳入迁移管理
+func (m *Migrator) Force(ctx context.Context, version uint64) error {
+	if version > 0 && !slices.ContainsFunc(m.migrations, func(mg Migration) bool { return mg.Version == version }) {
+		return fmt.Errorf("迁移版本 %d 不存在", version)
+	}
+	return m.locked(ctx, func(conn *sql.Conn) error {
+		if err := m.ensureTable(ctx, conn); err != nil {
+			return err
+		}
+		if _, err := conn.ExecContext(ctx, "DELETE FROM `"+table+"` WHERE `version` > ?", version); err != nil {
+			return err
+		}
+		for _, mg := range m.migrations {
+			if mg.Version > version {
+				break
+			}
+			_, err := conn.ExecContext(ctx,
+				"INSERT INTO `"+table+"` (`version`, `name`, `dirty`) VALUES (?, ?, 0) ON DUPLICATE KEY UPDATE `dirty` = 0",
+				mg.Version, mg.Name)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// prepare 创建迁移记录表并读取状态，存在失败的迁移时拒绝执行
+func (m *Migrator) prepare(ctx context.Context, conn *sql.Conn) ([]State, error) {
+	if err := m.ensureTable(ctx, conn); err != nil {
+		return nil, err
+	}
+	records, err := m.records(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+	list := states(m.migrations, records)
+	for _, s := range list {
+		if s.Dirty {
+			return nil, fmt.Errorf("%w: 版本 %d，请修复表结构后执行 migrate force <实际版本>", ErrDirty, s.Version)
+		}
+	}
+	return list, nil
+}
+
+// apply 执行单个迁移
+func (m *Migrator) apply(ctx context.Context, conn *sql.Conn, mg Migration) error {
+	if _, err := conn.ExecContext(ctx, "INSERT INTO `"+table+"` (`version`, `name`, `dirty`) VALUES (?, ?, 1)", mg.Version, mg.Name); err != nil {
+		return err
+	}
+	if _, err := conn.ExecContext(ctx, mg.Up); err != nil {
+		return fmt.Errorf("执行迁移 %d_%s 失败: %w", mg.Version, mg.Name, err)
+	}
+	_, err := conn.ExecContext(ctx, "UPDATE `"+table+"` SET `dirty` = 0, `applied_at` = NOW() WHERE `version` = ?", mg.Version)
+	return err
+}
+
+// revert 回滚单个迁移
+func (m *Migrator) revert(ctx context.Context, conn *sql.Conn, mg Migration) error {
+	if mg.Down == "" {
+		return fmt.Errorf("迁移 %d_%s 缺少 down 脚本，无法回滚", mg.Version, mg.Name)
+	}
+	if _, err := conn.ExecContext(ctx, "UPDATE `"+table+"` SET `dirty` = 1 WHERE `version` = ?", mg.Version); err != nil {
+		return err
+	}
+	if _, err := conn.ExecContext(ctx, mg.Down); err != nil {
+		return fmt.Errorf("回滚迁移 %d_%s 失败: %w", mg.Version, mg.Name, err)
+	}
+	_, err := conn.ExecContext(ctx, "DELETE FROM `"+table+"` WHERE `version` = ?", mg.Version)
+	return err
+}
+
+// locked 持有 MySQL 命名锁执行，避免多个实例同时迁移
+func (m *Migrator) locked(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	var got sql.NullInt64
+	if err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, ?)", lockName, lockWait).Scan(&got); err != nil {
+		return err
+	}
+	if got.Int64 != 1 {
+		return ErrLocked
+	}
+	defer conn.ExecContext(context.Background(), "SELECT RELEASE_LOCK(?)", lockName)
+
+	return fn(conn)
+}
+
+// ensureTable 创建迁移记录表
+func (m *Migrator) ensureTable(ctx context.Context, conn *sql.Conn) error {
+	_, err := conn.ExecContext(ctx, "CREATE TABLE IF NOT EXISTS `"+table+"` ("+
+		"`version` BIGINT UNSIGNED NOT NULL COMMENT '迁移版本号',"+
+		"`name` VARCHAR(200) NOT NULL DEFAULT '' COMMENT '迁移名称',"+
+		"`dirty` TINYINT NOT NULL DEFAULT 0 COMMENT '是否执行失败:0=否,1=是',"+
+		"`applied_at` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '执行时间',"+
+		"PRIMARY KEY (`version`)"+
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='数据库迁移记录表'")
+	return err
+}
+
+// queryer *sql.DB 与 *sql.Conn 的共同查询能力
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// records 读取迁移记录，记录表不存在时视为未执行任何迁移
+func (m *Migrator) records(ctx context.Context, q queryer) (map[uint64]record, error) {
+	records := make(map[uint64]record)
+	var exists int
+	err := q.QueryRowContext(ctx, "SELECT COUNT(*) FROM information_schema.tables WHERE table_schema = DATABASE() AND table_name = ?", table).Scan(&exists)
+	if err != nil || exists == 0 {
+		return records, err
+	}
+
+	// 执行时间按时间戳读取，不依赖连接串的 parseTime 设置
+	rows, err := q.QueryContext(ctx, "SELECT `version`, `name`, `dirty`, UNIX_TIMESTAMP(`applied_at`) FROM `"+table+"`")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var r record
+		var appliedAt int64
+		if err := rows.Scan(&r.version, &r.name, &r.dirty, &appliedAt); err != nil {
+			return nil, err
+		}
+		r.appliedAt = time.Unix(appliedAt, 0)
+		records[r.version] = r
+	}
+	return records, rows.Err()
+}
+
+// states 合并迁移脚本与执行记录，按版本号升序
+func states(migrations []Migration, records map[uint64]record) []State {
+	list := make([]State, 0, len(migrations)+len(records))
+	known := make(map[uint64]bool, len(migrations))
+	for _, mg := range migrations {
+		known[mg.Version] = true
+		s := State{Migration: mg}
+		if r, ok := records[mg.Version]; ok {
+			s.Applied, s.Dirty, s.AppliedAt = true, r.dirty, r.appliedAt
+		}
+		list = append(list, s)
+	}
+	for _, r := range records {
+		if !known[r.version] {
+			list = append(list, State{
+				Migration: Migration{Version: r.version, Name: r.name},
+				Applied:   true, Dirty: r.dirty, AppliedAt: r.appliedAt, Unknown: true,
+			})
+		}
+	}
+	slices.SortFunc(list, func(a, b State) int { return cmp.Compare(a.Version, b.Version) })
+	return list
+}
@@ -0,0 +1,79 @@
+package migrate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"thinkingModels/migrations"
+)
+
+func TestLoad(t *testing.T) {
+	list, err := Load(fstest.MapFS{
+		"0002_add_price.up.sql":   {Data: []byte("ALTER TABLE a ADD COLUMN b INT;")},
+		"0002_add_price.down.sql": {Data: []byte("ALTER TABLE a DROP COLUMN b;")},
+		"0010_seed.up.sql":        {Data: []byte("INSERT INTO a VALUES (1);")},
+		"0001_init.up.sql":        {Data: []byte("CREATE TABLE a (id INT);")},
+		"0001_init.down.sql":      {Data: []byte("DROP TABLE a;")},
+		"README.md":               {Data: []byte("忽略非迁移文件")},
+	})
+	assert.Nil(t, err)
+	assert.Len(t, list, 3)
+	assert.Equal(t, []uint64{1, 2, 10}, []uint64{list[0].Version, list[1].Version, list[2].Version}, "按数值而非字符串排序")
+	assert.Equal(t, "add_price", list[1].Name)
+	assert.Equal(t, "DROP TABLE a;", list[0].Down)
+	assert.Empty(t, list[2].Down)
+
+	_, err = Load(fstest.MapFS{"0001_a.up.sql": {}, "0001_b.up.sql": {}})
+	assert.ErrorContains(t, err, "重复")
+	_, err = Load(fstest.MapFS{"0001_a.down.sql": {}})
+	assert.ErrorContains(t, err, "缺少 up 脚本")
+}
+
+// 嵌入的迁移脚本必须能正常加载且版本连续
+func TestEmbedded(t *testing.T) {
+	list, err := Load(migrations.FS)
+	assert.Nil(t, err)
+	for i, mg := range list {
+		assert.Equal(t, uint64(i+1), mg.Version)
+		assert.NotEmpty(t, mg.Down, "%d_%s 缺少 down 脚本", mg.Version, mg.Name)
+	}
+}
+
+func TestStates(t *testing.T) {
+	now := time.Now()
+	list := states(
+		[]Migration{{Version: 1, Name: "init"}, {Version: 2, Name: "b"}, {Version: 3, Name: "c"}},
+		map[uint64]record{
+			1: {version: 1, name: "init", appliedAt: now},
+			3: {version: 3, name: "c", dirty: true},
+			5: {version: 5, name: "newer"},
+		},
+	)
+	assert.Len(t, list, 4)
+	assert.True(t, list[0].Applied)
+	assert.Equal(t, now, list[0].AppliedAt)
+	assert.False(t, list[1].Applied)
+	assert.True(t, list[2].Dirty)
+	assert.True(t, list[3].Unknown, "数据库中存在代码不认识的版本")
+	assert.Equal(t, "newer", list[3].Name)
+}
+
+func TestCreate(t *testing.T) {
+	dir := t.TempDir()
+	up, down, err := Create(dir, "Add User Level")
+	assert.Nil(t, err)
+	assert.Equal(t, filepath.Join(dir, "0001_add_user_level.up.sql"), up)
+	assert.Equal(t, filepath.Join(dir, "0001_add_user_level.down.sql"), down)
+
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, "0009_seed.up.sql"), []byte("SELECT 1;"), 0o644))
+	up, _, err = Create(dir, "next")
+	assert.Nil(t, err)
+	assert.Equal(t, filepath.Join(dir, "0010_next.up.sql"), up)
+
+	_, _, err = Create(dir, "--")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,443 @@
+package oauth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"thinkingModels/config"
+)
+
+// ========== OAuth2 / OIDC 客户端 ==========
+// 授权码模式 + PKCE(S256)，令牌端点使用 client_secret_post 认证。
+// 提供方返回 id_token 时按 JWKS 校验签名、iss、aud、exp 与 nonce；
+// 配置了 userinfo 端点时再拉取用户信息补全资料。
+
+var (
+	ErrProviderNotFound = errors.New("不支持的登录方式")
+	ErrNoSubject        = errors.New("第三方账号信息缺少用户标识")
+)
+
+// Provider 身份提供方
+type Provider struct {
+	Name         string
+	ClientId     string
+	ClientSecret string
+	Issuer       string
+	AuthUrl      string
+	TokenUrl     string
+	UserInfoUrl  string
+	JwksUrl      string
+	RedirectUrl  string
+	Scopes       []string
+	TrustEmail   bool
+	Client       *http.Client
+
+	mu         sync.Mutex
+	discovered bool
+	keys       map[string]any // kid -> 公钥
+}
+
+// Token 令牌端点响应
+type Token struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	IdToken     string `json:"id_token"`
+	Error       string `json:"error"`
+	ErrorDesc   string `json:"error_description"`
+}
+
+// Identity 第三方用户身份
+type Identity struct {
+	Subject       string // 提供方内唯一用户标识
+	Email         string
+	EmailVerified bool
+	Name          string
+	Avatar        string
+}
+
+// NewProvider 根据配置创建提供方
+func NewProvider(conf config.OauthProvider) *Provider {
+	return &Provider{
+		Name:         conf.Name,
+		ClientId:     conf.ClientId,
+		ClientSecret: conf.ClientSecret,
+		Issuer:       strings.TrimRight(conf.Issuer, "/"),
+		AuthUrl:      conf.AuthUrl,
+		TokenUrl:     conf.TokenUrl,
+		UserInfoUrl:  conf.UserInfoUrl,
+		JwksUrl:      conf.JwksUrl,
+		RedirectUrl:  conf.RedirectUrl,
+		Scopes:       conf.Scopes,
+		TrustEmail:   conf.TrustEmail,
+		Client:       &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+var (
+	providers     map[string]*Provider
+	providersOnce sync.Once
+)
+
+// loadProviders 从配置加载全部提供方
+func loadProviders() {
+	providers = make(map[string]*Provider, len(config.Config.Oauth2.Providers))
+	for _, conf := range config.Config.Oauth2.Providers {
+		if conf.Name == "" || conf.ClientId == "" {
+			continue
+		}
+		providers[conf.Name] = NewProvider(conf)
+	}
+}
+
+// GetProvider 按名称获取已配置的提供方
+func GetProvider(name string) (*Provider, error) {
+	providersOnce.Do(loadProviders)
+	p, ok := providers[name]
+	if !ok {
+		return nil, ErrProviderNotFound
+	}
+	return p, nil
+}
+
+// ProviderNames 已配置的提供方名称
+func ProviderNames() []string {
+	providersOnce.Do(loadProviders)
+	names := make([]string, 0, len(providers))
+	for name := range providers {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// RandomString 生成URL安全的随机串（用于 state、nonce）
+func RandomString() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// NewPKCE 生成 PKCE code_verifier 及其 S256 code_challenge
+func NewPKCE() (verifier, challenge string, err error) {
+	verifier, err = RandomString()
+	if err != nil {
+		return "", "", err
+	}
+	return verifier, S256Challenge(verifier), nil
+}
+
+// S256Challenge 计算 code_challenge = BASE64URL(SHA256(verifier))
+func S256Challenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// isOidc 是否按OIDC流程处理（申请了 openid scope）
+func (p *Provider) isOidc() bool {
+	return slices.Contains(p.Scopes, "openid")
+}
+
+// discover 读取 OIDC 发现文档，补全未配置的端点
+func (p *Provider) discover(ctx context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.discovered || p.Issuer == "" {
+		return nil
+	}
+	if p.AuthUrl != "" && p.TokenUrl != "" && (p.JwksUrl != "" || !p.isOidc()) {
+		p.discovered = true
+		return nil
+	}
+
+	doc := struct {
+		Issuer                string `json:"issuer"`
+		AuthorizationEndpoint string `json:"authorization_endpoint"`
+		TokenEndpoint         string `json:"token_endpoint"`
+		UserinfoEndpoint      string `json:"userinfo_endpoint"`
+		JwksUri               string `json:"jwks_uri"`
+	}{}
+	if err := p.getJSON(ctx, p.Issuer+"/.well-known/openid-configuration", "", &doc); err != nil {
+		return fmt.Errorf("读取OIDC发现文档失败: %w", err)
+	}
+	if doc.Issuer != "" && strings.TrimRight(doc.Issuer, "/") != p.Issuer {
+		return errors.New("OIDC发现文档 issuer 不匹配")
+	}
+	if p.AuthUrl == "" {
+		p.AuthUrl = doc.AuthorizationEndpoint
+	}
+	if p.TokenUrl == "" {
+		p.TokenUrl = doc.TokenEndpoint
+	}
+	if p.UserInfoUrl == "" {
+		p.UserInfoUrl = doc.UserinfoEndpoint
+	}
+	if p.JwksUrl == "" {
+		p.JwksUrl = doc.JwksUri
+	}
+	p.discovered = true
+	return nil
+}
+
+// AuthCodeURL 生成跳转到提供方的授权地址
+func (p *Provider) AuthCodeURL(ctx context.Context, state, nonce, challenge string) (string, error) {
+	if err := p.discover(ctx); err != nil {
+		return "", err
+	}
+	if p.AuthUrl == "" {
+		return "", errors.New("未配置授权端点")
+	}
+	query := url.Values{}
+	query.Set("response_type", "code")
+	query.Set("client_id", p.ClientId)
+	query.Set("redirect_uri", p.RedirectUrl)
+	query.Set("scope", strings.Join(p.Scopes, " "))
+	query.Set("state", state)
+	query.Set("code_challenge", challenge)
+	query.Set("code_challenge_method", "S256")
+	if p.isOidc() && nonce != "" {
+		query.Set("nonce", nonce)
+	}
+
+	sep := "?"
+	if strings.Contains(p.AuthUrl, "?") {
+		sep = "&"
+	}
+	return p.AuthUrl + sep + query.Encode(), nil
+}
+
+// Exchange 使用授权码和 code_verifier 换取令牌
+func (p *Provider) Exchange(ctx context.Context, code, verifier string) (*Token, error) {
+	if err := p.discover(ctx); err != nil {
+		return nil, err
+	}
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.RedirectUrl)
+	form.Set("client_id", p.ClientId)
+	form.Set("client_secret", p.ClientSecret)
+	form.Set("code_verifier", verifier)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.TokenUrl, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+
+	token := &Token{}
+	if err = json.Unmarshal(body, token); err != nil {
+		return nil, fmt.Errorf("令牌端点响应格式错误: %w", err)
+	}
+	if token.Error != "" {
+		return nil, fmt.Errorf("授权码换取令牌失败: %s %s", token.Error, token.ErrorDesc)
+	}
+	if resp.StatusCode != http.StatusOK || token.AccessToken == "" {
+		return nil, fmt.Errorf("授权码换取令牌失败: HTTP %d", resp.StatusCode)
+	}
+	return token, nil
+}
+
+// Identity 解析第三方用户身份：优先 id_token，userinfo 端点补全资料
+func (p *Provider) Identity(ctx context.Context, token *Token, nonce string) (*Identity, error) {
+	if err := p.discover(ctx); err != nil {
+		return nil, err
+	}
+	identity := &Identity{}
+	if token.IdToken != "" {
+		claims, err := p.verifyIdToken(ctx, token.IdToken, nonce)
+		if err != nil {
+			return nil, err
+		}
+		p.fill(identity, claims)
+	} else if p.isOidc() {
+		return nil, errors.New("提供方未返回 id_token")
+	}
+
+	if p.UserInfoUrl != "" {
+		info := map[string]any{}
+		if err := p.getJSON(ctx, p.UserInfoUrl, token.AccessToken, &info); err != nil {
+			return nil, fmt.Errorf("获取第三方用户信息失败: %w", err)
+		}
+		sub := claimString(info, "sub", "id")
+		if identity.Subject != "" && sub != "" && sub != identity.Subject {
+			return nil, errors.New("第三方用户信息与 id_token 不一致")
+		}
+		p.fill(identity, info)
+	}
+
+	if identity.Subject == "" {
+		return nil, ErrNoSubject
+	}
+	if identity.Email == "" {
+		identity.EmailVerified = false
+	}
+	return identity, nil
+}
+
+// fill 将声明合并到身份信息（已有值不覆盖）
+func (p *Provider) fill(identity *Identity, claims map[string]any) {
+	if identity.Subject == "" {
+		identity.Subject = claimString(claims, "sub", "id")
+	}
+	if identity.Email == "" {
+		identity.Email = claimString(claims, "email")
+		identity.EmailVerified = claimBool(claims, "email_verified") || (p.TrustEmail && identity.Email != "")
+	}
+	if identity.Name == "" {
+		identity.Name = claimString(claims, "name", "nickname", "preferred_username", "login")
+	}
+	if identity.Avatar == "" {
+		identity.Avatar = claimString(claims, "picture", "avatar_url")
+	}
+}
+
+// verifyIdToken 校验 id_token 签名与声明
+func (p *Provider) verifyIdToken(ctx context.Context, idToken, nonce string) (jwt.MapClaims, error) {
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512"}),
+		jwt.WithAudience(p.ClientId),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(time.Minute),
+	}
+	if p.Issuer != "" {
+		opts = append(opts, jwt.WithIssuer(p.Issuer))
+	}
+
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(idToken, claims, func(t *jwt.Token) (any, error) {
+		kid, _ := t.Header["kid"].(string)
+		return p.publicKey(ctx, kid)
+	}, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("id_token 校验失败: %w", err)
+	}
+	if nonce != "" && claimString(claims, "nonce") != nonce {
+		return nil, errors.New("id_token 校验失败: nonce 不匹配")
+	}
+	return claims, nil
+}
+
+// publicKey 按 kid 查找签名公钥，未命中时刷新一次 JWKS（提供方轮换密钥）
+func (p *Provider) publicKey(ctx context.Context, kid string) (any, error) {
+	p.mu.Lock()
+	key, ok := p.lookupKey(kid)
+	p.mu.Unlock()
+	if ok {
+		return key, nil
+	}
+
+	if p.JwksUrl == "" {
+		return nil, errors.New("未配置 JWKS 地址")
+	}
+	jwks := struct {
+		Keys []jwk `json:"keys"`
+	}{}
+	if err := p.getJSON(ctx, p.JwksUrl, "", &jwks); err != nil {
+		return nil, fmt.Errorf("获取 JWKS 失败: %w", err)
+	}
+	keys := make(map[string]any, len(jwks.Keys))
+	for _, k := range jwks.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		if pub, err := k.PublicKey(); err == nil {
+			keys[k.Kid] = pub
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.keys = keys
+	if key, ok = p.lookupKey(kid); ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("未找到签名公钥 kid=%s", kid)
+}
+
+// lookupKey 查找公钥；未指定 kid 且只有一把公钥时直接使用
+func (p *Provider) lookupKey(kid string) (any, bool) {
+	if key, ok := p.keys[kid]; ok {
+		return key, true
+	}
+	if kid == "" && len(p.keys) == 1 {
+		for _, key := range p.keys {
+			return key, true
+		}
+	}
+	return nil, false
+}
+
+// getJSON GET 请求并解析 JSON，accessToken 非空时携带 Bearer 认证
+func (p *Provider) getJSON(ctx context.Context, endpoint, accessToken string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if accessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+	}
+	resp, err := p.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	return json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(out)
+}
+
+// claimString 取第一个非空的字符串声明（数字ID转为字符串）
+func claimString(claims map[string]any, names ...string) string {
+	for _, name := range names {
+		switch v := claims[name].(type) {
+		case string:
+			if v != "" {
+				return v
+			}
+		case float64:
+			return fmt.Sprintf("%.0f", v)
+		case json.Number:
+			return v.String()
+		}
+	}
+	return ""
+}
+
+// claimBool 读取布尔声明，兼容字符串 "true"
+func claimBool(claims map[string]any, name string) bool {
+	switch v := claims[name].(type) {
+	case bool:
+		return v
+	case string:
+		return v == "true"
+	}
+	return false
+}
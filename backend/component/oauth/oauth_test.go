@@ -0,0 +1,169 @@
+package oauth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"thinkingModels/config"
+)
+
+// mockIdP 本地模拟 OIDC 提供方：发现文档、授权码换令牌(校验PKCE)、JWKS、userinfo
+type mockIdP struct {
+	*httptest.Server
+	key       *rsa.PrivateKey
+	challenge string // 授权请求中的 code_challenge
+	nonce     string
+	claims    jwt.MapClaims
+}
+
+func newMockIdP(t *testing.T) *mockIdP {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.Nil(t, err)
+	idp := &mockIdP{key: key}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 idp.URL,
+			"authorization_endpoint": idp.URL + "/authorize",
+			"token_endpoint":         idp.URL + "/token",
+			"userinfo_endpoint":      idp.URL + "/userinfo",
+			"jwks_uri":               idp.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": "k1",
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.Form.Get("code") != "good-code" || S256Challenge(r.Form.Get("code_verifier")) != idp.challenge {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+		claims := jwt.MapClaims{
+			"iss":   idp.URL,
+			"aud":   "client-1",
+			"sub":   "idp-user-1",
+			"exp":   time.Now().Add(time.Minute).Unix(),
+			"nonce": idp.nonce,
+		}
+		for k, v := range idp.claims {
+			claims[k] = v
+		}
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+		token.Header["kid"] = "k1"
+		idToken, _ := token.SignedString(key)
+		json.NewEncoder(w).Encode(map[string]string{"access_token": "at-1", "token_type": "Bearer", "id_token": idToken})
+	})
+	mux.HandleFunc("/userinfo", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer at-1" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{"sub": "idp-user-1", "name": "Mock User", "picture": "http://img/1.png"})
+	})
+	idp.Server = httptest.NewServer(mux)
+	t.Cleanup(idp.Close)
+	return idp
+}
+
+// authorize 模拟用户在提供方完成授权：记录授权请求参数
+func (idp *mockIdP) authorize(t *testing.T, authUrl string) {
+	u, err := url.Parse(authUrl)
+	assert.Nil(t, err)
+	assert.Equal(t, idp.URL+"/authorize", u.Scheme+"://"+u.Host+u.Path)
+	assert.Equal(t, "S256", u.Query().Get("code_challenge_method"))
+	idp.challenge = u.Query().Get("code_challenge")
+	idp.nonce = u.Query().Get("nonce")
+}
+
+func TestOidcLogin(t *testing.T) {
+	idp := newMockIdP(t)
+	idp.claims = jwt.MapClaims{"email": "a@example.com", "email_verified": true}
+	p := NewProvider(config.OauthProvider{
+		Name:        "mock",
+		ClientId:    "client-1",
+		Issuer:      idp.URL,
+		RedirectUrl: "http://app/callback",
+		Scopes:      []string{"openid", "email", "profile"},
+	})
+	ctx := context.Background()
+
+	verifier, challenge, err := NewPKCE()
+	assert.Nil(t, err)
+	authUrl, err := p.AuthCodeURL(ctx, "state-1", "nonce-1", challenge)
+	assert.Nil(t, err)
+	idp.authorize(t, authUrl)
+	assert.Equal(t, challenge, idp.challenge)
+
+	// 错误的 code_verifier 无法换取令牌
+	_, err = p.Exchange(ctx, "good-code", "wrong-verifier")
+	assert.NotNil(t, err)
+
+	token, err := p.Exchange(ctx, "good-code", verifier)
+	assert.Nil(t, err)
+	identity, err := p.Identity(ctx, token, "nonce-1")
+	assert.Nil(t, err)
+	assert.Equal(t, &Identity{
+		Subject:       "idp-user-1",
+		Email:         "a@example.com",
+		EmailVerified: true,
+		Name:          "Mock User",
+		Avatar:        "http://img/1.png",
+	}, identity)
+
+	// nonce 不匹配
+	_, err = p.Identity(ctx, token, "nonce-2")
+	assert.NotNil(t, err)
+
+	// 受众不匹配
+	other := NewProvider(config.OauthProvider{ClientId: "client-2", Issuer: idp.URL, Scopes: []string{"openid"}})
+	_, err = other.Identity(ctx, token, "")
+	assert.ErrorIs(t, err, jwt.ErrTokenInvalidAudience)
+}
+
+func TestOidcLogin_UnverifiedEmail(t *testing.T) {
+	idp := newMockIdP(t)
+	idp.claims = jwt.MapClaims{"email": "a@example.com", "email_verified": false}
+	p := NewProvider(config.OauthProvider{ClientId: "client-1", Issuer: idp.URL, Scopes: []string{"openid"}})
+	ctx := context.Background()
+
+	verifier, challenge, _ := NewPKCE()
+	authUrl, err := p.AuthCodeURL(ctx, "s", "", challenge)
+	assert.Nil(t, err)
+	idp.authorize(t, authUrl)
+
+	token, err := p.Exchange(ctx, "good-code", verifier)
+	assert.Nil(t, err)
+	identity, err := p.Identity(ctx, token, "")
+	assert.Nil(t, err)
+	assert.False(t, identity.EmailVerified)
+}
+
+func TestS256Challenge(t *testing.T) {
+	// BASE64URL(SHA256(verifier))，无填充
+	assert.Equal(t, "6oWgK98xFfqW7QSYNkidldjTt-Mhfqqzrg3gIONWcRg", S256Challenge("dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gXk2vjbvc"))
+
+	verifier, challenge, err := NewPKCE()
+	assert.Nil(t, err)
+	assert.Len(t, verifier, 43)
+	assert.Equal(t, S256Challenge(verifier), challenge)
+}
@@ -0,0 +1,60 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+// sweepEvery 清理过期计数的间隔
+const sweepEvery = time.Minute
+
+// memoryStore 进程内滑动窗口计数，redis 不可用时使用
+type memoryStore struct {
+	mu        sync.Mutex
+	counters  map[string]*counter
+	lastSweep time.Time
+}
+
+type counter struct {
+	idx      int64 // 当前固定窗口序号
+	prev     int
+	curr     int
+	expireAt time.Time
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{counters: map[string]*counter{}}
+}
+
+// take 与 redis 脚本相同的计数逻辑
+func (s *memoryStore) take(key string, limit int, idx int64, elapsed float64, now, expireAt time.Time) (allowed bool, prev, curr int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if now.Sub(s.lastSweep) >= sweepEvery {
+		for k, c := range s.counters {
+			if now.After(c.expireAt) {
+				delete(s.counters, k)
+			}
+		}
+		s.lastSweep = now
+	}
+
+	c := s.counters[key]
+	switch {
+	case c == nil:
+		c = &counter{idx: idx}
+		s.counters[key] = c
+	case c.idx == idx-1:
+		c.idx, c.prev, c.curr = idx, c.curr, 0
+	case c.idx != idx:
+		c.idx, c.prev, c.curr = idx, 0, 0
+	}
+
+	if float64(c.prev)*(1-elapsed)+float64(c.curr) >= float64(limit) {
+		return false, c.prev, c.curr
+	}
+	c.curr++
+	c.expireAt = expireAt
+	return true, c.prev, c.curr
+}
@@ -0,0 +1,102 @@
+package ratelimit
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"thinkingModels/component/logger"
+	"thinkingModels/component/redis"
+)
+
+// ========== 滑动窗口限流 ==========
+// 最近一个窗口内的请求数 ≈ 上一固定窗口计数 × 其仍落在滑动窗口内的比例 + 当前固定窗口计数，
+// 达到上限即拒绝。计数保存在 redis，多实例共享；redis 不可用时退回进程内计数（单实例各自计数），
+// 并在 fallbackFor 内不再访问 redis，避免每个请求都等待连接超时。
+
+// fallbackFor redis 出错后改用进程内计数的时长
+const fallbackFor = 10 * time.Second
+
+// redisTake 便于测试替换
+var redisTake = redis.SlidingWindow
+
+var (
+	memory        = newMemoryStore()
+	fallbackUntil atomic.Int64 // 进程内计数截止时间(UnixNano)
+)
+
+// Result 限流结果
+type Result struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int // 剩余可用次数
+	Reset      int // 当前固定窗口结束前的秒数，之后计数开始回落
+	RetryAfter int // 被拒绝时需等待的秒数
+}
+
+// Allow 对 key 计一次请求，limit<=0 表示不限
+func Allow(ctx context.Context, key string, limit int, window time.Duration) Result {
+	return allow(ctx, key, limit, window, time.Now())
+}
+
+func allow(ctx context.Context, key string, limit int, window time.Duration, now time.Time) Result {
+	if limit <= 0 || window <= 0 {
+		return Result{Allowed: true, Limit: limit, Remaining: math.MaxInt32}
+	}
+
+	idx := now.UnixNano() / int64(window)
+	elapsed := float64(now.UnixNano()%int64(window)) / float64(window)
+
+	var allowed bool
+	var prev, curr int
+	useMemory := now.UnixNano() < fallbackUntil.Load()
+	if !useMemory {
+		var err error
+		expire := int(math.Ceil((2 * window).Seconds()))
+		allowed, prev, curr, err = redisTake(ctx, key+":"+strconv.FormatInt(idx, 10), key+":"+strconv.FormatInt(idx-1, 10), limit, elapsed, expire)
+		if err != nil {
+			useMemory = true
+			fallbackUntil.Store(now.Add(fallbackFor).UnixNano())
+			logger.Ctx(ctx).Warn("rate limit falls back to memory", slog.String("error", err.Error()))
+		}
+	}
+	if useMemory {
+		allowed, prev, curr = memory.take(key, limit, idx, elapsed, now, now.Add(2*window))
+	}
+	return result(allowed, limit, prev, curr, elapsed, window)
+}
+
+// result 根据计数计算剩余次数、重置时间与需等待时间
+func result(allowed bool, limit, prev, curr int, elapsed float64, window time.Duration) Result {
+	res := Result{Allowed: allowed, Limit: limit, Reset: seconds((1 - elapsed) * window.Seconds())}
+
+	estimate := float64(prev)*(1-elapsed) + float64(curr)
+	res.Remaining = int(math.Ceil(float64(limit) - estimate))
+	if res.Remaining < 0 || !allowed {
+		res.Remaining = 0
+	}
+	if allowed {
+		return res
+	}
+
+	// 估算值回落到上限以下所需时间
+	if curr >= limit {
+		// 当前窗口计数已达上限：等到窗口结束，它成为上一窗口后再按比例回落
+		res.RetryAfter = seconds((1-elapsed)*window.Seconds() + (1-float64(limit)/float64(curr))*window.Seconds())
+	} else {
+		res.RetryAfter = seconds((1 - float64(limit-curr)/float64(prev) - elapsed) * window.Seconds())
+	}
+	res.Reset = res.RetryAfter
+	return res
+}
+
+// seconds 向上取整，至少1秒
+func seconds(s float64) int {
+	if n := int(math.Ceil(s)); n > 1 {
+		return n
+	}
+	return 1
+}
@@ -0,0 +1,75 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// useMemory 模拟 redis 不可用
+func useMemory(t *testing.T) {
+	old := redisTake
+	redisTake = func(context.Context, string, string, int, float64, int) (bool, int, int, error) {
+		return false, 0, 0, errors.New("dial tcp: connection refused")
+	}
+	memory = newMemoryStore()
+	fallbackUntil.Store(0)
+	t.Cleanup(func() {
+		redisTake = old
+		fallbackUntil.Store(0)
+	})
+}
+
+func TestAllow_MemoryFallback(t *testing.T) {
+	useMemory(t)
+	ctx := context.Background()
+	start := time.Unix(1_699_999_980, 0) // 恰为60秒窗口的起点
+
+	for i := 1; i <= 3; i++ {
+		res := allow(ctx, "test:ip:1", 3, time.Minute, start.Add(time.Duration(i)*time.Second))
+		assert.True(t, res.Allowed)
+		assert.Equal(t, 3-i, res.Remaining)
+	}
+	assert.Greater(t, fallbackUntil.Load(), int64(0), "redis 出错后一段时间内直接使用进程内计数")
+
+	res := allow(ctx, "test:ip:1", 3, time.Minute, start.Add(10*time.Second))
+	assert.False(t, res.Allowed)
+	assert.Equal(t, 0, res.Remaining)
+	assert.Equal(t, 50, res.RetryAfter, "等到当前窗口结束")
+
+	// 其他 key 不受影响
+	assert.True(t, allow(ctx, "test:ip:2", 3, time.Minute, start.Add(10*time.Second)).Allowed)
+
+	// 下一窗口过去一半时上一窗口计数仍按比例计入：3×(1-0.5) = 1.5，还可再请求2次
+	next := start.Add(90 * time.Second)
+	assert.True(t, allow(ctx, "test:ip:1", 3, time.Minute, next).Allowed)
+	assert.True(t, allow(ctx, "test:ip:1", 3, time.Minute, next).Allowed)
+	assert.False(t, allow(ctx, "test:ip:1", 3, time.Minute, next).Allowed)
+
+	// 两个窗口之后计数清零
+	assert.True(t, allow(ctx, "test:ip:1", 3, time.Minute, start.Add(200*time.Second)).Allowed)
+
+	// 不限
+	assert.True(t, allow(ctx, "test:ip:1", 0, time.Minute, start).Allowed)
+}
+
+func TestResult(t *testing.T) {
+	// 放行：上一窗口10次、已过去一半、当前窗口3次 → 估算8次
+	res := result(true, 10, 10, 3, 0.5, time.Minute)
+	assert.Equal(t, 2, res.Remaining)
+	assert.Equal(t, 30, res.Reset)
+	assert.Equal(t, 0, res.RetryAfter)
+
+	// 拒绝：当前窗口未满，等上一窗口计数回落 → 10×(1-f)+6 < 10，f > 0.6
+	res = result(false, 10, 10, 6, 0.5, time.Minute)
+	assert.Equal(t, 0, res.Remaining)
+	assert.Equal(t, 6, res.RetryAfter)
+	assert.Equal(t, res.RetryAfter, res.Reset)
+
+	// 拒绝：当前窗口已满 → 等窗口结束(30秒)后再回落 20×(1-f) < 10，f > 0.5
+	res = result(false, 10, 0, 20, 0.5, time.Minute)
+	assert.Equal(t, 60, res.RetryAfter)
+}
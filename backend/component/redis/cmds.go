@@ -44,3 +44,105 @@ func HLen(key string) (int, error) {
 	}
 	return len, nil
 }
+
+func Del(keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	conn := GetRedisConn()
+	defer conn.Close()
+	_, err := conn.Do("DEL", redis.Args{}.AddFlat(keys)...)
+	return err
+}
+
+func SAdd(key string, expire int, members ...string) error {
+	conn := GetRedisConn()
+	defer conn.Close()
+	_, err := conn.Do("SADD", redis.Args{}.Add(key).AddFlat(members)...)
+	if err != nil {
+		return err
+	}
+	if expire > 0 {
+		conn.Do("EXPIRE", key, expire)
+	}
+	return nil
+}
+
+func SRem(key string, members ...string) error {
+	conn := GetRedisConn()
+	defer conn.Close()
+	_, err := conn.Do("SREM", redis.Args{}.Add(key).AddFlat(members)...)
+	return err
+}
+
+func SMembers(key string) ([]string, error) {
+	conn := GetRedisConn()
+	defer conn.Close()
+	return redis.Strings(conn.Do("SMEMBERS", key))
+}
+
+func SetEx(key, value string, expire int) error {
+	conn := GetRedisConn()
+	defer conn.Close()
+	_, err := conn.Do("SET", key, value, "EX", expire)
+	return err
+}
+
+func MGet(keys ...string) ([]string, error) {
+	conn := GetRedisConn()
+	defer conn.Close()
+	return redis.Strings(conn.Do("MGET", redis.Args{}.AddFlat(keys)...))
+}
+
+// GetDel 原子读取并删除，key不存在返回空字符串
+func GetDel(key string) (string, error) {
+	conn := GetRedisConn()
+	defer conn.Close()
+	conn.Send("MULTI")
+	conn.Send("GET", key)
+	conn.Send("DEL", key)
+	values, err := redis.Values(conn.Do("EXEC"))
+	if err != nil {
+		return "", err
+	}
+	value, err := redis.String(values[0], nil)
+	if err == redis.ErrNil {
+		return "", nil
+	}
+	return value, err
+}
+
+// Incr 自增计数，首次创建时设置过期时间
+func Incr(key string, expire int) (int, error) {
+	conn := GetRedisConn()
+	defer conn.Close()
+	count, err := redis.Int(conn.Do("INCR", key))
+	if err != nil {
+		return 0, err
+	}
+	if count == 1 && expire > 0 {
+		conn.Do("EXPIRE", key, expire)
+	}
+	return count, nil
+}
+
+// TTL 剩余过期秒数，key不存在返回-2，未设置过期返回-1
+func TTL(key string) (int, error) {
+	conn := GetRedisConn()
+	defer conn.Close()
+	return redis.Int(conn.Do("TTL", key))
+}
+
+// SetNx key不存在时写入并设置过期时间，返回是否写入成功
+func SetNx(key, value string, expire int) (bool, error) {
+	conn := GetRedisConn()
+	defer conn.Close()
+	res, err := redis.String(conn.Do("SET", key, value, "EX", expire, "NX"))
+	if err == redis.ErrNil {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return res == "OK", nil
+}
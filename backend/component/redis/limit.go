@@ -0,0 +1,39 @@
+package redis
+
+import (
+	"context"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// slidingWindowScript 滑动窗口计数（原子执行）
+// KEYS[1] 当前固定窗口计数 KEYS[2] 上一固定窗口计数
+// ARGV[1] 上限 ARGV[2] 当前窗口已过去的比例(0-1) ARGV[3] 计数过期秒数
+var slidingWindowScript = redis.NewScript(2, `
+local prev = tonumber(redis.call('GET', KEYS[2]) or '0')
+local curr = tonumber(redis.call('GET', KEYS[1]) or '0')
+if prev * (1 - tonumber(ARGV[2])) + curr >= tonumber(ARGV[1]) then
+	return {0, prev, curr}
+end
+curr = redis.call('INCR', KEYS[1])
+if curr == 1 then
+	redis.call('EXPIRE', KEYS[1], ARGV[3])
+end
+return {1, prev, curr}
+`)
+
+// SlidingWindow 按上一窗口剩余比例加权估算最近一个窗口内的请求数，未达上限时计入本次请求
+// 返回是否放行，以及上一窗口、当前窗口的计数（放行时含本次）
+func SlidingWindow(ctx context.Context, currKey, prevKey string, limit int, elapsed float64, expire int) (allowed bool, prev, curr int, err error) {
+	conn, err := pool.GetContext(ctx)
+	if err != nil {
+		return false, 0, 0, err
+	}
+	defer conn.Close()
+
+	values, err := redis.Ints(slidingWindowScript.DoContext(ctx, conn, currKey, prevKey, limit, elapsed, expire))
+	if err != nil {
+		return false, 0, 0, err
+	}
+	return values[0] == 1, values[1], values[2], nil
+}
@@ -0,0 +1,80 @@
+package redis
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"thinkingModels/component/logger"
+)
+
+// tracedConn 记录慢命令与执行错误的连接包装
+// 通过 redis.DoContext 传入上下文时日志带 trace id；键中可能含令牌，只记录最后一个冒号之前的前缀
+type tracedConn struct {
+	redis.Conn
+	slow time.Duration // 0 表示不记录慢命令
+}
+
+func (c *tracedConn) Do(cmd string, args ...any) (any, error) {
+	return c.DoContext(context.Background(), cmd, args...)
+}
+
+func (c *tracedConn) DoContext(ctx context.Context, cmd string, args ...any) (any, error) {
+	start := time.Now()
+	reply, err := redis.DoContext(c.Conn, ctx, cmd, args...)
+	c.log(ctx, cmd, args, time.Since(start), err)
+	return reply, err
+}
+
+func (c *tracedConn) DoWithTimeout(timeout time.Duration, cmd string, args ...any) (any, error) {
+	start := time.Now()
+	reply, err := redis.DoWithTimeout(c.Conn, timeout, cmd, args...)
+	c.log(context.Background(), cmd, args, time.Since(start), err)
+	return reply, err
+}
+
+func (c *tracedConn) ReceiveContext(ctx context.Context) (any, error) {
+	return redis.ReceiveContext(c.Conn, ctx)
+}
+
+func (c *tracedConn) ReceiveWithTimeout(timeout time.Duration) (any, error) {
+	return redis.ReceiveWithTimeout(c.Conn, timeout)
+}
+
+func (c *tracedConn) log(ctx context.Context, cmd string, args []any, elapsed time.Duration, err error) {
+	if cmd == "" { // 连接池归还连接时的 flush
+		return
+	}
+
+	var level slog.Level
+	var msg string
+	switch {
+	case err != nil && err != redis.ErrNil:
+		level, msg = slog.LevelWarn, "redis error"
+	case c.slow > 0 && elapsed >= c.slow:
+		level, msg = slog.LevelWarn, "slow redis"
+	default:
+		return
+	}
+
+	attrs := []slog.Attr{slog.String("cmd", strings.ToUpper(cmd)), slog.Duration("elapsed", elapsed)}
+	if len(args) > 0 {
+		if key, ok := args[0].(string); ok {
+			attrs = append(attrs, slog.String("key", keyPrefix(key)))
+		}
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+	logger.Ctx(ctx).LogAttrs(ctx, level, msg, attrs...)
+}
+
+// keyPrefix 键的业务前缀，如 reset_password:abc → reset_password:*
+func keyPrefix(key string) string {
+	if i := strings.LastIndexByte(key, ':'); i >= 0 {
+		return key[:i+1] + "*"
+	}
+	return key
+}
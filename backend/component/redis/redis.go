@@ -1,10 +1,13 @@
 package redis
 
 import (
+	"context"
 	"sync"
 	"time"
 
 	"github.com/gomodule/redigo/redis"
+	"thinkingModels/component/metrics"
+	"thinkingModels/config"
 )
 
 var once sync.Once
@@ -12,14 +15,24 @@ var pool *redis.Pool
 
 func init() {
 	once.Do(func() {
+		conf := config.Config.Redis
 		pool = &redis.Pool{
-			MaxIdle:     60,                              // 最大连接数量
-			MaxActive:   60,                              // 最大活跃连接数，不确定用0
-			IdleTimeout: time.Duration(60) * time.Second, // 空闲连接超时时间|查询:CONFIG GET timeout|设置CONFIG SET timeout 65
+			MaxIdle:     conf.MaxIdle,                                  // 最大空闲连接数
+			MaxActive:   conf.MaxActive,                                // 最大活跃连接数，0表示不限
+			IdleTimeout: time.Duration(conf.IdleTimeout) * time.Second, // 空闲连接超时时间|查询:CONFIG GET timeout|设置CONFIG SET timeout 65
 			Dial: func() (redis.Conn, error) {
-				return redis.Dial("tcp", "121.37.146.209:6380")
+				conn, err := redis.Dial("tcp", conf.Address,
+					redis.DialPassword(conf.Password),
+					redis.DialDatabase(conf.Db),
+					redis.DialConnectTimeout(time.Duration(conf.DialTimeout)*time.Second),
+				)
+				if err != nil {
+					return nil, err
+				}
+				return &tracedConn{Conn: conn, slow: time.Duration(config.Config.Log.SlowRedis) * time.Millisecond}, nil
 			},
 		}
+		metrics.RegisterRedisPool(pool.ActiveCount, pool.IdleCount)
 	})
 }
 
@@ -33,6 +46,22 @@ func CloseRedisConn(conn redis.Conn) {
 	conn.Close()
 }
 
+// Ping 检查 redis 连通性（就绪探针）
+func Ping(ctx context.Context) error {
+	conn, err := pool.GetContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	_, err = redis.DoContext(conn, ctx, "PING")
+	return err
+}
+
+// Close 关闭连接池（优雅退出时调用）
+func Close() error {
+	return pool.Close()
+}
+
 // 分布式单用户锁
 func GetLock(key string, time uint) (redis.Conn, bool, error) {
 	conn := pool.Get()
@@ -43,6 +72,10 @@ func GetLock(key string, time uint) (redis.Conn, bool, error) {
 		time = 10
 	}
 	res, err := redis.String(conn.Do("SET", key, "1", "EX", time, "NX"))
+	if err == redis.ErrNil {
+		// NX 未设置成功时返回 nil：锁已被占用
+		return conn, false, nil
+	}
 	if err != nil {
 		return conn, false, err
 	}
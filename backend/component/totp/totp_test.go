@@ -0,0 +1,53 @@
+package totp
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestHotp RFC 6238 附录B SHA1 测试向量（8位）
+func TestHotp(t *testing.T) {
+	key := []byte("12345678901234567890")
+	vectors := map[int64]string{
+		59:          "94287082",
+		1111111109:  "07081804",
+		1111111111:  "14050471",
+		1234567890:  "89005924",
+		2000000000:  "69279037",
+		20000000000: "65353130",
+	}
+	for ts, want := range vectors {
+		assert.Equal(t, want, hotp(key, uint64(ts/Period), 8), ts)
+	}
+}
+
+// TestValidate 当前及前后一个时间步有效，并返回匹配的时间步
+func TestValidate(t *testing.T) {
+	secret, err := GenerateSecret()
+	assert.Nil(t, err)
+	now := time.Unix(1700000000, 0)
+	step := now.Unix() / Period
+
+	code, err := Code(secret, step-1)
+	assert.Nil(t, err)
+	matched, ok := Validate(secret, code, now)
+	assert.True(t, ok)
+	assert.Equal(t, step-1, matched)
+
+	code, _ = Code(secret, step+2)
+	_, ok = Validate(secret, code, now)
+	assert.False(t, ok)
+
+	_, ok = Validate(secret, "12345", now)
+	assert.False(t, ok)
+}
+
+func TestURI(t *testing.T) {
+	uri := URI("JBSWY3DPEHPK3PXP", "thinkingModels", "alice@example.com")
+	assert.True(t, strings.HasPrefix(uri, "otpauth://totp/thinkingModels:alice@example.com?"))
+	assert.Contains(t, uri, "secret=JBSWY3DPEHPK3PXP")
+	assert.Contains(t, uri, "issuer=thinkingModels")
+}
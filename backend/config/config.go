@@ -5,54 +5,194 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
-
-	"github.com/spf13/viper"
 )
 
-var Config struct {
-	Env   string `json:"env"`
-	Host  string `json:"host"`
-	Port  string `json:"port"`
+// Config 全局配置，启动时按 config.yaml → config.{env}.yaml → TM_* 环境变量 的顺序加载并校验
+var Config AppConfig
+
+// AppConfig 应用配置
+// 标记 secret 的字段为敏感信息，输出日志时脱敏（见 Redacted）
+type AppConfig struct {
+	Env    string `json:"env"` // 运行环境: dev | test | prod，决定加载的环境配置文件
+	Host   string `json:"host"`
+	Port   string `json:"port"`
+	Server struct {
+		ReadHeaderTimeout int      `json:"readHeaderTimeout"` // 读取请求头超时(秒)
+		IdleTimeout       int      `json:"idleTimeout"`       // Keep-Alive 空闲连接超时(秒)
+		ShutdownDelay     int      `json:"shutdownDelay"`     // 收到退出信号后继续服务的时间(秒)，期间 /readyz 返回 503，等待负载均衡摘除流量
+		ShutdownTimeout   int      `json:"shutdownTimeout"`   // 排空进行中请求与后台任务的最长等待时间(秒)
+		ProbeTimeout      int      `json:"probeTimeout"`      // /readyz 检查依赖的超时(秒)
+		TrustedProxies    []string `json:"trustedProxies"`    // 可信反向代理的IP或网段，仅这些来源转发的 X-Forwarded-For 用于识别客户端IP，默认不信任任何代理
+	} `json:"server"`
+	Log struct {
+		Level     string `json:"level"`     // 日志级别: debug | info | warn | error
+		Format    string `json:"format"`    // 输出格式: text | json
+		SlowQuery int    `json:"slowQuery"` // SQL 慢查询阈值(毫秒)，0表示不记录
+		SlowRedis int    `json:"slowRedis"` // redis 慢命令阈值(毫秒)，0表示不记录
+	} `json:"log"`
 	Mysql struct {
-		DbSource string `json:"dbSource"`
-	}
+		DbSource        string `json:"dbSource" secret:"dsn"`
+		MaxIdleConns    int    `json:"maxIdleConns"`    // 空闲连接数
+		MaxOpenConns    int    `json:"maxOpenConns"`    // 最大打开连接数（不超过数据库 max_connections）
+		ConnMaxLifetime int    `json:"connMaxLifetime"` // 连接最大存活时间(秒)，应略小于数据库 wait_timeout
+	} `json:"mysql"`
 	Redis struct {
-		Address  string `json:"address"`
-		Password string `json:"password"`
-	}
+		Address     string `json:"address"`
+		Password    string `json:"password" secret:"true"`
+		Db          int    `json:"db"`
+		MaxIdle     int    `json:"maxIdle"`     // 最大空闲连接数
+		MaxActive   int    `json:"maxActive"`   // 最大活跃连接数，0表示不限
+		IdleTimeout int    `json:"idleTimeout"` // 空闲连接超时(秒)
+		DialTimeout int    `json:"dialTimeout"` // 建立连接超时(秒)
+	} `json:"redis"`
+	Jwt struct {
+		SigningKey string   `json:"signingKey"` // 签发使用的密钥 kid，其余密钥只用于校验（轮换期间保留旧密钥）
+		Keys       []JwtKey `json:"keys"`
+	} `json:"jwt"`
+	LoginGuard struct {
+		MaxFailures   int `json:"maxFailures"`   // 同一用户名失败次数上限，达到后锁定
+		IpMaxFailures int `json:"ipMaxFailures"` // 同一IP失败次数上限，达到后锁定
+		FailureWindow int `json:"failureWindow"` // 失败计数窗口(秒)
+		LockDuration  int `json:"lockDuration"`  // 锁定时长(秒)
+		BackoffBase   int `json:"backoffBase"`   // 退避基数(秒)，第N次失败后需等待 base*2^(N-1) 秒
+		BackoffMax    int `json:"backoffMax"`    // 退避上限(秒)
+	} `json:"loginGuard"`
+	Captcha struct {
+		TTL        int  `json:"ttl"`        // 验证码有效期(秒)
+		LoginAfter int  `json:"loginAfter"` // 同一IP登录失败达到该次数后需要验证码
+		Register   bool `json:"register"`   // 注册是否需要验证码
+		Tolerance  int  `json:"tolerance"`  // 允许的像素误差
+	} `json:"captcha"`
+	Register struct {
+		EmailVerify    bool   `json:"emailVerify"`    // 自助注册是否需要验证邮箱，开启后账号在验证前不能登录
+		VerifyTTL      int    `json:"verifyTTL"`      // 邮箱验证链接有效期(秒)
+		VerifyEmailUrl string `json:"verifyEmailUrl"` // 邮箱验证页面地址，%s 为验证令牌
+		InviteRequired bool   `json:"inviteRequired"` // 是否仅允许凭邀请码注册（含第三方登录自动注册）
+	} `json:"register"`
+	Audit struct {
+		RetentionDays int `json:"retentionDays"` // 审计日志保留天数，0表示永久保留
+	} `json:"audit"`
+	Mail struct {
+		Driver           string `json:"driver"` // 投递方式: smtp | outbox
+		Host             string `json:"host"`
+		Port             int    `json:"port"`
+		Username         string `json:"username"`
+		Password         string `json:"password" secret:"true"`
+		From             string `json:"from"`
+		OutboxDir        string `json:"outboxDir"`        // outbox 模式下邮件写入目录
+		ResetPasswordUrl string `json:"resetPasswordUrl"` // 重置密码页面地址，%s 为重置令牌
+	} `json:"mail"`
+	Oauth2 struct {
+		StateTTL  int             `json:"stateTTL"` // 授权流程(state)有效期(秒)
+		Providers []OauthProvider `json:"providers"`
+	} `json:"oauth2"`
+	Ai struct {
+		DefaultProvider string       `json:"defaultProvider"` // 默认使用的提供方名称
+		Timeout         int          `json:"timeout"`         // 单次调用超时(秒)
+		Providers       []AiProvider `json:"providers"`
+	} `json:"ai"`
+	Storage struct {
+		Driver    string `json:"driver"`    // 存储方式: local | s3
+		LocalDir  string `json:"localDir"`  // local 模式下文件写入目录
+		PublicUrl string `json:"publicUrl"` // 文件对外访问地址前缀
+		Endpoint  string `json:"endpoint"`  // s3 兼容服务地址（OSS/COS/MinIO 等）
+		Region    string `json:"region"`
+		Bucket    string `json:"bucket"`
+		AccessKey string `json:"accessKey" secret:"true"`
+		SecretKey string `json:"secretKey" secret:"true"`
+		MaxSize   int    `json:"maxSize"` // 单个文件大小上限(MB)
+	} `json:"storage"`
+	RateLimit struct {
+		Enabled  bool       `json:"enabled"`
+		Window   int        `json:"window"`   // 计数窗口(秒)
+		Global   int        `json:"global"`   // 全站窗口内请求上限，0表示不限
+		PerIp    int        `json:"perIp"`    // 单个IP窗口内请求上限，0表示不限
+		PerUser  int        `json:"perUser"`  // 单个用户窗口内请求上限，0表示不限
+		Login    RatePolicy `json:"login"`    // 登录（含两步验证），按IP计数
+		Register RatePolicy `json:"register"` // 注册，按IP计数
+		Ai       RatePolicy `json:"ai"`       // AI分析，按用户计数
+		Mail     RatePolicy `json:"mail"`     // 找回密码、重发验证邮件，按IP计数
+	} `json:"rateLimit"`
+	Metrics struct {
+		Enabled    bool     `json:"enabled"`
+		Path       string   `json:"path"`                // 指标地址
+		Token      string   `json:"token" secret:"true"` // 抓取时携带 Authorization: Bearer <token>
+		AllowCidrs []string `json:"allowCidrs"`          // 免令牌访问的来源网段
+	} `json:"metrics"`
 }
 
-func init() {
-	viper := viper.New()
-	viper.SetConfigName("config")
-	viper.SetConfigType("yaml")
+// RatePolicy 单独的路由限流策略，在全局限流之外额外生效，不受 rateLimit.enabled 影响
+type RatePolicy struct {
+	Window int `json:"window"` // 计数窗口(秒)
+	Limit  int `json:"limit"`  // 窗口内请求上限，0表示不限
+}
 
-	// 添加多个配置文件搜索路径
-	for _, path := range getConfigPaths() {
-		viper.AddConfigPath(path)
-	}
+// IsProd 是否生产环境
+func (c *AppConfig) IsProd() bool {
+	return c.Env == EnvProd
+}
 
-	if err := viper.ReadInConfig(); err != nil {
-		panic(fmt.Errorf("读取配置信息错误: %s", err))
-	}
-	if err := viper.Unmarshal(&Config); err != nil {
-		fmt.Println(err)
+// JwtKey JWT签名密钥配置
+// HS256 使用 secret；RS256/EdDSA 使用 PEM 私钥（内联或文件），只配置公钥时仅用于校验
+type JwtKey struct {
+	Kid            string `json:"kid"` // 密钥标识，写入 Token 头部
+	Alg            string `json:"alg"` // HS256 | RS256 | EdDSA
+	Secret         string `json:"secret" secret:"true"`
+	PrivateKey     string `json:"privateKey" secret:"true"` // PEM 私钥
+	PrivateKeyFile string `json:"privateKeyFile"`           // PEM 私钥文件路径
+	PublicKey      string `json:"publicKey"`                // PEM 公钥
+	PublicKeyFile  string `json:"publicKeyFile"`            // PEM 公钥文件路径
+}
+
+// OauthProvider 第三方登录(OAuth2/OIDC)身份提供方配置
+// 配置 issuer 时自动读取 /.well-known/openid-configuration 补全未填写的端点
+type OauthProvider struct {
+	Name         string   `json:"name"` // 提供方标识，用于路由 /oauth2/:provider
+	ClientId     string   `json:"clientId"`
+	ClientSecret string   `json:"clientSecret" secret:"true"`
+	Issuer       string   `json:"issuer"` // OIDC签发者，校验 id_token 的 iss
+	AuthUrl      string   `json:"authUrl"`
+	TokenUrl     string   `json:"tokenUrl"`
+	UserInfoUrl  string   `json:"userInfoUrl"`
+	JwksUrl      string   `json:"jwksUrl"`
+	RedirectUrl  string   `json:"redirectUrl"` // 回调地址，需与提供方登记的一致
+	Scopes       []string `json:"scopes"`
+	TrustEmail   bool     `json:"trustEmail"` // 提供方不返回 email_verified 时，是否视其邮箱为已验证
+}
+
+// AiProvider AI 模型提供方配置（OpenAI 兼容接口）
+type AiProvider struct {
+	Name    string   `json:"name"`    // 提供方标识
+	BaseUrl string   `json:"baseUrl"` // 接口地址，如 https://api.deepseek.com/v1
+	ApiKey  string   `json:"apiKey" secret:"true"`
+	Models  []string `json:"models"` // 可用模型，第一个为默认模型
+}
+
+func init() {
+	conf, err := Load(getConfigPaths()...)
+	if err != nil {
+		panic(fmt.Errorf("加载配置失败: %w", err))
 	}
+	Config = *conf
 }
 
-// 获取所有可能的配置文件路径
+// 获取所有可能的配置文件路径，设置 TM_CONFIG_DIR 时只使用该目录
 func getConfigPaths() []string {
+	if dir := os.Getenv("TM_CONFIG_DIR"); dir != "" {
+		return []string{dir}
+	}
+
 	var paths []string
 
 	// 1. 可执行文件所在目录（生产环境）
 	if execPath, err := os.Executable(); err == nil {
 		execDir := filepath.Dir(execPath)
-		paths = append(paths, execDir)
+		paths = append(paths, execDir, filepath.Join(execDir, "config"))
 	}
 
 	// 2. 当前工作目录
 	if workDir, err := os.Getwd(); err == nil {
-		paths = append(paths, workDir)
+		paths = append(paths, workDir, filepath.Join(workDir, "config"))
 	}
 
 	// 3. 源代码 config 目录（开发环境）
@@ -0,0 +1,128 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testBase = `env: dev
+port: 2500
+redis:
+  maxIdle: 10
+mail:
+  driver: "smtp"
+  port: 465
+`
+
+const testDev = `mysql:
+  dbSource: "root:dev-pass@tcp(127.0.0.1:3306)/tm?parseTime=True"
+redis:
+  address: "127.0.0.1:6379"
+jwt:
+  signingKey: "k1"
+  keys:
+    - kid: "k1"
+      alg: "HS256"
+      secret: "${TM_TEST_JWT_SECRET}"
+register:
+  emailVerify: true
+  verifyEmailUrl: "http://localhost/verify?token=%s"
+mail:
+  driver: "outbox"
+  resetPasswordUrl: "http://localhost/reset?token=%s"
+`
+
+func writeConfig(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+	for name, content := range files {
+		assert.Nil(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+	}
+	return dir
+}
+
+func TestLoad(t *testing.T) {
+	t.Setenv("TM_TEST_JWT_SECRET", "secret-from-env-0123456789abcdef")
+	t.Setenv("TM_REDIS_ADDRESS", "redis.internal:6380")
+	t.Setenv("TM_REDIS_PASSWORD", "redis-pass")
+	dir := writeConfig(t, map[string]string{"config.yaml": testBase, "config.dev.yaml": testDev})
+
+	conf, err := Load(t.TempDir(), dir)
+	assert.Nil(t, err)
+	assert.Equal(t, EnvDev, conf.Env)
+	assert.Equal(t, "outbox", conf.Mail.Driver)                                  // 环境配置覆盖公共配置
+	assert.Equal(t, 465, conf.Mail.Port)                                         // 未覆盖的保留公共配置
+	assert.Equal(t, 10, conf.Redis.MaxIdle)                                      // 配置文件优先于默认值
+	assert.Equal(t, 60, conf.Redis.MaxActive)                                    // 默认值
+	assert.Equal(t, "redis.internal:6380", conf.Redis.Address)                   // 环境变量覆盖配置文件
+	assert.Equal(t, "redis-pass", conf.Redis.Password)                           // 配置文件中没有的键也能通过环境变量设置
+	assert.Equal(t, "secret-from-env-0123456789abcdef", conf.Jwt.Keys[0].Secret) // 占位符替换
+}
+
+func TestLoad_EnvSelectsFile(t *testing.T) {
+	t.Setenv("TM_ENV", "prod")
+	dir := writeConfig(t, map[string]string{"config.yaml": testBase, "config.dev.yaml": testDev})
+
+	// 没有 config.prod.yaml，也没有通过环境变量提供连接信息
+	_, err := Load(dir)
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+	assert.Contains(t, err.Error(), "TM_MYSQL_DBSOURCE")
+	assert.Contains(t, err.Error(), "redis.address")
+	assert.Contains(t, err.Error(), "jwt.keys")
+
+	_, err = Load(t.TempDir())
+	assert.ErrorContains(t, err, "config.yaml")
+}
+
+func TestValidate(t *testing.T) {
+	t.Setenv("TM_TEST_JWT_SECRET", "your-secret-key-change-in-production")
+	dir := writeConfig(t, map[string]string{"config.yaml": testBase, "config.dev.yaml": testDev})
+	conf, err := Load(dir)
+	assert.Nil(t, err)
+
+	// 生产环境：示例密钥、outbox 均不允许，错误信息不包含密钥本身
+	conf.Env = EnvProd
+	err = conf.Validate()
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+	assert.Contains(t, err.Error(), "jwt.keys[0].secret")
+	assert.Contains(t, err.Error(), "mail.driver")
+	assert.NotContains(t, err.Error(), "your-secret-key")
+
+	conf.Env = EnvDev
+	conf.Port = "abc"
+	conf.Jwt.SigningKey = "k2"
+	conf.Storage.Driver = "ftp"
+	conf.RateLimit.Enabled = true
+	conf.RateLimit.Login = RatePolicy{Limit: 10}
+	conf.Metrics.Enabled = true
+	conf.Metrics.AllowCidrs = []string{"10.0.0.0/8", "10.0.0.1"}
+	conf.Server.TrustedProxies = []string{"10.0.0.1", "10.0.0.0/8", "lb.internal"}
+	err = conf.Validate()
+	for _, key := range []string{"port", "jwt.signingKey", "storage.driver", "rateLimit.login.window", "metrics.allowCidrs[1]", "server.trustedProxies[2]"} {
+		assert.Contains(t, err.Error(), key+":")
+	}
+	assert.NotContains(t, err.Error(), "server.trustedProxies[0]")
+}
+
+func TestRedacted(t *testing.T) {
+	conf := &AppConfig{Env: EnvProd}
+	conf.Mysql.DbSource = "root:dev-pass@tcp(127.0.0.1:3306)/tm"
+	conf.Redis.Address = "127.0.0.1:6379"
+	conf.Redis.Password = "redis-pass"
+	conf.Jwt.Keys = []JwtKey{{Kid: "k1", Alg: "HS256", Secret: "jwt-secret"}}
+	conf.Ai.Providers = []AiProvider{{Name: "deepseek", ApiKey: "sk-123"}}
+
+	out := conf.Redacted()
+	for _, secret := range []string{"dev-pass", "redis-pass", "jwt-secret", "sk-123"} {
+		assert.False(t, strings.Contains(out, secret), secret)
+	}
+	assert.Contains(t, out, "127.0.0.1:6379")
+	assert.Contains(t, out, "deepseek")
+	assert.Contains(t, out, `root:******@tcp(127.0.0.1:3306)/tm`)
+
+	// 原配置不受影响
+	assert.Equal(t, "jwt-secret", conf.Jwt.Keys[0].Secret)
+}
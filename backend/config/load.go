@@ -0,0 +1,148 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+const (
+	EnvDev  = "dev"
+	EnvTest = "test"
+	EnvProd = "prod"
+
+	envPrefix = "TM" // 环境变量前缀，如 TM_MYSQL_DBSOURCE 覆盖 mysql.dbSource
+)
+
+// placeholder 配置文件中的环境变量占位符，如 ${TM_JWT_SECRET}
+// 用于列表项（JWT 密钥、第三方登录、AI 提供方）中的敏感字段，这类字段无法通过 TM_* 按键名覆盖
+var placeholder = regexp.MustCompile(`\$\{(` + envPrefix + `_[A-Z0-9_]+)\}`)
+
+// defaults 默认值
+var defaults = map[string]any{
+	"env":                      EnvDev,
+	"host":                     "0.0.0.0",
+	"port":                     "2500",
+	"server.readHeaderTimeout": 10,
+	"server.idleTimeout":       120,
+	"server.shutdownTimeout":   30,
+	"server.probeTimeout":      2,
+	"log.level":                "info",
+	"log.format":               "text",
+	"log.slowQuery":            200,
+	"log.slowRedis":            50,
+	"mysql.maxIdleConns":       50,
+	"mysql.maxOpenConns":       100,
+	"mysql.connMaxLifetime":    28700,
+	"redis.db":                 0,
+	"redis.maxIdle":            60,
+	"redis.maxActive":          60,
+	"redis.idleTimeout":        60,
+	"redis.dialTimeout":        5,
+	"mail.driver":              "outbox",
+	"ai.timeout":               60,
+	"storage.driver":           "local",
+	"storage.localDir":         "runtime/upload",
+	"storage.maxSize":          10,
+	"rateLimit.window":         60,
+	"metrics.path":             "/metrics",
+}
+
+// Load 按顺序加载配置并校验：
+//  1. config.yaml 公共配置（必须存在）
+//  2. config.{env}.yaml 环境配置（可选），env 取 TM_ENV，未设置时取 config.yaml 中的 env
+//  3. TM_* 环境变量覆盖，键名中的层级分隔符替换为下划线，如 TM_REDIS_ADDRESS
+func Load(paths ...string) (*AppConfig, error) {
+	v := viper.New()
+	v.SetConfigType("yaml")
+	v.SetEnvPrefix(envPrefix)
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	v.AutomaticEnv()
+	bindKeys(v, reflect.TypeOf(AppConfig{}), "")
+	for key, value := range defaults {
+		v.SetDefault(key, value)
+	}
+
+	base, err := findConfigFile(paths, "config.yaml")
+	if err != nil {
+		return nil, err
+	}
+	if err = readConfigFile(v, base, false); err != nil {
+		return nil, err
+	}
+
+	env := strings.ToLower(v.GetString("env"))
+	if file := filepath.Join(filepath.Dir(base), "config."+env+".yaml"); fileExists(file) {
+		if err = readConfigFile(v, file, true); err != nil {
+			return nil, err
+		}
+	}
+
+	conf := &AppConfig{}
+	if err = v.Unmarshal(conf); err != nil {
+		return nil, fmt.Errorf("解析配置失败: %w", err)
+	}
+	conf.Env = env
+	if err = conf.Validate(); err != nil {
+		return nil, err
+	}
+	return conf, nil
+}
+
+// findConfigFile 在搜索路径中查找配置文件，返回第一个存在的文件
+func findConfigFile(paths []string, name string) (string, error) {
+	for _, dir := range paths {
+		if file := filepath.Join(dir, name); fileExists(file) {
+			return file, nil
+		}
+	}
+	return "", fmt.Errorf("未找到配置文件 %s，搜索路径: %s", name, strings.Join(paths, ", "))
+}
+
+// readConfigFile 读取配置文件并替换环境变量占位符，merge 为 true 时合并到已有配置
+func readConfigFile(v *viper.Viper, file string, merge bool) error {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("读取配置文件 %s 失败: %w", file, err)
+	}
+	data = placeholder.ReplaceAllFunc(data, func(match []byte) []byte {
+		return []byte(os.Getenv(string(placeholder.FindSubmatch(match)[1])))
+	})
+
+	if merge {
+		err = v.MergeConfig(bytes.NewReader(data))
+	} else {
+		err = v.ReadConfig(bytes.NewReader(data))
+	}
+	if err != nil {
+		return fmt.Errorf("解析配置文件 %s 失败: %w", file, err)
+	}
+	return nil
+}
+
+// bindKeys 登记配置结构中的全部键，配置文件中未出现的键也能通过环境变量设置
+// 列表（JWT 密钥、第三方登录、AI 提供方等）不支持按键名覆盖，使用占位符
+func bindKeys(v *viper.Viper, t reflect.Type, prefix string) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		key := prefix + strings.Split(field.Tag.Get("json"), ",")[0]
+		switch field.Type.Kind() {
+		case reflect.Struct:
+			bindKeys(v, field.Type, key+".")
+		case reflect.Slice:
+		default:
+			_ = v.BindEnv(key)
+		}
+	}
+}
+
+func fileExists(file string) bool {
+	info, err := os.Stat(file)
+	return err == nil && !info.IsDir()
+}
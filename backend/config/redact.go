@@ -0,0 +1,67 @@
+package config
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+const redactedMask = "******"
+
+// Redacted 脱敏后的配置（JSON），用于启动日志
+// 标记 secret:"true" 的字段整体替换为掩码，secret:"dsn" 的字段只隐藏其中的密码
+func (c *AppConfig) Redacted() string {
+	// 先深拷贝，避免改动正在使用的配置
+	data, err := json.Marshal(c)
+	if err != nil {
+		return "{}"
+	}
+	clone := &AppConfig{}
+	if err = json.Unmarshal(data, clone); err != nil {
+		return "{}"
+	}
+
+	redact(reflect.ValueOf(clone).Elem())
+	data, _ = json.Marshal(clone)
+	return string(data)
+}
+
+// redact 递归脱敏结构体中的敏感字段
+func redact(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Field(i)
+			switch v.Type().Field(i).Tag.Get("secret") {
+			case "true":
+				if field.Kind() == reflect.String && field.String() != "" {
+					field.SetString(redactedMask)
+				}
+			case "dsn":
+				field.SetString(redactDSN(field.String()))
+			default:
+				redact(field)
+			}
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			redact(v.Index(i))
+		}
+	}
+}
+
+// redactDSN 隐藏数据库连接串中的密码，无法解析时整体隐藏
+func redactDSN(dsn string) string {
+	if dsn == "" {
+		return ""
+	}
+	conf, err := mysql.ParseDSN(dsn)
+	if err != nil {
+		return redactedMask
+	}
+	if conf.Passwd != "" {
+		conf.Passwd = redactedMask
+	}
+	return conf.FormatDSN()
+}
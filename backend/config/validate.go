@@ -0,0 +1,201 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// ErrInvalidConfig 配置校验失败
+var ErrInvalidConfig = errors.New("配置校验失败")
+
+// sampleSecrets 示例配置中的密钥，生产环境禁止使用
+var sampleSecrets = []string{"your-secret-key-change-in-production", "mock-secret"}
+
+// validator 收集全部校验错误，一次性报告
+type validator struct {
+	errs []string
+}
+
+func (v *validator) check(ok bool, key, format string, args ...any) {
+	if !ok {
+		v.errs = append(v.errs, key+": "+fmt.Sprintf(format, args...))
+	}
+}
+
+func (v *validator) required(key, value string) {
+	v.check(strings.TrimSpace(value) != "", key, "不能为空（可通过环境变量 %s 设置）", envName(key))
+}
+
+func (v *validator) nonNegative(key string, value int) {
+	v.check(value >= 0, key, "不能小于0")
+}
+
+// Validate 校验配置，返回的错误列出全部不合法的配置项（不包含配置值本身，避免泄露敏感信息）
+func (c *AppConfig) Validate() error {
+	v := &validator{}
+
+	v.check(slices.Contains([]string{EnvDev, EnvTest, EnvProd}, c.Env), "env", "只能为 dev、test、prod，当前为 %q", c.Env)
+	port, err := strconv.Atoi(c.Port)
+	v.check(err == nil && port > 0 && port < 65536, "port", "必须为 1-65535 的端口号")
+	v.nonNegative("server.readHeaderTimeout", c.Server.ReadHeaderTimeout)
+	v.nonNegative("server.idleTimeout", c.Server.IdleTimeout)
+	v.nonNegative("server.shutdownDelay", c.Server.ShutdownDelay)
+	v.check(c.Server.ShutdownTimeout > 0, "server.shutdownTimeout", "必须大于0")
+	v.check(c.Server.ProbeTimeout > 0, "server.probeTimeout", "必须大于0")
+	for i, proxy := range c.Server.TrustedProxies {
+		_, _, cidrErr := net.ParseCIDR(proxy)
+		v.check(cidrErr == nil || net.ParseIP(proxy) != nil, fmt.Sprintf("server.trustedProxies[%d]", i), "必须是IP或网段")
+	}
+	v.check(slices.Contains([]string{"debug", "info", "warn", "error"}, c.Log.Level), "log.level", "只能为 debug、info、warn、error")
+	v.check(slices.Contains([]string{"text", "json"}, c.Log.Format), "log.format", "只能为 text 或 json")
+	v.nonNegative("log.slowQuery", c.Log.SlowQuery)
+	v.nonNegative("log.slowRedis", c.Log.SlowRedis)
+
+	// 数据库
+	v.required("mysql.dbSource", c.Mysql.DbSource)
+	if c.Mysql.DbSource != "" {
+		_, err = mysql.ParseDSN(c.Mysql.DbSource)
+		v.check(err == nil, "mysql.dbSource", "DSN 格式错误")
+	}
+	v.nonNegative("mysql.maxIdleConns", c.Mysql.MaxIdleConns)
+	v.nonNegative("mysql.maxOpenConns", c.Mysql.MaxOpenConns)
+	v.check(c.Mysql.MaxOpenConns == 0 || c.Mysql.MaxIdleConns <= c.Mysql.MaxOpenConns, "mysql.maxIdleConns", "不能大于 maxOpenConns")
+	v.nonNegative("mysql.connMaxLifetime", c.Mysql.ConnMaxLifetime)
+
+	// redis
+	v.required("redis.address", c.Redis.Address)
+	if c.Redis.Address != "" {
+		_, _, err = net.SplitHostPort(c.Redis.Address)
+		v.check(err == nil, "redis.address", "格式应为 host:port")
+	}
+	v.check(c.Redis.Db >= 0 && c.Redis.Db < 16, "redis.db", "必须为 0-15")
+	v.nonNegative("redis.maxIdle", c.Redis.MaxIdle)
+	v.nonNegative("redis.maxActive", c.Redis.MaxActive)
+	v.nonNegative("redis.idleTimeout", c.Redis.IdleTimeout)
+	v.nonNegative("redis.dialTimeout", c.Redis.DialTimeout)
+
+	// JWT：密钥格式由 jwtkey 在启动时解析校验，这里只检查必填项与生产环境的示例密钥
+	v.required("jwt.signingKey", c.Jwt.SigningKey)
+	v.check(len(c.Jwt.Keys) > 0, "jwt.keys", "至少配置一个密钥")
+	kids := make([]string, 0, len(c.Jwt.Keys))
+	for i, key := range c.Jwt.Keys {
+		name := fmt.Sprintf("jwt.keys[%d]", i)
+		v.check(key.Kid != "", name+".kid", "不能为空")
+		v.check(!slices.Contains(kids, key.Kid), name+".kid", "重复的 kid %q", key.Kid)
+		kids = append(kids, key.Kid)
+		if key.Alg == "HS256" {
+			v.check(key.Secret != "", name+".secret", "HS256 密钥不能为空（可在配置中使用 ${TM_JWT_SECRET} 占位符）")
+			v.check(!c.IsProd() || !slices.Contains(sampleSecrets, key.Secret), name+".secret", "生产环境不能使用示例密钥")
+		}
+	}
+	if c.Jwt.SigningKey != "" && len(kids) > 0 {
+		v.check(slices.Contains(kids, c.Jwt.SigningKey), "jwt.signingKey", "未在 jwt.keys 中找到 kid %q", c.Jwt.SigningKey)
+	}
+
+	// 注册与邮件
+	if c.Register.EmailVerify {
+		v.check(isHttpUrl(c.Register.VerifyEmailUrl) && strings.Contains(c.Register.VerifyEmailUrl, "%s"),
+			"register.verifyEmailUrl", "必须为 http(s) 地址且包含令牌占位符 %%s")
+	}
+	v.check(isHttpUrl(c.Mail.ResetPasswordUrl) && strings.Contains(c.Mail.ResetPasswordUrl, "%s"),
+		"mail.resetPasswordUrl", "必须为 http(s) 地址且包含令牌占位符 %%s")
+	switch c.Mail.Driver {
+	case "smtp":
+		v.required("mail.host", c.Mail.Host)
+		v.required("mail.from", c.Mail.From)
+		v.check(c.Mail.Port > 0 && c.Mail.Port < 65536, "mail.port", "必须为 1-65535 的端口号")
+	case "outbox":
+		v.check(!c.IsProd(), "mail.driver", "生产环境不能使用 outbox，请配置 smtp")
+	default:
+		v.check(false, "mail.driver", "只能为 smtp 或 outbox")
+	}
+
+	// 第三方登录
+	names := make([]string, 0, len(c.Oauth2.Providers))
+	for i, p := range c.Oauth2.Providers {
+		name := fmt.Sprintf("oauth2.providers[%d]", i)
+		v.check(p.Name != "", name+".name", "不能为空")
+		v.check(!slices.Contains(names, p.Name), name+".name", "重复的提供方 %q", p.Name)
+		names = append(names, p.Name)
+		v.check(p.ClientId != "", name+".clientId", "不能为空")
+		v.check(p.Issuer != "" || (p.AuthUrl != "" && p.TokenUrl != ""), name, "需配置 issuer，或同时配置 authUrl 与 tokenUrl")
+		v.check(!c.IsProd() || !slices.Contains(sampleSecrets, p.ClientSecret), name+".clientSecret", "生产环境不能使用示例密钥")
+	}
+
+	// AI 提供方
+	v.nonNegative("ai.timeout", c.Ai.Timeout)
+	names = names[:0]
+	for i, p := range c.Ai.Providers {
+		name := fmt.Sprintf("ai.providers[%d]", i)
+		v.check(p.Name != "", name+".name", "不能为空")
+		v.check(!slices.Contains(names, p.Name), name+".name", "重复的提供方 %q", p.Name)
+		names = append(names, p.Name)
+		v.check(isHttpUrl(p.BaseUrl), name+".baseUrl", "必须为 http(s) 地址")
+		v.check(p.ApiKey != "", name+".apiKey", "不能为空（可在配置中使用 ${TM_AI_XXX_APIKEY} 占位符）")
+	}
+	if c.Ai.DefaultProvider != "" {
+		v.check(slices.Contains(names, c.Ai.DefaultProvider), "ai.defaultProvider", "未在 ai.providers 中找到 %q", c.Ai.DefaultProvider)
+	}
+
+	// 文件存储
+	switch c.Storage.Driver {
+	case "local":
+		v.required("storage.localDir", c.Storage.LocalDir)
+	case "s3":
+		v.required("storage.endpoint", c.Storage.Endpoint)
+		v.required("storage.bucket", c.Storage.Bucket)
+		v.required("storage.accessKey", c.Storage.AccessKey)
+		v.required("storage.secretKey", c.Storage.SecretKey)
+	default:
+		v.check(false, "storage.driver", "只能为 local 或 s3")
+	}
+	v.nonNegative("storage.maxSize", c.Storage.MaxSize)
+
+	// 限流
+	if c.RateLimit.Enabled {
+		v.check(c.RateLimit.Window > 0, "rateLimit.window", "必须大于0")
+	}
+	v.nonNegative("rateLimit.global", c.RateLimit.Global)
+	v.nonNegative("rateLimit.perIp", c.RateLimit.PerIp)
+	v.nonNegative("rateLimit.perUser", c.RateLimit.PerUser)
+	policies := []struct {
+		name   string
+		policy RatePolicy
+	}{{"login", c.RateLimit.Login}, {"register", c.RateLimit.Register}, {"ai", c.RateLimit.Ai}, {"mail", c.RateLimit.Mail}}
+	for _, p := range policies {
+		v.nonNegative("rateLimit."+p.name+".limit", p.policy.Limit)
+		if p.policy.Limit > 0 {
+			v.check(p.policy.Window > 0, "rateLimit."+p.name+".window", "必须大于0")
+		}
+	}
+
+	// 指标
+	if c.Metrics.Enabled {
+		v.check(strings.HasPrefix(c.Metrics.Path, "/"), "metrics.path", "必须以 / 开头")
+		v.check(c.Metrics.Token != "" || len(c.Metrics.AllowCidrs) > 0, "metrics.token", "开启指标时需设置令牌（%s）或 metrics.allowCidrs，避免指标公开", envName("metrics.token"))
+		for i, cidr := range c.Metrics.AllowCidrs {
+			_, _, err := net.ParseCIDR(cidr)
+			v.check(err == nil, fmt.Sprintf("metrics.allowCidrs[%d]", i), "网段格式错误")
+		}
+	}
+
+	if len(v.errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w（env=%s）:\n  - %s", ErrInvalidConfig, c.Env, strings.Join(v.errs, "\n  - "))
+}
+
+// envName 配置键对应的环境变量名，如 mysql.dbSource → TM_MYSQL_DBSOURCE
+func envName(key string) string {
+	return envPrefix + "_" + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+}
+
+func isHttpUrl(value string) bool {
+	return strings.HasPrefix(value, "http://") || strings.HasPrefix(value, "https://")
+}
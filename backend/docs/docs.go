@@ -67,13 +67,13 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "参数错误",
+                        "description": "参数错误或用户名密码错误",
                         "schema": {
                             "$ref": "#/definitions/api.Response"
                         }
                     },
-                    "401": {
-                        "description": "用户名或密码错误",
+                    "429": {
+                        "description": "登录失败次数过多，已临时锁定",
                         "schema": {
                             "$ref": "#/definitions/api.Response"
                         }
@@ -0,0 +1,64 @@
+package accessToken
+
+import (
+	"thinkingModels/component/errs"
+	"time"
+)
+
+// AccessTokenAbility 个人访问令牌能力接口定义
+type AccessTokenAbility interface {
+	// LoadByToken 按令牌原文查找（比对摘要）
+	LoadByToken(token string) (*AccessTokenEntity, error)
+	// Revoke 吊销令牌
+	Revoke() error
+	// RevokeByUser 吊销用户的全部令牌
+	RevokeByUser(userId uint64) error
+	// Touch 记录使用时间与IP（一分钟内只写一次）
+	Touch(ip string) error
+}
+
+var ErrTokenInvalid = errs.Unauthorized("访问令牌无效或已过期")
+
+// touchInterval 使用记录的最小写入间隔
+const touchInterval = time.Minute
+
+// LoadByToken 按令牌原文查找（比对摘要）
+func (m *AccessTokenEntity) LoadByToken(token string) (*AccessTokenEntity, error) {
+	if !IsPersonalToken(token) {
+		return nil, ErrTokenInvalid
+	}
+	cond := m.MakeConditon(SearchAccessToken{TokenHash: HashToken(token)})
+	entity, err := m.LoadData(cond)
+	if err != nil || entity == nil || entity.Id == 0 {
+		return nil, ErrTokenInvalid
+	}
+	return entity, nil
+}
+
+// Revoke 吊销令牌
+func (m *AccessTokenEntity) Revoke() error {
+	if m.Status == 0 {
+		return nil
+	}
+	m.Status = 0
+	_, err := m.Update()
+	return err
+}
+
+// RevokeByUser 吊销用户的全部令牌
+func (m *AccessTokenEntity) RevokeByUser(userId uint64) error {
+	return m.Tx().Table(m.TableName()).
+		Where("user_id = ? AND status = 1", userId).
+		UpdateColumn("status", 0).Error
+}
+
+// Touch 记录使用时间与IP（一分钟内只写一次，避免每个请求都写库）
+func (m *AccessTokenEntity) Touch(ip string) error {
+	now := time.Now()
+	if now.Sub(time.Time(m.LastUsedAt)) < touchInterval && m.LastUsedIp == ip {
+		return nil
+	}
+	return m.Tx().Table(m.TableName()).
+		Where("id = ?", m.Id).
+		UpdateColumns(map[string]any{"last_used_at": now, "last_used_ip": ip}).Error
+}
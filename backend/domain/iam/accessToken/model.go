@@ -0,0 +1,114 @@
+package accessToken
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jianyuezhexue/base"
+	"thinkingModels/component/db"
+	"thinkingModels/component/errs"
+)
+
+// AccessTokenEntityInterface 个人访问令牌实体接口
+type AccessTokenEntityInterface interface {
+	base.BaseModelInterface[AccessTokenEntity]
+	AccessTokenAbility
+}
+
+// AccessTokenEntity 个人访问令牌实体（只保存令牌摘要，原文仅在创建时展示一次）
+type AccessTokenEntity struct {
+	base.BaseModel[AccessTokenEntity]
+	UserId      uint64       `json:"userId" type:"db" comment:"所属用户ID"`
+	Name        string       `json:"name" type:"db" comment:"令牌名称"`
+	TokenPrefix string       `json:"tokenPrefix" type:"db" comment:"令牌前缀，用于识别"`
+	TokenHash   string       `json:"-" type:"db" comment:"令牌SHA256摘要"`
+	Scopes      string       `json:"scopes" type:"db" comment:"权限范围，逗号分隔"`
+	ExpiresAt   db.LocalTime `json:"expiresAt" type:"db" comment:"过期时间，为空表示永不过期"`
+	LastUsedAt  db.LocalTime `json:"lastUsedAt" type:"db" comment:"最后使用时间"`
+	LastUsedIp  string       `json:"lastUsedIp" type:"db" comment:"最后使用IP"`
+	Status      int          `json:"status" type:"db" comment:"状态:0=已吊销,1=正常"`
+}
+
+// NewAccessTokenEntity 实例化个人访问令牌实体
+func NewAccessTokenEntity(ctx *gin.Context, opt ...base.Option[AccessTokenEntity]) AccessTokenEntityInterface {
+	entity := &AccessTokenEntity{}
+	entity.BaseModel = base.NewBaseModel(ctx, db.InitDb(), entity.TableName(), entity)
+
+	// 自定义配置选项
+	if len(opt) > 0 {
+		for _, fc := range opt {
+			fc(&entity.BaseModel)
+		}
+	}
+	return entity
+}
+
+// TableName 数据表名
+func (m *AccessTokenEntity) TableName() string {
+	return "personal_access_tokens"
+}
+
+// Validate 数据校验
+func (m *AccessTokenEntity) Validate() error {
+	if m.UserId == 0 {
+		return errs.Validation("用户ID不能为空")
+	}
+	if m.Name == "" {
+		return errs.Validation("令牌名称不能为空")
+	}
+	if len([]rune(m.Name)) > 50 {
+		return errs.Validation("令牌名称不能超过50字符")
+	}
+	if m.TokenHash == "" {
+		return errs.Validation("令牌摘要不能为空")
+	}
+	if m.Scopes == "" {
+		return errs.Validation("权限范围不能为空")
+	}
+	return nil
+}
+
+// Repair 数据修复
+func (m *AccessTokenEntity) Repair() error {
+	return nil
+}
+
+// Complete 数据完善
+func (m *AccessTokenEntity) Complete() error {
+	return nil
+}
+
+// ========== 业务方法 ==========
+
+// ScopeList 权限范围列表
+func (m *AccessTokenEntity) ScopeList() []string {
+	if m.Scopes == "" {
+		return []string{}
+	}
+	return strings.Split(m.Scopes, ",")
+}
+
+// HasScope 是否拥有权限范围，write 范围包含同资源的 read
+func (m *AccessTokenEntity) HasScope(scope string) bool {
+	scopes := m.ScopeList()
+	if slices.Contains(scopes, scope) {
+		return true
+	}
+	if resource, ok := strings.CutSuffix(scope, ":"+ScopeRead); ok {
+		return slices.Contains(scopes, resource+":"+ScopeWrite)
+	}
+	return false
+}
+
+// IsExpired 是否已过期
+func (m *AccessTokenEntity) IsExpired() bool {
+	expiresAt := time.Time(m.ExpiresAt)
+	return !expiresAt.IsZero() && time.Now().After(expiresAt)
+}
+
+// IsActive 是否可用（未吊销且未过期）
+func (m *AccessTokenEntity) IsActive() bool {
+	return m.Status == 1 && !m.IsExpired()
+}
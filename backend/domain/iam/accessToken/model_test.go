@@ -0,0 +1,71 @@
+package accessToken
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"thinkingModels/component/db"
+)
+
+// TestGenerateToken 令牌只保存摘要，前缀可识别
+func TestGenerateToken(t *testing.T) {
+	token, hash, prefix, err := GenerateToken()
+	assert.Nil(t, err)
+	assert.True(t, IsPersonalToken(token))
+	assert.Equal(t, HashToken(token), hash)
+	assert.NotContains(t, hash, token)
+	assert.Equal(t, token[:len(prefix)], prefix)
+	assert.False(t, IsPersonalToken("eyJhbGciOiJIUzI1NiJ9.x.y"))
+}
+
+// TestRequiredScope 接口所需权限范围，未列出的接口不允许使用
+func TestRequiredScope(t *testing.T) {
+	cases := []struct {
+		method, path, scope string
+		ok                  bool
+	}{
+		{"GET", "/thinking/model/list", "model:read", true},
+		{"POST", "/thinking/model", "model:write", true},
+		{"GET", "/thinking/action/my", "action:read", true},
+		{"POST", "/thinking/followup", "action:write", true},
+		{"GET", "/user/info", "profile:read", true},
+		{"GET", "/master/category/all", "model:read", true},
+		{"POST", "/master/category", "", false},
+		{"GET", "/user/tokens", "", false},
+		{"POST", "/user/password", "", false},
+		{"POST", "/iam/role", "", false},
+		{"GET", "/thinking/modelx", "", false},
+	}
+	for _, c := range cases {
+		scope, ok := RequiredScope(c.method, c.path)
+		assert.Equal(t, c.ok, ok, c.path)
+		assert.Equal(t, c.scope, scope, c.path)
+	}
+}
+
+// TestAccessTokenEntity_HasScope write 包含 read
+func TestAccessTokenEntity_HasScope(t *testing.T) {
+	token := &AccessTokenEntity{Scopes: "action:read,model:write", Status: 1}
+	assert.True(t, token.HasScope("model:write"))
+	assert.True(t, token.HasScope("model:read"))
+	assert.True(t, token.HasScope("action:read"))
+	assert.False(t, token.HasScope("action:write"))
+	assert.False(t, token.HasScope("topic:read"))
+
+	assert.True(t, token.IsActive())
+	token.ExpiresAt = db.LocalTime(time.Now().Add(-time.Minute))
+	assert.False(t, token.IsActive())
+}
+
+// TestNormalizeScopes 校验并去重
+func TestNormalizeScopes(t *testing.T) {
+	scopes, err := NormalizeScopes([]string{"model:write", "action:read", "model:write"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"action:read", "model:write"}, scopes)
+
+	_, err = NormalizeScopes([]string{"admin:write"})
+	assert.NotNil(t, err)
+	_, err = NormalizeScopes(nil)
+	assert.NotNil(t, err)
+}
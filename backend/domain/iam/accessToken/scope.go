@@ -0,0 +1,86 @@
+package accessToken
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+	"thinkingModels/component/errs"
+)
+
+// ========== 权限范围 ==========
+// 范围格式: <资源>:read | <资源>:write，write 包含 read。
+// 个人访问令牌只能访问 scopeRoutes 中列出的接口，其余接口（账号、令牌管理、角色权限等）一律拒绝。
+
+const (
+	ScopeRead  = "read"
+	ScopeWrite = "write"
+)
+
+// Scopes 可授予的权限范围
+var Scopes = []ScopeInfo{
+	{Scope: "profile:read", Name: "读取当前用户信息"},
+	{Scope: "model:read", Name: "读取思维模型、分类与标签"},
+	{Scope: "model:write", Name: "创建、修改、删除思维模型"},
+	{Scope: "topic:read", Name: "读取课题与分析记录"},
+	{Scope: "topic:write", Name: "创建、修改、删除课题与分析记录"},
+	{Scope: "action:read", Name: "读取行动项与跟进记录"},
+	{Scope: "action:write", Name: "创建、修改、删除行动项与跟进记录"},
+}
+
+// scopeRoutes 接口路径前缀与资源的对应关系（按前缀匹配，越具体越靠前）
+var scopeRoutes = []struct {
+	Prefix   string
+	Resource string
+	ReadOnly bool // 只允许读
+}{
+	{"/user/info", "profile", true},
+	{"/thinking/model", "model", false},
+	{"/thinking/category", "model", false},
+	{"/thinking/tag", "model", false},
+	{"/master/category", "model", true},
+	{"/thinking/topic", "topic", false},
+	{"/thinking/analysis", "topic", false},
+	{"/subject/topic", "topic", false},
+	{"/subject/analysis", "topic", false},
+	{"/thinking/action", "action", false},
+	{"/thinking/followup", "action", false},
+}
+
+// RequiredScope 计算访问接口所需的权限范围，ok=false 表示该接口不允许使用个人访问令牌
+// path 为路由模板（gin FullPath），GET/HEAD 为读操作，其余为写操作
+func RequiredScope(method, path string) (scope string, ok bool) {
+	for _, route := range scopeRoutes {
+		if path != route.Prefix && !strings.HasPrefix(path, route.Prefix+"/") {
+			continue
+		}
+		access := ScopeWrite
+		if method == http.MethodGet || method == http.MethodHead {
+			access = ScopeRead
+		}
+		if route.ReadOnly && access != ScopeRead {
+			return "", false
+		}
+		return route.Resource + ":" + access, true
+	}
+	return "", false
+}
+
+// NormalizeScopes 校验并去重权限范围
+func NormalizeScopes(scopes []string) ([]string, error) {
+	result := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		scope = strings.TrimSpace(scope)
+		valid := slices.ContainsFunc(Scopes, func(s ScopeInfo) bool { return s.Scope == scope })
+		if !valid {
+			return nil, errs.Validation("无效的权限范围: " + scope)
+		}
+		if !slices.Contains(result, scope) {
+			result = append(result, scope)
+		}
+	}
+	if len(result) == 0 {
+		return nil, errs.Validation("权限范围不能为空")
+	}
+	slices.Sort(result)
+	return result, nil
+}
@@ -0,0 +1,38 @@
+package accessToken
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+)
+
+// ========== 令牌生成 ==========
+// 令牌格式: tmpat_<43位base64url随机串>，固定前缀便于鉴权中间件区分JWT、便于密钥扫描工具识别
+
+const (
+	TokenPrefix       = "tmpat_"
+	displayPrefixSize = len(TokenPrefix) + 6 // 列表中展示的令牌前缀长度
+)
+
+// GenerateToken 生成令牌，返回原文、摘要与展示前缀
+func GenerateToken() (token, hash, prefix string, err error) {
+	b := make([]byte, 32)
+	if _, err = rand.Read(b); err != nil {
+		return "", "", "", err
+	}
+	token = TokenPrefix + base64.RawURLEncoding.EncodeToString(b)
+	return token, HashToken(token), token[:displayPrefixSize], nil
+}
+
+// HashToken 令牌摘要（SHA256，令牌本身为高熵随机串，无需加盐慢哈希）
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// IsPersonalToken 是否为个人访问令牌格式
+func IsPersonalToken(token string) bool {
+	return strings.HasPrefix(token, TokenPrefix)
+}
@@ -0,0 +1,52 @@
+package accessToken
+
+// ==================== 请求DTO ====================
+
+// CreateAccessToken 创建个人访问令牌请求
+type CreateAccessToken struct {
+	Name          string   `json:"name" binding:"required,max=50"`         // 令牌名称
+	Scopes        []string `json:"scopes" binding:"required,min=1"`        // 权限范围
+	ExpiresInDays int      `json:"expiresInDays" binding:"min=0,max=3650"` // 有效天数，0表示永不过期
+}
+
+// RevokeAccessToken 吊销个人访问令牌请求
+type RevokeAccessToken struct {
+	Ids []uint64 `json:"ids" binding:"required,min=1"`
+}
+
+// SearchAccessToken 个人访问令牌搜索条件
+type SearchAccessToken struct {
+	Page      int64  `json:"page" form:"page" search:"page"`                                                   // 分页
+	PageSize  int64  `json:"pageSize" form:"pageSize" search:"pageSize"`                                       // 分页大小
+	UserId    uint64 `json:"-" form:"-" search:"type:eq;column:user_id;table:personal_access_tokens"`          // 所属用户
+	Status    *int   `json:"status" form:"status" search:"type:eq;column:status;table:personal_access_tokens"` // 状态
+	TokenHash string `json:"-" form:"-" search:"type:eq;column:token_hash;table:personal_access_tokens"`       // 令牌摘要
+}
+
+// ==================== 响应DTO ====================
+
+// ScopeInfo 权限范围说明
+type ScopeInfo struct {
+	Scope string `json:"scope"`
+	Name  string `json:"name"`
+}
+
+// AccessTokenInfo 个人访问令牌信息（不含令牌原文）
+type AccessTokenInfo struct {
+	Id          uint64   `json:"id"`
+	Name        string   `json:"name"`
+	TokenPrefix string   `json:"tokenPrefix"` // 令牌前缀，用于识别
+	Scopes      []string `json:"scopes"`
+	ExpiresAt   string   `json:"expiresAt"` // 为空表示永不过期
+	LastUsedAt  string   `json:"lastUsedAt"`
+	LastUsedIp  string   `json:"lastUsedIp"`
+	Status      int      `json:"status"`
+	Expired     bool     `json:"expired"`
+	CreatedAt   string   `json:"createdAt"`
+}
+
+// CreateAccessTokenResponse 创建个人访问令牌响应
+type CreateAccessTokenResponse struct {
+	AccessTokenInfo
+	Token string `json:"token"` // 令牌原文，仅在创建时返回一次
+}
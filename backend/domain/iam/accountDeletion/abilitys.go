@@ -0,0 +1,131 @@
+package accountDeletion
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"thinkingModels/component/db"
+)
+
+// AnonymousName 注销后用户与公开模型作者的显示名称
+const AnonymousName = "已注销用户"
+
+// AccountDeletionAbility 注销账号任务能力接口定义
+type AccountDeletionAbility interface {
+	// LoadByJobId 按任务ID查找任务
+	LoadByJobId(jobId string) (*AccountDeletionEntity, error)
+	// ListRetryable 待执行的任务（排队中、可重试的失败任务、执行中断的任务），按申请时间先后
+	ListRetryable(limit int) ([]*AccountDeletionEntity, error)
+	// Start 标记任务开始执行
+	Start() error
+	// Finish 标记任务完成并记录各表处理行数
+	Finish(stats map[string]int64) error
+	// Fail 标记任务失败
+	Fail(cause error) error
+	// Erase 在一个事务内清理用户数据：匿名化公开模型，物理删除私有数据与凭据，匿名化并软删除用户
+	Erase() (map[string]int64, error)
+}
+
+// LoadByJobId 按任务ID查找任务
+func (m *AccountDeletionEntity) LoadByJobId(jobId string) (*AccountDeletionEntity, error) {
+	cond := m.MakeConditon(SearchAccountDeletion{JobId: jobId})
+	return m.LoadData(cond)
+}
+
+// ListRetryable 待执行的任务（排队中、可重试的失败任务、执行中断的任务），按申请时间先后
+func (m *AccountDeletionEntity) ListRetryable(limit int) ([]*AccountDeletionEntity, error) {
+	list := make([]*AccountDeletionEntity, 0)
+	err := m.Tx().Table(m.TableName()).
+		Where("deleted_at IS NULL").
+		Where("status = ? OR (status = ? AND attempts < ?) OR (status = ? AND updated_at < ?)",
+			StatusPending, StatusFailed, MaxAttempts, StatusRunning, time.Now().Add(-staleRunning)).
+		Order("id ASC").
+		Limit(limit).
+		Find(&list).Error
+	return list, err
+}
+
+// Start 标记任务开始执行
+func (m *AccountDeletionEntity) Start() error {
+	m.Status = StatusRunning
+	m.Attempts++
+	_, err := m.Update()
+	return err
+}
+
+// Finish 标记任务完成并记录各表处理行数
+func (m *AccountDeletionEntity) Finish(stats map[string]int64) error {
+	data, err := json.Marshal(stats)
+	if err != nil {
+		return err
+	}
+	m.Status = StatusFinished
+	m.Stats = string(data)
+	m.Error = ""
+	m.FinishedAt = db.LocalTime(time.Now())
+	_, err = m.Update()
+	return err
+}
+
+// Fail 标记任务失败
+func (m *AccountDeletionEntity) Fail(cause error) error {
+	m.Status = StatusFailed
+	m.Error = cause.Error()
+	if err := m.Repair(); err != nil {
+		return err
+	}
+	_, err := m.Update()
+	return err
+}
+
+// publicModelCond 需保留（匿名化）的模型：已发布、跨企业共享或官方模型，其余视为作者私有
+const publicModelCond = "(status = 1 OR is_shared = 1 OR is_official = 1)"
+
+// Erase 在一个事务内清理用户数据：匿名化公开模型，物理删除私有数据与凭据，匿名化并软删除用户
+// 全部语句按用户ID幂等执行，任务失败重试时可安全重复
+func (m *AccountDeletionEntity) Erase() (map[string]int64, error) {
+	stats := map[string]int64{}
+	err := m.Transaction(func(tx *gorm.DB) error {
+		steps := []struct {
+			name string
+			sql  string
+			args []any
+		}{
+			// 私有模型的标签、私有模型
+			{"model_tags", "DELETE FROM model_tags WHERE model_id IN (SELECT id FROM thinking_models WHERE author_id = ? AND NOT " + publicModelCond + ")", []any{m.UserId}},
+			{"thinking_models.deleted", "DELETE FROM thinking_models WHERE author_id = ? AND NOT " + publicModelCond, []any{m.UserId}},
+			// 公开模型保留内容，去掉作者信息
+			{"thinking_models.anonymized", "UPDATE thinking_models SET author_id = 0, author_name = ? WHERE author_id = ?", []any{AnonymousName, m.UserId}},
+			// 课题实践数据（含已软删除的数据）
+			{"action_followups", "DELETE FROM action_followups WHERE user_id = ?", []any{m.UserId}},
+			{"actions", "DELETE FROM actions WHERE user_id = ?", []any{m.UserId}},
+			{"topic_analyses", "DELETE FROM topic_analyses WHERE user_id = ?", []any{m.UserId}},
+			{"topics", "DELETE FROM topics WHERE user_id = ?", []any{m.UserId}},
+			// 凭据与登录记录
+			{"personal_access_tokens", "DELETE FROM personal_access_tokens WHERE user_id = ?", []any{m.UserId}},
+			{"user_identities", "DELETE FROM user_identities WHERE user_id = ?", []any{m.UserId}},
+			{"user_sessions", "DELETE FROM user_sessions WHERE user_id = ?", []any{m.UserId}},
+			// 关注关系（关注他人与被关注）
+			{"user_follows", "DELETE FROM user_follows WHERE follower_id = ? OR followee_id = ?", []any{m.UserId, m.UserId}},
+			// 用户：保留ID供审计日志等引用，清空个人信息后软删除
+			{"users", "UPDATE users SET username = ?, password = '', nickname = ?, email = '', phone = '', avatar = '', bio = ''," +
+				" expert_title = '', expert_company = '', expert_domains = '', last_login_ip = '', role_ids = ''," +
+				" totp_secret = '', totp_enabled = 0, recovery_codes = '', status = 0, deleted_at = COALESCE(deleted_at, NOW()) WHERE id = ?",
+				[]any{fmt.Sprintf("deleted_%d", m.UserId), AnonymousName, m.UserId}},
+		}
+		for _, step := range steps {
+			res := tx.Exec(step.sql, step.args...)
+			if res.Error != nil {
+				return fmt.Errorf("%s: %w", step.name, res.Error)
+			}
+			stats[step.name] = res.RowsAffected
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
@@ -0,0 +1,99 @@
+package accountDeletion
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jianyuezhexue/base"
+	"thinkingModels/component/db"
+	"thinkingModels/component/errs"
+)
+
+// 任务状态
+const (
+	StatusPending  = 0 // 排队中
+	StatusRunning  = 1 // 处理中
+	StatusFinished = 2 // 已完成
+	StatusFailed   = 3 // 失败（未超过重试次数时会自动重试）
+
+	MaxAttempts = 5 // 最大执行次数
+
+	// staleRunning 处理中超过该时长视为执行实例已退出，任务重新进入队列
+	staleRunning = 10 * time.Minute
+)
+
+// AccountDeletionEntityInterface 注销账号任务实体接口
+type AccountDeletionEntityInterface interface {
+	base.BaseModelInterface[AccountDeletionEntity]
+	AccountDeletionAbility
+}
+
+// AccountDeletionEntity 注销账号任务实体：申请注销时凭据立即失效，数据由后台任务清理
+type AccountDeletionEntity struct {
+	base.BaseModel[AccountDeletionEntity]
+	JobId      string       `json:"jobId" type:"db" comment:"任务ID(随机串，用于匿名查询进度)"`
+	UserId     uint64       `json:"userId" type:"db" comment:"注销的用户ID"`
+	Status     int          `json:"status" type:"db" comment:"状态:0=排队中,1=处理中,2=已完成,3=失败"`
+	Attempts   int          `json:"attempts" type:"db" comment:"已执行次数"`
+	Stats      string       `json:"stats" type:"db" comment:"各表处理行数(JSON)"`
+	Error      string       `json:"-" type:"db" comment:"最近一次失败原因"`
+	FinishedAt db.LocalTime `json:"finishedAt" type:"db" comment:"完成时间"`
+}
+
+// NewAccountDeletionEntity 实例化注销账号任务实体
+func NewAccountDeletionEntity(ctx *gin.Context, opt ...base.Option[AccountDeletionEntity]) AccountDeletionEntityInterface {
+	entity := &AccountDeletionEntity{}
+	entity.BaseModel = base.NewBaseModel(ctx, db.InitDb(), entity.TableName(), entity)
+
+	// 自定义配置选项
+	if len(opt) > 0 {
+		for _, fc := range opt {
+			fc(&entity.BaseModel)
+		}
+	}
+	return entity
+}
+
+// TableName 数据表名
+func (m *AccountDeletionEntity) TableName() string {
+	return "account_deletions"
+}
+
+// Validate 数据校验
+func (m *AccountDeletionEntity) Validate() error {
+	if m.UserId == 0 || m.JobId == "" {
+		return errs.Validation("用户ID和任务ID不能为空")
+	}
+	return nil
+}
+
+// Repair 数据修复：截断超长的失败原因
+func (m *AccountDeletionEntity) Repair() error {
+	if len(m.Error) > 500 {
+		m.Error = m.Error[:500]
+	}
+	return nil
+}
+
+// Complete 数据完善
+func (m *AccountDeletionEntity) Complete() error {
+	return nil
+}
+
+// StatusText 状态描述
+func (m *AccountDeletionEntity) StatusText() string {
+	switch m.Status {
+	case StatusPending:
+		return "排队中"
+	case StatusRunning:
+		return "处理中"
+	case StatusFinished:
+		return "已完成"
+	case StatusFailed:
+		if m.Attempts >= MaxAttempts {
+			return "失败"
+		}
+		return "等待重试"
+	}
+	return "未知"
+}
@@ -0,0 +1,26 @@
+package accountDeletion
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestStatusText 失败任务未达到最大执行次数时显示等待重试
+func TestStatusText(t *testing.T) {
+	cases := []struct {
+		status, attempts int
+		text             string
+	}{
+		{StatusPending, 0, "排队中"},
+		{StatusRunning, 1, "处理中"},
+		{StatusFinished, 1, "已完成"},
+		{StatusFailed, 1, "等待重试"},
+		{StatusFailed, MaxAttempts, "失败"},
+		{9, 0, "未知"},
+	}
+	for _, c := range cases {
+		job := &AccountDeletionEntity{Status: c.status, Attempts: c.attempts}
+		assert.Equal(t, c.text, job.StatusText())
+	}
+}
@@ -0,0 +1,29 @@
+package accountDeletion
+
+import "encoding/json"
+
+// ==================== 请求DTO ====================
+
+// DeleteAccountRequest 注销账号请求
+type DeleteAccountRequest struct {
+	Password string `json:"password" binding:"required"` // 登录密码
+	Code     string `json:"code"`                        // 已启用两步验证时必填：6位验证码或恢复码
+}
+
+// SearchAccountDeletion 注销账号任务搜索条件
+type SearchAccountDeletion struct {
+	JobId  string `json:"jobId" form:"jobId" search:"type:eq;column:job_id;table:account_deletions"`    // 任务ID
+	UserId uint64 `json:"userId" form:"userId" search:"type:eq;column:user_id;table:account_deletions"` // 用户ID
+}
+
+// ==================== 响应DTO ====================
+
+// AccountDeletionInfo 注销账号任务进度
+type AccountDeletionInfo struct {
+	JobId      string          `json:"jobId"`      // 任务ID，凭此查询进度
+	Status     int             `json:"status"`     // 0=排队中,1=处理中,2=已完成,3=失败
+	StatusText string          `json:"statusText"` // 状态描述
+	Stats      json.RawMessage `json:"stats"`      // 各表处理行数，完成后返回
+	CreatedAt  string          `json:"createdAt"`  // 申请时间
+	FinishedAt string          `json:"finishedAt"` // 完成时间
+}
@@ -0,0 +1,29 @@
+package auditLog
+
+import (
+	"time"
+)
+
+// purgeBatchSize 每批清理的行数，避免长事务锁表
+const purgeBatchSize = 1000
+
+// AuditLogAbility 审计日志能力接口定义
+type AuditLogAbility interface {
+	// Purge 物理删除指定时间之前的审计日志，返回删除行数
+	Purge(before time.Time) (int64, error)
+}
+
+// Purge 物理删除指定时间之前的审计日志，返回删除行数
+func (m *AuditLogEntity) Purge(before time.Time) (int64, error) {
+	var total int64
+	for {
+		res := m.Tx().Exec("DELETE FROM "+m.TableName()+" WHERE created_at < ? LIMIT ?", before, purgeBatchSize)
+		if res.Error != nil {
+			return total, res.Error
+		}
+		total += res.RowsAffected
+		if res.RowsAffected < purgeBatchSize {
+			return total, nil
+		}
+	}
+}
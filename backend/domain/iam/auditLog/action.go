@@ -0,0 +1,64 @@
+package auditLog
+
+// ========== 审计动作 ==========
+// 命名规则：目标类型.动作
+
+const (
+	ActionLogin              = "auth.login"              // 登录成功
+	ActionLoginFailed        = "auth.login_failed"       // 登录失败
+	ActionTwoFactorFailed    = "auth.2fa_failed"         // 两步验证失败
+	ActionLogout             = "auth.logout"             // 登出
+	ActionLogoutAll          = "auth.logout_all"         // 退出全部设备
+	ActionPasswordChange     = "auth.password_change"    // 修改密码
+	ActionPasswordReset      = "auth.password_reset"     // 通过邮件重置密码
+	ActionTotpEnable         = "auth.2fa_enable"         // 启用两步验证
+	ActionTotpDisable        = "auth.2fa_disable"        // 关闭两步验证
+	ActionRecoveryRegenerate = "auth.recovery_codes"     // 重新生成恢复码
+	ActionOauthLink          = "auth.oauth_link"         // 绑定第三方账号
+	ActionUserRegister       = "user.register"           // 用户注册
+	ActionUserVerifyEmail    = "user.verify_email"       // 验证注册邮箱
+	ActionUserCreate         = "user.create"             // 创建用户
+	ActionUserUpdate         = "user.update"             // 修改用户
+	ActionUserDelete         = "user.delete"             // 删除用户
+	ActionUserAssignRoles    = "user.assign_roles"       // 分配角色
+	ActionUserUnlock         = "user.unlock"             // 解除登录锁定
+	ActionUserExport         = "user.export"             // 导出个人数据
+	ActionUserDeleteAccount  = "user.delete_account"     // 申请注销账号
+	ActionUserErased         = "user.erased"             // 注销账号数据清理完成
+	ActionRoleCreate         = "role.create"             // 创建角色
+	ActionRoleUpdate         = "role.update"             // 修改角色
+	ActionRoleDelete         = "role.delete"             // 删除角色
+	ActionRoleAssign         = "role.assign_permissions" // 角色分配权限
+	ActionPermissionCreate   = "permission.create"       // 创建权限
+	ActionPermissionUpdate   = "permission.update"       // 修改权限
+	ActionPermissionDelete   = "permission.delete"       // 删除权限
+	ActionInviteCreate       = "invite.create"           // 创建邀请码
+	ActionInviteUpdate       = "invite.update"           // 修改邀请码
+	ActionInviteDelete       = "invite.delete"           // 删除邀请码
+	ActionSessionRevoke      = "session.revoke"          // 下线会话
+	ActionTokenCreate        = "token.create"            // 创建个人访问令牌
+	ActionTokenRevoke        = "token.revoke"            // 吊销个人访问令牌
+	ActionModelPublish       = "model.publish"           // 发布思维模型
+	ActionModelUnpublish     = "model.unpublish"         // 下架思维模型
+	ActionModelShare         = "model.share"             // 设置模型共享
+	ActionModelDelete        = "model.delete"            // 删除思维模型
+	ActionDictCreate         = "dict.create"             // 创建字典
+	ActionDictUpdate         = "dict.update"             // 修改字典
+	ActionDictDelete         = "dict.delete"             // 删除字典
+	ActionCategoryCreate     = "category.create"         // 创建分类
+	ActionCategoryUpdate     = "category.update"         // 修改分类
+	ActionCategoryDelete     = "category.delete"         // 删除分类
+)
+
+// 审计目标类型
+const (
+	TargetUser        = "user"
+	TargetRole        = "role"
+	TargetPermission  = "permission"
+	TargetSession     = "session"
+	TargetInviteCode  = "invite_code"
+	TargetAccessToken = "access_token"
+	TargetModel       = "thinking_model"
+	TargetDictionary  = "super_dictionary"
+	TargetCategory    = "category"
+)
@@ -0,0 +1,70 @@
+package auditLog
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+// ignoreFields 不参与对比的公共字段
+var ignoreFields = map[string]bool{
+	"id":           true,
+	"createdAt":    true,
+	"updatedAt":    true,
+	"deletedAt":    true,
+	"createBy":     true,
+	"createByName": true,
+	"updateBy":     true,
+	"updateByName": true,
+}
+
+// Change 单个字段的变更
+type Change struct {
+	Before any `json:"before"`
+	After  any `json:"after"`
+}
+
+// Snapshot 数据快照：按 json 字段转为 Map（json:"-" 的敏感字段不会记录），之后修改原数据不影响快照
+func Snapshot(v any) map[string]any {
+	if v == nil {
+		return nil
+	}
+	if m, ok := v.(map[string]any); ok {
+		return m
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Pointer && rv.IsNil() {
+		return nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	m := map[string]any{}
+	if err = json.Unmarshal(data, &m); err != nil {
+		return nil
+	}
+	return m
+}
+
+// Diff 对比变更前后的数据，只返回有变化的字段；before 为空表示新增，after 为空表示删除
+func Diff(before, after any) map[string]Change {
+	b, a := Snapshot(before), Snapshot(after)
+	changes := map[string]Change{}
+	for key, value := range b {
+		if ignoreFields[key] {
+			continue
+		}
+		if next, ok := a[key]; !ok || !reflect.DeepEqual(value, next) {
+			changes[key] = Change{Before: value, After: a[key]}
+		}
+	}
+	for key, value := range a {
+		if ignoreFields[key] {
+			continue
+		}
+		if _, ok := b[key]; !ok {
+			changes[key] = Change{After: value}
+		}
+	}
+	return changes
+}
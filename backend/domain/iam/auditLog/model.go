@@ -0,0 +1,80 @@
+package auditLog
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/jianyuezhexue/base"
+	"thinkingModels/component/db"
+	"thinkingModels/component/errs"
+)
+
+// AuditLogEntityInterface 审计日志实体接口
+type AuditLogEntityInterface interface {
+	base.BaseModelInterface[AuditLogEntity]
+	AuditLogAbility
+}
+
+// AuditLogEntity 审计日志实体（只追加，不提供修改和删除接口，仅按保留期限清理）
+type AuditLogEntity struct {
+	base.BaseModel[AuditLogEntity]
+	ActorId    uint64 `json:"actorId" type:"db" comment:"操作人ID，0表示匿名"`
+	ActorName  string `json:"actorName" type:"db" comment:"操作人名称"`
+	Action     string `json:"action" type:"db" comment:"动作，如 auth.login"`
+	TargetType string `json:"targetType" type:"db" comment:"目标类型，如 user"`
+	TargetId   string `json:"targetId" type:"db" comment:"目标ID"`
+	Ip         string `json:"ip" type:"db" comment:"客户端IP"`
+	UserAgent  string `json:"userAgent" type:"db" comment:"User-Agent"`
+	TraceId    string `json:"traceId" type:"db" comment:"链路追踪ID"`
+	Diff       string `json:"diff" type:"db" comment:"变更前后差异(JSON)"`
+	Remark     string `json:"remark" type:"db" comment:"备注，如失败原因"`
+}
+
+// NewAuditLogEntity 实例化审计日志实体
+func NewAuditLogEntity(ctx *gin.Context, opt ...base.Option[AuditLogEntity]) AuditLogEntityInterface {
+	entity := &AuditLogEntity{}
+	entity.BaseModel = base.NewBaseModel(ctx, db.InitDb(), entity.TableName(), entity)
+
+	// 自定义配置选项
+	if len(opt) > 0 {
+		for _, fc := range opt {
+			fc(&entity.BaseModel)
+		}
+	}
+	return entity
+}
+
+// TableName 数据表名
+func (m *AuditLogEntity) TableName() string {
+	return "audit_logs"
+}
+
+// Validate 数据校验
+func (m *AuditLogEntity) Validate() error {
+	if m.Action == "" {
+		return errs.Validation("审计动作不能为空")
+	}
+	return nil
+}
+
+// Repair 数据修复：截断超长字段
+func (m *AuditLogEntity) Repair() error {
+	m.ActorName = truncate(m.ActorName, 50)
+	m.TargetId = truncate(m.TargetId, 64)
+	m.UserAgent = truncate(m.UserAgent, 500)
+	m.TraceId = truncate(m.TraceId, 64)
+	m.Remark = truncate(m.Remark, 500)
+	return nil
+}
+
+// Complete 数据完善
+func (m *AuditLogEntity) Complete() error {
+	return nil
+}
+
+// truncate 按字符截断
+func truncate(s string, max int) string {
+	runes := []rune(s)
+	if len(runes) > max {
+		return string(runes[:max])
+	}
+	return s
+}
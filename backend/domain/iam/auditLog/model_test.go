@@ -0,0 +1,38 @@
+package auditLog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type diffSample struct {
+	Id       uint64 `json:"id"`
+	Name     string `json:"name"`
+	Password string `json:"-"`
+	Status   int    `json:"status"`
+	RoleIds  string `json:"roleIds"`
+}
+
+// TestDiff 只记录有变化的字段，敏感字段与公共字段不记录
+func TestDiff(t *testing.T) {
+	before := &diffSample{Id: 1, Name: "a", Password: "old", Status: 1, RoleIds: "1"}
+	snapshot := Snapshot(before)
+	before.Name, before.Password, before.RoleIds = "b", "new", "1,2"
+
+	diff := Diff(snapshot, before)
+	assert.Equal(t, map[string]Change{
+		"name":    {Before: "a", After: "b"},
+		"roleIds": {Before: "1", After: "1,2"},
+	}, diff)
+
+	// 新增与删除
+	created := Diff(nil, &diffSample{Id: 2, Name: "c"})
+	assert.Equal(t, Change{After: "c"}, created["name"])
+	assert.NotContains(t, created, "id")
+	deleted := Diff(&diffSample{Name: "c"}, nil)
+	assert.Equal(t, Change{Before: "c"}, deleted["name"])
+
+	var nilSample *diffSample
+	assert.Empty(t, Diff(nilSample, nilSample))
+}
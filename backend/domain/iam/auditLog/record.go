@@ -0,0 +1,70 @@
+package auditLog
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"thinkingModels/component/logger"
+)
+
+// Entry 一条审计事件
+type Entry struct {
+	Action     string // 动作，见 action.go
+	TargetType string // 目标类型
+	TargetId   any    // 目标ID，数字或字符串
+	Before     any    // 变更前数据（实体、DTO 或 Snapshot）
+	After      any    // 变更后数据
+	ActorId    uint64 // 操作人，为空时取当前登录用户
+	ActorName  string // 操作人名称，为空时取当前登录用户
+	Remark     string // 备注
+}
+
+// Record 写入审计日志：从请求上下文补全操作人、IP、User-Agent 与 trace id
+// 审计写入失败只记录日志，不影响业务操作
+func Record(ctx *gin.Context, e *Entry) {
+	entity := NewAuditLogEntity(ctx)
+	data, ok := entity.(*AuditLogEntity)
+	if !ok {
+		return
+	}
+	data.Action = e.Action
+	data.TargetType = e.TargetType
+	data.ActorId = e.ActorId
+	data.ActorName = e.ActorName
+	data.Remark = e.Remark
+	if e.TargetId != nil {
+		data.TargetId = fmt.Sprint(e.TargetId)
+	}
+	if e.Before != nil || e.After != nil {
+		if diff := Diff(e.Before, e.After); len(diff) > 0 {
+			b, _ := json.Marshal(diff)
+			data.Diff = string(b)
+		}
+	}
+	if ctx != nil {
+		if data.ActorId == 0 {
+			data.ActorId, _ = strconv.ParseUint(ctx.GetString("currUserId"), 10, 64)
+		}
+		if data.ActorName == "" {
+			data.ActorName = ctx.GetString("currUserName")
+		}
+		data.Ip = ctx.ClientIP()
+		if ctx.Request != nil {
+			data.UserAgent = ctx.Request.UserAgent()
+			data.TraceId = logger.TraceId(ctx)
+		}
+	}
+
+	err := entity.Repair()
+	if err == nil {
+		err = entity.Validate()
+	}
+	if err == nil {
+		_, err = entity.Create()
+	}
+	if err != nil {
+		logger.Ctx(ctx).Error("写入审计日志失败", "action", data.Action, "target", data.TargetType+":"+data.TargetId, "error", err)
+	}
+}
@@ -0,0 +1,37 @@
+package auditLog
+
+import "encoding/json"
+
+// ==================== 请求DTO ====================
+
+// SearchAuditLog 审计日志搜索条件
+type SearchAuditLog struct {
+	Page       int64    `json:"page" form:"page" search:"page"`                                                      // 分页
+	PageSize   int64    `json:"pageSize" form:"pageSize" search:"pageSize"`                                          // 分页大小
+	ActorId    uint64   `json:"actorId" form:"actorId" search:"type:eq;column:actor_id;table:audit_logs"`            // 操作人ID
+	ActorName  string   `json:"actorName" form:"actorName" search:"type:like;column:actor_name;table:audit_logs"`    // 操作人名称
+	Action     string   `json:"action" form:"action" search:"type:eq;column:action;table:audit_logs"`                // 动作
+	TargetType string   `json:"targetType" form:"targetType" search:"type:eq;column:target_type;table:audit_logs"`   // 目标类型
+	TargetId   string   `json:"targetId" form:"targetId" search:"type:eq;column:target_id;table:audit_logs"`         // 目标ID
+	Ip         string   `json:"ip" form:"ip" search:"type:eq;column:ip;table:audit_logs"`                            // IP
+	TraceId    string   `json:"traceId" form:"traceId" search:"type:eq;column:trace_id;table:audit_logs"`            // 链路追踪ID
+	CreatedAt  []string `json:"createdAt" form:"createdAt" search:"type:between;column:created_at;table:audit_logs"` // 时间范围
+}
+
+// ==================== 响应DTO ====================
+
+// AuditLogInfo 审计日志信息
+type AuditLogInfo struct {
+	Id         uint64          `json:"id"`
+	ActorId    uint64          `json:"actorId"`
+	ActorName  string          `json:"actorName"`
+	Action     string          `json:"action"`
+	TargetType string          `json:"targetType"`
+	TargetId   string          `json:"targetId"`
+	Ip         string          `json:"ip"`
+	UserAgent  string          `json:"userAgent"`
+	TraceId    string          `json:"traceId"`
+	Diff       json.RawMessage `json:"diff,omitempty" swaggertype:"object"` // 变更字段 {"字段": {"before": 旧值, "after": 新值}}
+	Remark     string          `json:"remark"`
+	CreatedAt  string          `json:"createdAt"`
+}
@@ -0,0 +1,60 @@
+package inviteCode
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+	"thinkingModels/component/errs"
+)
+
+// InviteCodeAbility 邀请码能力接口定义
+type InviteCodeAbility interface {
+	// LoadByCode 按邀请码查找（不区分大小写）
+	LoadByCode(code string) (*InviteCodeEntity, error)
+	// Redeem 核销邀请码：并发安全地占用一次使用次数
+	Redeem(code string) (*InviteCodeEntity, error)
+	// Release 归还一次使用次数（核销后注册失败时调用）
+	Release() error
+}
+
+var (
+	ErrInviteCodeRequired = errs.Validation("请填写邀请码")
+	ErrInviteCodeInvalid  = errs.Validation("邀请码无效、已过期或已用完")
+)
+
+// LoadByCode 按邀请码查找（不区分大小写）
+func (m *InviteCodeEntity) LoadByCode(code string) (*InviteCodeEntity, error) {
+	cond := m.MakeConditon(SearchInviteCode{Code: NormalizeCode(code)})
+	return m.LoadData(cond)
+}
+
+// Redeem 核销邀请码：以条件更新占用一次使用次数，并发注册时不会超出次数上限
+func (m *InviteCodeEntity) Redeem(code string) (*InviteCodeEntity, error) {
+	code = NormalizeCode(code)
+	if code == "" {
+		return nil, ErrInviteCodeRequired
+	}
+	res := m.Tx().Table(m.TableName()).
+		Where("code = ? AND status = 1 AND deleted_at IS NULL", code).
+		Where("max_uses = 0 OR used_count < max_uses").
+		Where("expires_at IS NULL OR expires_at > ?", time.Now()).
+		UpdateColumn("used_count", gorm.Expr("used_count + 1"))
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return nil, ErrInviteCodeInvalid
+	}
+	entity, err := m.LoadByCode(code)
+	if err != nil || entity.Id == 0 {
+		return nil, ErrInviteCodeInvalid
+	}
+	return entity, nil
+}
+
+// Release 归还一次使用次数（核销后注册失败时调用）
+func (m *InviteCodeEntity) Release() error {
+	return m.Tx().Table(m.TableName()).
+		Where("id = ? AND used_count > 0", m.Id).
+		UpdateColumn("used_count", gorm.Expr("used_count - 1")).Error
+}
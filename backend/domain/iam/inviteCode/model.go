@@ -0,0 +1,121 @@
+package inviteCode
+
+import (
+	"crypto/rand"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jianyuezhexue/base"
+	"thinkingModels/component/db"
+	"thinkingModels/component/errs"
+)
+
+const (
+	codeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789" // 去掉易混淆的 0/O、1/I
+	codeLength   = 8                                  // 自动生成的邀请码长度
+	maxCodeLen   = 32                                 // 自定义邀请码最大长度
+)
+
+// InviteCodeEntityInterface 邀请码实体接口
+type InviteCodeEntityInterface interface {
+	base.BaseModelInterface[InviteCodeEntity]
+	InviteCodeAbility
+}
+
+// InviteCodeEntity 邀请码实体：开启邀请注册后，凭有效邀请码才能注册，注册用户记录邀请人
+type InviteCodeEntity struct {
+	base.BaseModel[InviteCodeEntity]
+	Code      string       `json:"code" type:"db" comment:"邀请码(大写，唯一)"`
+	OwnerId   uint64       `json:"ownerId" type:"db" comment:"邀请人用户ID"`
+	MaxUses   int          `json:"maxUses" type:"db" comment:"最大使用次数，0表示不限"`
+	UsedCount int          `json:"usedCount" type:"db" comment:"已使用次数"`
+	ExpiresAt db.LocalTime `json:"expiresAt" type:"db" comment:"过期时间，为空表示永不过期"`
+	Status    int          `json:"status" type:"db" comment:"状态:0=停用,1=启用"`
+	Remark    string       `json:"remark" type:"db" comment:"备注"`
+}
+
+// NewInviteCodeEntity 实例化邀请码实体
+func NewInviteCodeEntity(ctx *gin.Context, opt ...base.Option[InviteCodeEntity]) InviteCodeEntityInterface {
+	entity := &InviteCodeEntity{}
+	entity.BaseModel = base.NewBaseModel(ctx, db.InitDb(), entity.TableName(), entity)
+
+	// 自定义配置选项
+	if len(opt) > 0 {
+		for _, fc := range opt {
+			fc(&entity.BaseModel)
+		}
+	}
+	return entity
+}
+
+// TableName 数据表名
+func (m *InviteCodeEntity) TableName() string {
+	return "invite_codes"
+}
+
+// Validate 数据校验
+func (m *InviteCodeEntity) Validate() error {
+	if m.Code == "" {
+		return errs.Validation("邀请码不能为空")
+	}
+	if len(m.Code) > maxCodeLen {
+		return errs.Validation("邀请码不能超过32字符")
+	}
+	if m.OwnerId == 0 {
+		return errs.Validation("邀请人不能为空")
+	}
+	if m.MaxUses < 0 {
+		return errs.Validation("最大使用次数不能为负数")
+	}
+	return nil
+}
+
+// Repair 数据修复：邀请码统一为大写
+func (m *InviteCodeEntity) Repair() error {
+	m.Code = NormalizeCode(m.Code)
+	return nil
+}
+
+// Complete 数据完善
+func (m *InviteCodeEntity) Complete() error {
+	return nil
+}
+
+// ========== 业务方法 ==========
+
+// IsExpired 是否已过期
+func (m *InviteCodeEntity) IsExpired() bool {
+	expiresAt := time.Time(m.ExpiresAt)
+	return !expiresAt.IsZero() && time.Now().After(expiresAt)
+}
+
+// IsExhausted 使用次数是否已用完
+func (m *InviteCodeEntity) IsExhausted() bool {
+	return m.MaxUses > 0 && m.UsedCount >= m.MaxUses
+}
+
+// IsUsable 是否可用于注册（启用、未过期、未用完）
+func (m *InviteCodeEntity) IsUsable() bool {
+	return m.Status == 1 && !m.IsExpired() && !m.IsExhausted()
+}
+
+// NormalizeCode 规范化邀请码：去除首尾空白并转为大写
+func NormalizeCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// GenerateCode 随机生成邀请码
+func GenerateCode() (string, error) {
+	b := make([]byte, codeLength)
+	max := big.NewInt(int64(len(codeAlphabet)))
+	for i := range b {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		b[i] = codeAlphabet[n.Int64()]
+	}
+	return string(b), nil
+}
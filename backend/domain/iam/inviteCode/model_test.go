@@ -0,0 +1,58 @@
+package inviteCode
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"thinkingModels/component/db"
+)
+
+// TestGenerateCode 随机邀请码只包含不易混淆的字符
+func TestGenerateCode(t *testing.T) {
+	seen := map[string]bool{}
+	for i := 0; i < 100; i++ {
+		code, err := GenerateCode()
+		assert.Nil(t, err)
+		assert.Len(t, code, codeLength)
+		for _, c := range code {
+			assert.True(t, strings.ContainsRune(codeAlphabet, c), code)
+		}
+		seen[code] = true
+	}
+	assert.Greater(t, len(seen), 95)
+	assert.Equal(t, "AB12CD", NormalizeCode(" ab12cd "))
+}
+
+// TestInviteCodeEntity_IsUsable 停用、过期、用完均不可用，0 表示不限次数
+func TestInviteCodeEntity_IsUsable(t *testing.T) {
+	code := &InviteCodeEntity{Code: "ABC", Status: 1}
+	assert.True(t, code.IsUsable())
+
+	code.UsedCount = 1000
+	assert.True(t, code.IsUsable())
+	code.MaxUses = 1000
+	assert.False(t, code.IsUsable())
+	code.MaxUses = 1001
+	assert.True(t, code.IsUsable())
+
+	code.ExpiresAt = db.LocalTime(time.Now().Add(-time.Minute))
+	assert.False(t, code.IsUsable())
+	code.ExpiresAt = db.LocalTime(time.Now().Add(time.Hour))
+	assert.True(t, code.IsUsable())
+
+	code.Status = 0
+	assert.False(t, code.IsUsable())
+}
+
+func TestInviteCodeEntity_Validate(t *testing.T) {
+	code := &InviteCodeEntity{Code: "abc"}
+	assert.NotNil(t, code.Validate()) // 缺少邀请人
+	code.OwnerId = 1
+	assert.Nil(t, code.Repair())
+	assert.Equal(t, "ABC", code.Code)
+	assert.Nil(t, code.Validate())
+	code.MaxUses = -1
+	assert.NotNil(t, code.Validate())
+}
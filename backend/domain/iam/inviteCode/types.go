@@ -0,0 +1,52 @@
+package inviteCode
+
+// ==================== 请求DTO ====================
+
+// CreateInviteCode 创建邀请码请求
+type CreateInviteCode struct {
+	Code      string `json:"code" binding:"omitempty,alphanum,max=32"`                   // 自定义邀请码，为空时随机生成
+	Count     int    `json:"count" binding:"omitempty,min=1,max=100"`                    // 批量生成数量（自定义邀请码时只能为1），默认1
+	OwnerId   uint64 `json:"ownerId"`                                                    // 邀请人用户ID，为空时为当前管理员
+	MaxUses   int    `json:"maxUses" binding:"min=0"`                                    // 最大使用次数，0表示不限
+	ExpiresAt string `json:"expiresAt" binding:"omitempty,datetime=2006-01-02 15:04:05"` // 过期时间，为空表示永不过期
+	Remark    string `json:"remark" binding:"max=255"`                                   // 备注
+}
+
+// UpdateInviteCode 更新邀请码请求（邀请码与邀请人创建后不可修改）
+type UpdateInviteCode struct {
+	Id        uint64 `json:"id" binding:"required"`
+	MaxUses   int    `json:"maxUses" binding:"min=0"`                                    // 最大使用次数，0表示不限
+	ExpiresAt string `json:"expiresAt" binding:"omitempty,datetime=2006-01-02 15:04:05"` // 过期时间，为空表示永不过期
+	Status    int    `json:"status" binding:"oneof=0 1"`                                 // 状态:0=停用,1=启用
+	Remark    string `json:"remark" binding:"max=255"`                                   // 备注
+}
+
+// DelInviteCode 删除邀请码请求
+type DelInviteCode struct {
+	Ids []uint64 `json:"ids" binding:"required,min=1"`
+}
+
+// SearchInviteCode 邀请码搜索条件
+type SearchInviteCode struct {
+	Page     int64  `json:"page" form:"page" search:"page"`                                             // 分页
+	PageSize int64  `json:"pageSize" form:"pageSize" search:"pageSize"`                                 // 分页大小
+	Code     string `json:"code" form:"code" search:"type:eq;column:code;table:invite_codes"`           // 邀请码
+	OwnerId  uint64 `json:"ownerId" form:"ownerId" search:"type:eq;column:owner_id;table:invite_codes"` // 邀请人
+	Status   *int   `json:"status" form:"status" search:"type:eq;column:status;table:invite_codes"`     // 状态
+}
+
+// ==================== 响应DTO ====================
+
+// InviteCodeInfo 邀请码信息
+type InviteCodeInfo struct {
+	Id        uint64 `json:"id"`
+	Code      string `json:"code"`
+	OwnerId   uint64 `json:"ownerId"`   // 邀请人用户ID
+	MaxUses   int    `json:"maxUses"`   // 最大使用次数，0表示不限
+	UsedCount int    `json:"usedCount"` // 已使用次数
+	ExpiresAt string `json:"expiresAt"` // 为空表示永不过期
+	Status    int    `json:"status"`
+	Usable    bool   `json:"usable"` // 当前是否可用于注册
+	Remark    string `json:"remark"`
+	CreatedAt string `json:"createdAt"`
+}
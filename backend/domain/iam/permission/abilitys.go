@@ -0,0 +1,82 @@
+package permission
+
+import (
+	"github.com/gin-gonic/gin"
+	"thinkingModels/domain/iam/user"
+)
+
+// PermissionAbility 权限能力接口定义
+type PermissionAbility interface {
+	// All 获取全部权限（按模块、排序）
+	All() ([]*PermissionEntity, error)
+	// ResolveCodes 解析角色集合拥有的权限码，isSuper 表示包含超级管理员角色
+	ResolveCodes(roleIds []uint64) (codes []string, isSuper bool, err error)
+}
+
+// All 获取全部权限（按模块、排序）
+// 用于角色分配权限时展示
+func (m *PermissionEntity) All() ([]*PermissionEntity, error) {
+	var list []*PermissionEntity
+	err := m.Tx().Model(m).
+		Where("deleted_at IS NULL").
+		Order("module ASC, sort ASC, id ASC").
+		Find(&list).Error
+	if err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// ResolveCodes 解析角色集合拥有的权限码
+// 仅统计启用状态的角色；包含超级管理员角色时返回全部权限码
+func (m *PermissionEntity) ResolveCodes(roleIds []uint64) ([]string, bool, error) {
+	codes := make([]string, 0)
+	if len(roleIds) == 0 {
+		return codes, false, nil
+	}
+
+	// 是否包含超级管理员角色
+	var superCount int64
+	err := m.Tx().Table("roles").
+		Where("id IN ? AND is_super = 1 AND status = 1 AND deleted_at IS NULL", roleIds).
+		Count(&superCount).Error
+	if err != nil {
+		return nil, false, err
+	}
+	isSuper := superCount > 0
+
+	query := m.Tx().Table(m.TableName()).Where("permissions.deleted_at IS NULL")
+	if !isSuper {
+		query = query.
+			Joins("JOIN role_permissions ON role_permissions.permission_id = permissions.id AND role_permissions.deleted_at IS NULL").
+			Joins("JOIN roles ON roles.id = role_permissions.role_id AND roles.status = 1 AND roles.deleted_at IS NULL").
+			Where("role_permissions.role_id IN ?", roleIds)
+	}
+	err = query.Distinct().Order("permissions.code ASC").Pluck("permissions.code", &codes).Error
+	if err != nil {
+		return nil, false, err
+	}
+	return codes, isSuper, nil
+}
+
+// CurrPermissions 解析当前登录用户的权限码，同一请求内只查询一次
+// 结果缓存在上下文 currPermissionCodes / currIsSuper 中，供权限中间件与业务逻辑共用
+func CurrPermissions(c *gin.Context) (map[string]bool, bool, error) {
+	if granted, ok := c.Get("currPermissionCodes"); ok {
+		return granted.(map[string]bool), c.GetBool("currIsSuper"), nil
+	}
+
+	roleIds := user.ParseRoleIds(c.GetString("currRoleIds"))
+	codes, isSuper, err := NewPermissionEntity(c).ResolveCodes(roleIds)
+	if err != nil {
+		return nil, false, err
+	}
+
+	granted := make(map[string]bool, len(codes))
+	for _, code := range codes {
+		granted[code] = true
+	}
+	c.Set("currPermissionCodes", granted)
+	c.Set("currIsSuper", isSuper)
+	return granted, isSuper, nil
+}
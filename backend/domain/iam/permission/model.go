@@ -0,0 +1,67 @@
+package permission
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/jianyuezhexue/base"
+	"thinkingModels/component/db"
+	"thinkingModels/component/errs"
+)
+
+// PermissionEntityInterface 权限实体接口
+type PermissionEntityInterface interface {
+	base.BaseModelInterface[PermissionEntity]
+	PermissionAbility
+}
+
+// PermissionEntity 权限实体（权限码即前端按钮/菜单与后端路由守卫共用的标识）
+type PermissionEntity struct {
+	base.BaseModel[PermissionEntity]
+	Code        string `json:"code" type:"db" comment:"权限码,如MODEL_PUBLISH"`
+	Name        string `json:"name" type:"db" comment:"权限名称"`
+	Module      string `json:"module" type:"db" comment:"所属模块"`
+	Description string `json:"description" type:"db" comment:"权限描述"`
+	Sort        int    `json:"sort" type:"db" comment:"排序"`
+}
+
+// NewPermissionEntity 实例化权限实体
+func NewPermissionEntity(ctx *gin.Context, opt ...base.Option[PermissionEntity]) PermissionEntityInterface {
+	entity := &PermissionEntity{}
+	entity.BaseModel = base.NewBaseModel(ctx, db.InitDb(), entity.TableName(), entity)
+
+	// 自定义配置选项
+	if len(opt) > 0 {
+		for _, fc := range opt {
+			fc(&entity.BaseModel)
+		}
+	}
+	return entity
+}
+
+// TableName 数据表名
+func (m *PermissionEntity) TableName() string {
+	return "permissions"
+}
+
+// Validate 数据校验
+func (m *PermissionEntity) Validate() error {
+	if m.Code == "" {
+		return errs.Validation("权限码不能为空")
+	}
+	if len(m.Code) > 100 {
+		return errs.Validation("权限码不能超过100字符")
+	}
+	if m.Name == "" {
+		return errs.Validation("权限名称不能为空")
+	}
+	return nil
+}
+
+// Repair 数据修复
+func (m *PermissionEntity) Repair() error {
+	return nil
+}
+
+// Complete 数据完善
+func (m *PermissionEntity) Complete() error {
+	return nil
+}
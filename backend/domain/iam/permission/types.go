@@ -0,0 +1,52 @@
+package permission
+
+// ==================== 请求DTO ====================
+
+// CreatePermission 创建权限请求
+type CreatePermission struct {
+	Code        string `json:"code" binding:"required,max=100"` // 权限码
+	Name        string `json:"name" binding:"required,max=50"`  // 权限名称
+	Module      string `json:"module" binding:"max=50"`         // 所属模块
+	Description string `json:"description" binding:"max=255"`   // 权限描述
+	Sort        int    `json:"sort"`                            // 排序
+}
+
+// UpdatePermission 更新权限请求（权限码创建后不可修改）
+type UpdatePermission struct {
+	Id          uint64 `json:"id" binding:"required"`
+	Name        string `json:"name" binding:"required,max=50"` // 权限名称
+	Module      string `json:"module" binding:"max=50"`        // 所属模块
+	Description string `json:"description" binding:"max=255"`  // 权限描述
+	Sort        int    `json:"sort"`                           // 排序
+}
+
+// SearchPermission 权限搜索条件
+type SearchPermission struct {
+	Page     int64    `json:"page" form:"page" search:"page"`                                        // 分页
+	PageSize int64    `json:"pageSize" form:"pageSize" search:"pageSize"`                            // 分页大小
+	Id       uint64   `json:"id" form:"id" search:"type:eq;column:id;table:permissions"`             // ID
+	Ids      []uint64 `json:"ids" form:"ids" search:"type:in;column:id;table:permissions"`           // IDs
+	Code     string   `json:"code" form:"code" search:"type:like;column:code;table:permissions"`     // 权限码
+	Name     string   `json:"name" form:"name" search:"type:like;column:name;table:permissions"`     // 权限名称
+	Module   string   `json:"module" form:"module" search:"type:eq;column:module;table:permissions"` // 所属模块
+}
+
+// DelPermission 删除权限请求
+type DelPermission struct {
+	Ids []uint64 `json:"ids" binding:"required,min=1"`
+}
+
+// ==================== 响应DTO ====================
+
+// ListReap 分页返回
+type ListReap struct {
+	Page     int64               `json:"page" comment:"页数"`
+	PageSize int64               `json:"pageSize" comment:"每页数量"`
+	Total    int64               `json:"total" comment:"总条数"`
+	List     []*PermissionEntity `json:"list" comment:"数据"`
+}
+
+// AllReap 全量列表返回
+type AllReap struct {
+	List []*PermissionEntity `json:"list" comment:"全部权限"`
+}
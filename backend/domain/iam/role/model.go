@@ -0,0 +1,89 @@
+package role
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/jianyuezhexue/base"
+	"thinkingModels/component/db"
+	"thinkingModels/component/errs"
+)
+
+// ErrSuperRoleForbidden 非超级管理员不能设置、修改或分配超级管理员角色（超级角色越过全部权限校验）
+var ErrSuperRoleForbidden = errs.Forbidden("仅超级管理员可设置或分配超级管理员角色")
+
+// RoleEntityInterface 角色实体接口
+type RoleEntityInterface interface {
+	base.BaseModelInterface[RoleEntity]
+}
+
+// RoleEntity 角色实体
+type RoleEntity struct {
+	base.BaseModel[RoleEntity]
+	Code        string `json:"code" type:"db" comment:"角色编码"`
+	Name        string `json:"name" type:"db" comment:"角色名称"`
+	Description string `json:"description" type:"db" comment:"角色描述"`
+	IsSuper     bool   `json:"isSuper" type:"db" comment:"是否超级管理员:拥有全部权限"`
+	Status      int    `json:"status" type:"db" comment:"状态:0=禁用,1=正常"`
+	Sort        int    `json:"sort" type:"db" comment:"排序"`
+}
+
+// NewRoleEntity 实例化角色实体
+func NewRoleEntity(ctx *gin.Context, opt ...base.Option[RoleEntity]) RoleEntityInterface {
+	entity := &RoleEntity{}
+	entity.BaseModel = base.NewBaseModel(ctx, db.InitDb(), entity.TableName(), entity)
+
+	// 自定义配置选项
+	if len(opt) > 0 {
+		for _, fc := range opt {
+			fc(&entity.BaseModel)
+		}
+	}
+	return entity
+}
+
+// TableName 数据表名
+func (m *RoleEntity) TableName() string {
+	return "roles"
+}
+
+// Validate 数据校验
+func (m *RoleEntity) Validate() error {
+	if m.Code == "" {
+		return errs.Validation("角色编码不能为空")
+	}
+	if len(m.Code) > 50 {
+		return errs.Validation("角色编码不能超过50字符")
+	}
+	if m.Name == "" {
+		return errs.Validation("角色名称不能为空")
+	}
+	if len(m.Name) > 50 {
+		return errs.Validation("角色名称不能超过50字符")
+	}
+	return nil
+}
+
+// Repair 数据修复
+func (m *RoleEntity) Repair() error {
+	if m.Status != 0 && m.Status != 1 {
+		m.Status = 1
+	}
+	return nil
+}
+
+// CheckSuperGrant 涉及超级管理员角色的操作只允许超级管理员执行
+func CheckSuperGrant(operatorIsSuper bool, roles ...*RoleEntity) error {
+	if operatorIsSuper {
+		return nil
+	}
+	for _, item := range roles {
+		if item != nil && item.IsSuper {
+			return ErrSuperRoleForbidden
+		}
+	}
+	return nil
+}
+
+// Complete 数据完善
+func (m *RoleEntity) Complete() error {
+	return nil
+}
@@ -0,0 +1,25 @@
+package role
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCheckSuperGrant 非超级管理员不能创建、修改或分配超级管理员角色
+func TestCheckSuperGrant(t *testing.T) {
+	normal := &RoleEntity{Code: "user"}
+	super := &RoleEntity{Code: "admin", IsSuper: true}
+
+	// 普通角色不受限制
+	assert.Nil(t, CheckSuperGrant(false))
+	assert.Nil(t, CheckSuperGrant(false, normal, nil))
+
+	// 非超级管理员涉及超级角色时拒绝
+	assert.Equal(t, ErrSuperRoleForbidden, CheckSuperGrant(false, super))
+	assert.Equal(t, ErrSuperRoleForbidden, CheckSuperGrant(false, normal, super))
+	assert.Equal(t, ErrSuperRoleForbidden, CheckSuperGrant(false, &RoleEntity{IsSuper: true}))
+
+	// 超级管理员可以操作超级角色
+	assert.Nil(t, CheckSuperGrant(true, normal, super))
+}
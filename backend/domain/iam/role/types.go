@@ -0,0 +1,61 @@
+package role
+
+// ==================== 请求DTO ====================
+
+// CreateRole 创建角色请求
+type CreateRole struct {
+	Code        string `json:"code" binding:"required,max=50"` // 角色编码
+	Name        string `json:"name" binding:"required,max=50"` // 角色名称
+	Description string `json:"description" binding:"max=255"`  // 角色描述
+	IsSuper     bool   `json:"isSuper"`                        // 是否超级管理员
+	Status      int    `json:"status" binding:"oneof=0 1"`     // 状态
+	Sort        int    `json:"sort"`                           // 排序
+}
+
+// UpdateRole 更新角色请求
+type UpdateRole struct {
+	Id          uint64 `json:"id" binding:"required"`
+	Name        string `json:"name" binding:"required,max=50"` // 角色名称
+	Description string `json:"description" binding:"max=255"`  // 角色描述
+	IsSuper     bool   `json:"isSuper"`                        // 是否超级管理员
+	Status      int    `json:"status" binding:"oneof=0 1"`     // 状态
+	Sort        int    `json:"sort"`                           // 排序
+}
+
+// SearchRole 角色搜索条件
+type SearchRole struct {
+	Page     int64    `json:"page" form:"page" search:"page"`                                  // 分页
+	PageSize int64    `json:"pageSize" form:"pageSize" search:"pageSize"`                      // 分页大小
+	Id       uint64   `json:"id" form:"id" search:"type:eq;column:id;table:roles"`             // ID
+	Ids      []uint64 `json:"ids" form:"ids" search:"type:in;column:id;table:roles"`           // IDs
+	Code     string   `json:"code" form:"code" search:"type:eq;column:code;table:roles"`       // 角色编码
+	Name     string   `json:"name" form:"name" search:"type:like;column:name;table:roles"`     // 角色名称
+	Status   *int     `json:"status" form:"status" search:"type:eq;column:status;table:roles"` // 状态
+}
+
+// DelRole 删除角色请求
+type DelRole struct {
+	Ids []uint64 `json:"ids" binding:"required,min=1"`
+}
+
+// AssignRolePermissions 角色分配权限请求（全量覆盖）
+type AssignRolePermissions struct {
+	RoleId        uint64   `json:"roleId" binding:"required"`
+	PermissionIds []uint64 `json:"permissionIds"`
+}
+
+// ==================== 响应DTO ====================
+
+// RoleDetail 角色详情（含已分配的权限）
+type RoleDetail struct {
+	*RoleEntity
+	PermissionIds []uint64 `json:"permissionIds"`
+}
+
+// ListReap 分页返回
+type ListReap struct {
+	Page     int64         `json:"page" comment:"页数"`
+	PageSize int64         `json:"pageSize" comment:"每页数量"`
+	Total    int64         `json:"total" comment:"总条数"`
+	List     []*RoleEntity `json:"list" comment:"数据"`
+}
@@ -0,0 +1,68 @@
+package rolePermission
+
+import (
+	"gorm.io/gorm"
+)
+
+// RolePermissionAbility 角色权限关联能力接口定义
+type RolePermissionAbility interface {
+	// PermissionIdsByRole 查询角色已分配的权限ID
+	PermissionIdsByRole(roleId uint64) ([]uint64, error)
+	// Replace 全量覆盖角色的权限
+	Replace(roleId uint64, permissionIds []uint64) error
+	// DeleteByRoleIds 删除角色的全部关联
+	DeleteByRoleIds(roleIds ...uint64) error
+	// DeleteByPermissionIds 删除权限的全部关联
+	DeleteByPermissionIds(permissionIds ...uint64) error
+}
+
+// PermissionIdsByRole 查询角色已分配的权限ID
+func (m *RolePermissionEntity) PermissionIdsByRole(roleId uint64) ([]uint64, error) {
+	ids := make([]uint64, 0)
+	err := m.Tx().Table(m.TableName()).
+		Where("role_id = ? AND deleted_at IS NULL", roleId).
+		Order("permission_id ASC").
+		Pluck("permission_id", &ids).Error
+	return ids, err
+}
+
+// Replace 全量覆盖角色的权限
+// 关联数据为纯关系数据，直接物理删除后重建，避免唯一索引与软删除冲突
+func (m *RolePermissionEntity) Replace(roleId uint64, permissionIds []uint64) error {
+	return m.Transaction(func(tx *gorm.DB) error {
+		err := tx.Exec("DELETE FROM role_permissions WHERE role_id = ?", roleId).Error
+		if err != nil {
+			return err
+		}
+
+		seen := make(map[uint64]bool, len(permissionIds))
+		rows := make([]map[string]any, 0, len(permissionIds))
+		for _, permissionId := range permissionIds {
+			if permissionId == 0 || seen[permissionId] {
+				continue
+			}
+			seen[permissionId] = true
+			rows = append(rows, map[string]any{"role_id": roleId, "permission_id": permissionId})
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+		return tx.Table(m.TableName()).Create(rows).Error
+	})
+}
+
+// DeleteByRoleIds 删除角色的全部关联
+func (m *RolePermissionEntity) DeleteByRoleIds(roleIds ...uint64) error {
+	if len(roleIds) == 0 {
+		return nil
+	}
+	return m.Tx().Exec("DELETE FROM role_permissions WHERE role_id IN ?", roleIds).Error
+}
+
+// DeleteByPermissionIds 删除权限的全部关联
+func (m *RolePermissionEntity) DeleteByPermissionIds(permissionIds ...uint64) error {
+	if len(permissionIds) == 0 {
+		return nil
+	}
+	return m.Tx().Exec("DELETE FROM role_permissions WHERE permission_id IN ?", permissionIds).Error
+}
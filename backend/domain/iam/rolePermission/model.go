@@ -0,0 +1,58 @@
+package rolePermission
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/jianyuezhexue/base"
+	"thinkingModels/component/db"
+	"thinkingModels/component/errs"
+)
+
+// RolePermissionEntityInterface 角色权限关联实体接口
+type RolePermissionEntityInterface interface {
+	base.BaseModelInterface[RolePermissionEntity]
+	RolePermissionAbility
+}
+
+// RolePermissionEntity 角色权限关联实体
+type RolePermissionEntity struct {
+	base.BaseModel[RolePermissionEntity]
+	RoleId       uint64 `json:"roleId" type:"db" comment:"角色ID"`
+	PermissionId uint64 `json:"permissionId" type:"db" comment:"权限ID"`
+}
+
+// NewRolePermissionEntity 实例化角色权限关联实体
+func NewRolePermissionEntity(ctx *gin.Context, opt ...base.Option[RolePermissionEntity]) RolePermissionEntityInterface {
+	entity := &RolePermissionEntity{}
+	entity.BaseModel = base.NewBaseModel(ctx, db.InitDb(), entity.TableName(), entity)
+
+	// 自定义配置选项
+	if len(opt) > 0 {
+		for _, fc := range opt {
+			fc(&entity.BaseModel)
+		}
+	}
+	return entity
+}
+
+// TableName 数据表名
+func (m *RolePermissionEntity) TableName() string {
+	return "role_permissions"
+}
+
+// Validate 数据校验
+func (m *RolePermissionEntity) Validate() error {
+	if m.RoleId == 0 || m.PermissionId == 0 {
+		return errs.Validation("角色ID和权限ID不能为空")
+	}
+	return nil
+}
+
+// Repair 数据修复
+func (m *RolePermissionEntity) Repair() error {
+	return nil
+}
+
+// Complete 数据完善
+func (m *RolePermissionEntity) Complete() error {
+	return nil
+}
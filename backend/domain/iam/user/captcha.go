@@ -0,0 +1,112 @@
+package user
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"thinkingModels/component/captcha"
+	"thinkingModels/component/errs"
+	"thinkingModels/component/redis"
+	"thinkingModels/config"
+)
+
+// ========== 人机验证 ==========
+// 验证码答案保存在Redis中，校验时一次性取出（无论对错都作废，需重新获取）。
+// 注册按配置始终需要；登录在同一IP失败次数达到阈值后需要。
+
+const captchaKey = "captcha:%s" // 验证码ID -> 答案
+
+var (
+	ErrCaptchaRequired = errs.Validation("请完成人机验证")
+	ErrCaptchaInvalid  = errs.Validation("人机验证失败，请重试")
+)
+
+// captchaConf 读取配置，未配置时使用默认值
+func captchaConf() (ttl, loginAfter, tolerance int) {
+	conf := config.Config.Captcha
+	ttl, loginAfter, tolerance = conf.TTL, conf.LoginAfter, conf.Tolerance
+	if ttl <= 0 {
+		ttl = 120
+	}
+	if loginAfter <= 0 {
+		loginAfter = 3
+	}
+	if tolerance <= 0 {
+		tolerance = 5
+	}
+	return
+}
+
+// IssueCaptcha 生成验证码并保存答案
+func IssueCaptcha(kind string) (*CaptchaResponse, error) {
+	challenge, answer, err := captcha.New(kind)
+	if err != nil {
+		return nil, errs.Validation(err.Error())
+	}
+	b := make([]byte, 16)
+	if _, err = rand.Read(b); err != nil {
+		return nil, err
+	}
+	id := hex.EncodeToString(b)
+
+	data, err := json.Marshal(answer)
+	if err != nil {
+		return nil, err
+	}
+	ttl, _, _ := captchaConf()
+	err = redis.SetEx(fmt.Sprintf(captchaKey, id), string(data), ttl)
+	if err != nil {
+		return nil, err
+	}
+	return &CaptchaResponse{CaptchaId: id, ExpiresIn: ttl, Challenge: challenge}, nil
+}
+
+// VerifyCaptcha 校验并作废验证码
+func VerifyCaptcha(id string, submission *captcha.Submission) error {
+	if id == "" || submission == nil {
+		return ErrCaptchaRequired
+	}
+	value, err := redis.GetDel(fmt.Sprintf(captchaKey, id))
+	if err != nil {
+		return err
+	}
+	if value == "" {
+		return ErrCaptchaInvalid
+	}
+	answer := &captcha.Answer{}
+	if err = json.Unmarshal([]byte(value), answer); err != nil {
+		return ErrCaptchaInvalid
+	}
+	_, _, tolerance := captchaConf()
+	if !answer.Verify(submission, tolerance) {
+		return ErrCaptchaInvalid
+	}
+	return nil
+}
+
+// LoginCaptchaRequired 该IP登录是否需要人机验证（Redis 异常时不要求）
+func LoginCaptchaRequired(ip string) bool {
+	if ip == "" {
+		return false
+	}
+	values, err := redis.MGet(fmt.Sprintf(loginFailIpKey, ip))
+	if err != nil {
+		return false
+	}
+	var ipCount int
+	fmt.Sscan(values[0], &ipCount)
+	return captchaRequiredAfter(ipCount)
+}
+
+// RegisterCaptchaRequired 注册是否需要人机验证
+func RegisterCaptchaRequired() bool {
+	return config.Config.Captcha.Register
+}
+
+// captchaRequiredAfter IP失败次数是否已达到需要验证码的阈值
+func captchaRequiredAfter(ipCount int) bool {
+	_, loginAfter, _ := captchaConf()
+	return ipCount >= loginAfter
+}
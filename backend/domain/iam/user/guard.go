@@ -0,0 +1,210 @@
+package user
+
+import (
+	"fmt"
+	"strings"
+
+	"thinkingModels/component/errs"
+	"thinkingModels/component/redis"
+	"thinkingModels/config"
+)
+
+// ========== 登录防暴力破解 ==========
+// 按用户名、按IP分别统计失败次数：
+// 用户名每次失败后需等待指数退避时间才能再次尝试；任一维度达到上限即临时锁定。
+// Redis 异常时放行，避免缓存故障导致无法登录。
+
+const (
+	loginFailUserKey    = "login_fail:user:%s"    // 用户名失败次数
+	loginFailIpKey      = "login_fail:ip:%s"      // IP失败次数
+	loginLockUserKey    = "login_lock:user:%s"    // 用户名锁定
+	loginLockIpKey      = "login_lock:ip:%s"      // IP锁定
+	loginBackoffUserKey = "login_backoff:user:%s" // 用户名退避
+)
+
+// LoginFailedError 登录失败，附带剩余尝试次数与需等待秒数
+type LoginFailedError struct {
+	Msg               string `json:"-"`
+	RemainingAttempts int    `json:"remainingAttempts"` // 锁定前剩余尝试次数
+	RetryAfter        int    `json:"retryAfter"`        // 需等待秒数，0表示可立即重试
+	CaptchaRequired   bool   `json:"captchaRequired"`   // 再次登录是否需要人机验证
+	limited           bool   // 锁定或退避中，按请求过于频繁处理
+}
+
+func (e *LoginFailedError) Error() string {
+	return e.Msg
+}
+
+// ErrCode 锁定或退避中为请求过于频繁，其余为校验不通过
+// （不使用401：客户端遇到401会尝试刷新令牌）
+func (e *LoginFailedError) ErrCode() errs.Code {
+	if e.limited {
+		return errs.CodeRateLimited
+	}
+	return errs.CodeValidation
+}
+
+// loginGuardConf 读取配置，未配置时使用默认值
+func loginGuardConf() (maxFailures, ipMaxFailures, window, lock, backoffBase, backoffMax int) {
+	conf := config.Config.LoginGuard
+	maxFailures, ipMaxFailures = conf.MaxFailures, conf.IpMaxFailures
+	window, lock = conf.FailureWindow, conf.LockDuration
+	backoffBase, backoffMax = conf.BackoffBase, conf.BackoffMax
+	if maxFailures <= 0 {
+		maxFailures = 5
+	}
+	if ipMaxFailures <= 0 {
+		ipMaxFailures = 20
+	}
+	if window <= 0 {
+		window = 900
+	}
+	if lock <= 0 {
+		lock = 900
+	}
+	if backoffBase < 0 {
+		backoffBase = 0
+	}
+	if backoffMax <= 0 {
+		backoffMax = 60
+	}
+	return
+}
+
+// normalizeUsername 统一用户名计数键
+func normalizeUsername(username string) string {
+	return strings.ToLower(strings.TrimSpace(username))
+}
+
+// CheckLoginAllowed 校验当前是否允许尝试登录（锁定或退避中返回 LoginFailedError）
+func CheckLoginAllowed(username, ip string) error {
+	username = normalizeUsername(username)
+
+	if ttl, err := redis.TTL(fmt.Sprintf(loginLockUserKey, username)); err == nil && ttl > 0 {
+		return lockedError(ttl)
+	}
+	if ip != "" {
+		if ttl, err := redis.TTL(fmt.Sprintf(loginLockIpKey, ip)); err == nil && ttl > 0 {
+			return lockedError(ttl)
+		}
+	}
+	if ttl, err := redis.TTL(fmt.Sprintf(loginBackoffUserKey, username)); err == nil && ttl > 0 {
+		return &LoginFailedError{
+			Msg:               fmt.Sprintf("尝试过于频繁，请%d秒后再试", ttl),
+			RemainingAttempts: remainingAttempts(username, ip),
+			RetryAfter:        ttl,
+			limited:           true,
+		}
+	}
+	return nil
+}
+
+// RecordLoginFailure 记录一次登录失败，返回携带剩余次数的错误
+func RecordLoginFailure(username, ip string) error {
+	username = normalizeUsername(username)
+	maxFailures, ipMaxFailures, window, lock, backoffBase, backoffMax := loginGuardConf()
+
+	userCount, err := redis.Incr(fmt.Sprintf(loginFailUserKey, username), window)
+	if err != nil {
+		return &LoginFailedError{Msg: "用户名或密码错误", RemainingAttempts: -1}
+	}
+	ipCount := 0
+	if ip != "" {
+		ipCount, _ = redis.Incr(fmt.Sprintf(loginFailIpKey, ip), window)
+	}
+
+	// 达到上限：锁定
+	if userCount >= maxFailures {
+		_ = redis.SetEx(fmt.Sprintf(loginLockUserKey, username), "1", lock)
+		_ = redis.Del(fmt.Sprintf(loginFailUserKey, username), fmt.Sprintf(loginBackoffUserKey, username))
+		return lockedError(lock)
+	}
+	if ip != "" && ipCount >= ipMaxFailures {
+		_ = redis.SetEx(fmt.Sprintf(loginLockIpKey, ip), "1", lock)
+		_ = redis.Del(fmt.Sprintf(loginFailIpKey, ip))
+		return lockedError(lock)
+	}
+
+	// 未达上限：指数退避
+	backoff := LoginBackoff(userCount, backoffBase, backoffMax)
+	if backoff > 0 {
+		_ = redis.SetEx(fmt.Sprintf(loginBackoffUserKey, username), "1", backoff)
+	}
+
+	remaining := maxFailures - userCount
+	if ip != "" && ipMaxFailures-ipCount < remaining {
+		remaining = ipMaxFailures - ipCount
+	}
+	return &LoginFailedError{
+		Msg:               fmt.Sprintf("用户名或密码错误，还可尝试%d次", remaining),
+		RemainingAttempts: remaining,
+		RetryAfter:        backoff,
+		CaptchaRequired:   ip != "" && captchaRequiredAfter(ipCount),
+	}
+}
+
+// ClearLoginFailures 登录成功后清除该用户名的失败记录（IP计数保留，防止撞库）
+func ClearLoginFailures(username string) {
+	username = normalizeUsername(username)
+	_ = redis.Del(fmt.Sprintf(loginFailUserKey, username), fmt.Sprintf(loginBackoffUserKey, username))
+}
+
+// UnlockLogin 解除用户名和/或IP的登录锁定（管理员操作）
+func UnlockLogin(username, ip string) error {
+	keys := make([]string, 0, 5)
+	if username = normalizeUsername(username); username != "" {
+		keys = append(keys,
+			fmt.Sprintf(loginFailUserKey, username),
+			fmt.Sprintf(loginLockUserKey, username),
+			fmt.Sprintf(loginBackoffUserKey, username),
+		)
+	}
+	if ip != "" {
+		keys = append(keys, fmt.Sprintf(loginFailIpKey, ip), fmt.Sprintf(loginLockIpKey, ip))
+	}
+	return redis.Del(keys...)
+}
+
+// LoginBackoff 第 failures 次失败后的退避秒数：base*2^(failures-1)，不超过 max
+func LoginBackoff(failures, base, max int) int {
+	if failures <= 0 || base <= 0 {
+		return 0
+	}
+	backoff := base
+	for i := 1; i < failures && backoff < max; i++ {
+		backoff *= 2
+	}
+	if backoff > max {
+		backoff = max
+	}
+	return backoff
+}
+
+// remainingAttempts 查询锁定前剩余尝试次数
+func remainingAttempts(username, ip string) int {
+	maxFailures, ipMaxFailures, _, _, _, _ := loginGuardConf()
+	keys := []string{fmt.Sprintf(loginFailUserKey, username), fmt.Sprintf(loginFailIpKey, ip)}
+	values, err := redis.MGet(keys...)
+	if err != nil {
+		return -1
+	}
+	var userCount, ipCount int
+	fmt.Sscan(values[0], &userCount)
+	fmt.Sscan(values[1], &ipCount)
+	remaining := maxFailures - userCount
+	if ip != "" && ipMaxFailures-ipCount < remaining {
+		remaining = ipMaxFailures - ipCount
+	}
+	return remaining
+}
+
+// lockedError 已锁定错误
+func lockedError(ttl int) *LoginFailedError {
+	minutes := (ttl + 59) / 60
+	return &LoginFailedError{
+		Msg:               fmt.Sprintf("登录失败次数过多，已临时锁定，请%d分钟后再试", minutes),
+		RemainingAttempts: 0,
+		RetryAfter:        ttl,
+		limited:           true,
+	}
+}
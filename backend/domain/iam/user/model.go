@@ -4,6 +4,8 @@ import (
 	"crypto/rand"
 	"encoding/hex"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -11,24 +13,50 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 	"golang.org/x/crypto/bcrypt"
 	"thinkingModels/component/db"
+	"thinkingModels/component/errs"
+	"thinkingModels/component/jwtkey"
 )
 
 // ========== JWT相关类型定义 ==========
 
+// 签名密钥见 config.yaml 的 jwt 配置（component/jwtkey）
+const (
+	jwtIssuer = "thinkingModels" // 签发者
+
+	accessTokenTTL  = time.Hour          // Access Token 有效期
+	refreshTokenTTL = 7 * 24 * time.Hour // Refresh Token 有效期
+
+	tokenTypeAccess  = "access"  // Access Token 类型
+	tokenTypeRefresh = "refresh" // Refresh Token 类型
+)
+
 // UserClaims JWT Claims定义
 type UserClaims struct {
 	UserID       uint64   `json:"sub"`
 	Username     string   `json:"username"`
 	EnterpriseID uint64   `json:"enterprise_id"`
 	RoleIds      string   `json:"role_ids"`
+	FamilyID     string   `json:"fid"` // 所属刷新令牌家族（登录会话）
+	TokenType    string   `json:"token_type"`
+	jwt.RegisteredClaims
+}
+
+// RefreshClaims Refresh Token Claims定义
+type RefreshClaims struct {
+	UserID    uint64 `json:"sub"`
+	FamilyID  string `json:"fid"` // 刷新令牌家族ID，同一次登录轮换出的所有Refresh Token共享
+	TokenType string `json:"token_type"`
 	jwt.RegisteredClaims
 }
 
 // TokenPair Token对
 type TokenPair struct {
-	AccessToken  string
-	RefreshToken string
-	ExpiresIn    int64 // 过期时间（秒）
+	AccessToken      string
+	RefreshToken     string
+	ExpiresIn        int64  // 过期时间（秒）
+	FamilyId         string // 刷新令牌家族ID
+	RefreshTokenId   string // Refresh Token 的 jti
+	RefreshExpiresIn int64  // Refresh Token 过期时间（秒）
 }
 
 // ========== 用户实体 ==========
@@ -40,9 +68,27 @@ type UserEntityInterface interface {
 	VerifyPassword(plainPassword string) bool
 	HashPassword() error
 	GenerateToken() (*TokenPair, error)
+	RotateToken(familyId string) (*TokenPair, error)
 	UpdateLoginInfo(ip string)
+	// 角色相关方法
+	RoleIdList() []uint64
+	SetRoleIds(roleIds []uint64)
+	// 两步验证相关方法
+	EnrollTotp() (secret, uri string, err error)
+	ConfirmTotp(code string) ([]string, error)
+	VerifyTotp(code string) bool
+	UseRecoveryCode(code string) bool
+	RegenerateRecoveryCodes() ([]string, error)
+	DisableTotp()
 }
 
+// 用户状态
+const (
+	StatusDisabled = 0 // 禁用
+	StatusActive   = 1 // 正常
+	StatusPending  = 2 // 待验证邮箱（自助注册开启邮箱验证时）
+)
+
 // UserEntity 用户实体
 type UserEntity struct {
 	base.BaseModel[UserEntity]
@@ -52,11 +98,18 @@ type UserEntity struct {
 	Email          string       `json:"email" type:"db" comment:"邮箱"`
 	Phone          string       `json:"phone" type:"db" comment:"手机号"`
 	Avatar         string       `json:"avatar" type:"db" comment:"头像URL"`
-	Status         int          `json:"status" type:"db" comment:"状态:0=禁用,1=正常"`
+	Bio            string       `json:"bio" type:"db" comment:"个人简介"`
+	Status         int          `json:"status" type:"db" comment:"状态:0=禁用,1=正常,2=待验证邮箱"`
+	EmailVerified  bool         `json:"emailVerified" type:"db" comment:"邮箱是否已验证"`
 	LastLoginTime  db.LocalTime `json:"lastLoginTime" type:"db" comment:"最后登录时间"`
 	LastLoginIP    string       `json:"lastLoginIp" type:"db" comment:"最后登录IP"`
 	EnterpriseID   uint64       `json:"enterpriseId" type:"db" comment:"企业ID"`
 	RoleIds        string       `json:"roleIds" type:"db" comment:"角色ID列表，逗号分隔"`
+	TotpSecret     string       `json:"-" type:"db" comment:"TOTP密钥(Base32)"`
+	TotpEnabled    bool         `json:"totpEnabled" type:"db" comment:"是否已启用两步验证"`
+	RecoveryCodes  string       `json:"-" type:"db" comment:"两步验证恢复码摘要，逗号分隔"`
+	InvitedBy      uint64       `json:"invitedBy" type:"db" comment:"邀请人用户ID"`
+	InviteCodeId   uint64       `json:"inviteCodeId" type:"db" comment:"注册使用的邀请码ID"`
 }
 
 // 实例化用户实体
@@ -115,10 +168,13 @@ func (u *UserEntity) HashPassword() error {
 	return nil
 }
 
-// GenerateToken 为用户生成JWT Token（实体方法）
+// GenerateToken 为用户生成JWT Token（实体方法），同时开启新的刷新令牌家族
 func (u *UserEntity) GenerateToken() (*TokenPair, error) {
-	// TODO: 从配置文件读取JWT密钥
-	secret := "your-secret-key-change-in-production"
+	return u.RotateToken(generateUniqueID())
+}
+
+// RotateToken 在指定刷新令牌家族内签发新的Token对（实体方法）
+func (u *UserEntity) RotateToken(familyId string) (*TokenPair, error) {
 	now := time.Now()
 
 	// Access Token (1小时有效期)
@@ -127,45 +183,142 @@ func (u *UserEntity) GenerateToken() (*TokenPair, error) {
 		Username:     u.Username,
 		EnterpriseID: u.EnterpriseID,
 		RoleIds:      u.RoleIds,
+		FamilyID:     familyId,
+		TokenType:    tokenTypeAccess,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour)),
+			ExpiresAt: jwt.NewNumericDate(now.Add(accessTokenTTL)),
 			IssuedAt:  jwt.NewNumericDate(now),
 			NotBefore: jwt.NewNumericDate(now),
-			Issuer:    "thinkingModels",
+			Issuer:    jwtIssuer,
 			ID:        generateUniqueID(),
 		},
 	}
 
-	accessToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, accessClaims).SignedString([]byte(secret))
+	accessToken, err := signToken(accessClaims)
 	if err != nil {
 		return nil, err
 	}
 
 	// Refresh Token (7天有效期)
-	refreshClaims := jwt.RegisteredClaims{
-		ExpiresAt: jwt.NewNumericDate(now.Add(7 * 24 * time.Hour)),
-		IssuedAt:  jwt.NewNumericDate(now),
-		ID:        generateUniqueID(),
+	refreshId := generateUniqueID()
+	refreshClaims := RefreshClaims{
+		UserID:    u.Id,
+		FamilyID:  familyId,
+		TokenType: tokenTypeRefresh,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(now.Add(refreshTokenTTL)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			Issuer:    jwtIssuer,
+			ID:        refreshId,
+		},
 	}
 
-	refreshToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, refreshClaims).SignedString([]byte(secret))
+	refreshToken, err := signToken(refreshClaims)
 	if err != nil {
 		return nil, err
 	}
 
 	return &TokenPair{
-		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
-		ExpiresIn:    3600, // 1小时
+		AccessToken:      accessToken,
+		RefreshToken:     refreshToken,
+		ExpiresIn:        int64(accessTokenTTL.Seconds()),
+		FamilyId:         familyId,
+		RefreshTokenId:   refreshId,
+		RefreshExpiresIn: int64(refreshTokenTTL.Seconds()),
 	}, nil
 }
 
+// signToken 使用当前签发密钥签名
+func signToken(claims jwt.Claims) (string, error) {
+	keys, err := jwtkey.Default()
+	if err != nil {
+		return "", err
+	}
+	return keys.Sign(claims)
+}
+
+// parseToken 按 kid 选取密钥校验签名，并校验算法、过期时间、生效时间(nbf)以及签发者
+func parseToken(tokenString string, claims jwt.Claims) (*jwt.Token, error) {
+	keys, err := jwtkey.Default()
+	if err != nil {
+		return nil, err
+	}
+	return jwt.ParseWithClaims(tokenString, claims, keys.Keyfunc,
+		jwt.WithValidMethods(keys.Methods()), jwt.WithIssuer(jwtIssuer), jwt.WithExpirationRequired())
+}
+
+// ParseToken 解析并校验Access Token
+func ParseToken(tokenString string) (*UserClaims, error) {
+	claims := &UserClaims{}
+	token, err := parseToken(tokenString, claims)
+	if err != nil {
+		return nil, err
+	}
+	if !token.Valid || claims.UserID == 0 || claims.TokenType != tokenTypeAccess {
+		return nil, errs.Unauthorized("token无效")
+	}
+	return claims, nil
+}
+
+// ParseRefreshToken 解析并校验Refresh Token
+func ParseRefreshToken(tokenString string) (*RefreshClaims, error) {
+	claims := &RefreshClaims{}
+	token, err := parseToken(tokenString, claims)
+	if err != nil {
+		return nil, err
+	}
+	if !token.Valid || claims.UserID == 0 || claims.FamilyID == "" || claims.TokenType != tokenTypeRefresh {
+		return nil, errs.Unauthorized("refresh token无效")
+	}
+	return claims, nil
+}
+
 // UpdateLoginInfo 更新登录信息
 func (u *UserEntity) UpdateLoginInfo(ip string) {
 	u.LastLoginTime = db.LocalTime(time.Now())
 	u.LastLoginIP = ip
 }
 
+// DisplayName 公开展示的名称：昵称，未设置时为用户名
+func (u *UserEntity) DisplayName() string {
+	if u.Nickname != "" {
+		return u.Nickname
+	}
+	return u.Username
+}
+
+// RoleIdList 解析角色ID列表
+func (u *UserEntity) RoleIdList() []uint64 {
+	return ParseRoleIds(u.RoleIds)
+}
+
+// SetRoleIds 设置角色ID列表（去重，逗号分隔存储）
+func (u *UserEntity) SetRoleIds(roleIds []uint64) {
+	seen := make(map[uint64]bool, len(roleIds))
+	parts := make([]string, 0, len(roleIds))
+	for _, id := range roleIds {
+		if id == 0 || seen[id] {
+			continue
+		}
+		seen[id] = true
+		parts = append(parts, strconv.FormatUint(id, 10))
+	}
+	u.RoleIds = strings.Join(parts, ",")
+}
+
+// ParseRoleIds 解析逗号分隔的角色ID字符串，忽略非法值
+func ParseRoleIds(roleIds string) []uint64 {
+	ids := make([]uint64, 0)
+	for _, part := range strings.Split(roleIds, ",") {
+		id, err := strconv.ParseUint(strings.TrimSpace(part), 10, 64)
+		if err == nil && id > 0 {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
 // generateUniqueID 生成唯一ID
 func generateUniqueID() string {
 	// 生成6字节随机数
@@ -1,15 +1,19 @@
 package user
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"thinkingModels/component/db"
+	"thinkingModels/component/redis"
 
 	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
 )
 
 // 测试用户数据
@@ -383,7 +387,7 @@ func createTestContextWithUser() *gin.Context {
 	ctx, _ := gin.CreateTestContext(w)
 	ctx.Request = req
 
-	// 设置用户信息到context（模拟middleware.UserInfo）
+	// 设置用户信息到context（模拟middleware.Auth）
 	ctx.Set("currUserId", "1")
 	ctx.Set("currUserName", "buildBlock")
 
@@ -456,3 +460,292 @@ func TestInsertVbenUser(t *testing.T) {
 
 	t.Log("Vben user test passed - can now login with username: vben, password: 123456")
 }
+
+// TestParseToken 测试Access Token解析与校验
+func TestParseToken(t *testing.T) {
+	userEntity := &UserEntity{Username: "test_token", EnterpriseID: 1, RoleIds: "1,2"}
+	userEntity.Id = 1001
+
+	tokenPair, err := userEntity.GenerateToken()
+	if err != nil {
+		t.Fatalf("GenerateToken failed: %v", err)
+	}
+
+	// 正常token
+	claims, err := ParseToken(tokenPair.AccessToken)
+	if err != nil {
+		t.Fatalf("ParseToken failed: %v", err)
+	}
+	if claims.UserID != 1001 || claims.Username != "test_token" || claims.RoleIds != "1,2" {
+		t.Errorf("Claims mismatch: %+v", claims)
+	}
+
+	// 篡改签名
+	if _, err := ParseToken(tokenPair.AccessToken + "x"); err == nil {
+		t.Error("ParseToken should fail for tampered token")
+	}
+
+	// 过期token
+	expired := UserClaims{
+		UserID: 1001,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(-time.Minute)),
+			Issuer:    jwtIssuer,
+		},
+	}
+	expiredToken, _ := signToken(expired)
+	if _, err := ParseToken(expiredToken); err == nil {
+		t.Error("ParseToken should fail for expired token")
+	}
+
+	// 签发者不匹配
+	wrongIssuer := UserClaims{
+		UserID: 1001,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+			Issuer:    "other",
+		},
+	}
+	wrongIssuerToken, _ := signToken(wrongIssuer)
+	if _, err := ParseToken(wrongIssuerToken); err == nil {
+		t.Error("ParseToken should fail for wrong issuer")
+	}
+
+	// 尚未生效
+	notBefore := UserClaims{
+		UserID: 1001,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+			NotBefore: jwt.NewNumericDate(time.Now().Add(time.Minute * 10)),
+			Issuer:    jwtIssuer,
+		},
+	}
+	notBeforeToken, _ := signToken(notBefore)
+	if _, err := ParseToken(notBeforeToken); err == nil {
+		t.Error("ParseToken should fail for token not valid yet")
+	}
+}
+
+// TestRotateToken 测试Refresh Token签发与解析
+func TestRotateToken(t *testing.T) {
+	userEntity := &UserEntity{Username: "test_refresh"}
+	userEntity.Id = 1002
+
+	first, err := userEntity.GenerateToken()
+	if err != nil {
+		t.Fatalf("GenerateToken failed: %v", err)
+	}
+	if first.FamilyId == "" || first.RefreshTokenId == "" {
+		t.Fatalf("TokenPair should carry family and refresh id: %+v", first)
+	}
+
+	// 同一家族内轮换
+	second, err := userEntity.RotateToken(first.FamilyId)
+	if err != nil {
+		t.Fatalf("RotateToken failed: %v", err)
+	}
+	if second.FamilyId != first.FamilyId {
+		t.Errorf("FamilyId should be kept, expected %s, got %s", first.FamilyId, second.FamilyId)
+	}
+	if second.RefreshTokenId == first.RefreshTokenId {
+		t.Error("RefreshTokenId should change after rotation")
+	}
+
+	claims, err := ParseRefreshToken(second.RefreshToken)
+	if err != nil {
+		t.Fatalf("ParseRefreshToken failed: %v", err)
+	}
+	if claims.UserID != 1002 || claims.FamilyID != first.FamilyId || claims.ID != second.RefreshTokenId {
+		t.Errorf("RefreshClaims mismatch: %+v", claims)
+	}
+
+	// Access Token 与 Refresh Token 不可混用
+	if _, err := ParseToken(second.RefreshToken); err == nil {
+		t.Error("ParseToken should reject refresh token")
+	}
+	if _, err := ParseRefreshToken(second.AccessToken); err == nil {
+		t.Error("ParseRefreshToken should reject access token")
+	}
+}
+
+// TestUserEntity_RoleIds 测试角色ID解析与设置
+// TestLockRefreshFamily 家族锁被占用时返回 ErrRefreshTokenBusy（需要 redis）
+func TestLockRefreshFamily(t *testing.T) {
+	if err := redis.Ping(context.Background()); err != nil {
+		t.Skipf("redis unavailable: %v", err)
+	}
+	familyId := fmt.Sprintf("test_lock_%d", time.Now().UnixNano())
+
+	unlock, err := LockRefreshFamily(familyId)
+	if err != nil {
+		t.Fatalf("LockRefreshFamily failed: %v", err)
+	}
+
+	// 锁被占用时再次加锁
+	if _, err = LockRefreshFamily(familyId); err != ErrRefreshTokenBusy {
+		t.Errorf("Expected ErrRefreshTokenBusy while locked, got %v", err)
+	}
+
+	// 释放后可再次加锁
+	unlock()
+	unlock, err = LockRefreshFamily(familyId)
+	if err != nil {
+		t.Fatalf("LockRefreshFamily after unlock failed: %v", err)
+	}
+	unlock()
+}
+
+// TestRevokeUserTokensBefore 按毫秒比较签发时间：同一秒内吊销前签发的Token失效，吊销后签发的仍有效（需要 redis）
+func TestRevokeUserTokensBefore(t *testing.T) {
+	if err := redis.Ping(context.Background()); err != nil {
+		t.Skipf("redis unavailable: %v", err)
+	}
+	userEntity := &UserEntity{Username: "test_revoke"}
+	userEntity.Id = uint64(time.Now().UnixNano())
+
+	revokedAt := time.Now()
+	if err := RevokeUserTokensBefore(userEntity.Id, revokedAt); err != nil {
+		t.Fatalf("RevokeUserTokensBefore failed: %v", err)
+	}
+
+	// 吊销后签发的Token，签发时间保留毫秒
+	time.Sleep(2 * time.Millisecond)
+	pair, err := userEntity.GenerateToken()
+	if err != nil {
+		t.Fatalf("GenerateToken failed: %v", err)
+	}
+	claims, err := ParseToken(pair.AccessToken)
+	if err != nil {
+		t.Fatalf("ParseToken failed: %v", err)
+	}
+	if claims.IssuedAt.UnixMilli() <= revokedAt.UnixMilli() {
+		t.Fatalf("IssuedAt should keep millisecond precision, got %v", claims.IssuedAt.Time)
+	}
+	if revoked, err := IsTokenRevoked(claims); err != nil || revoked {
+		t.Errorf("Token issued after revocation should be valid, revoked=%v err=%v", revoked, err)
+	}
+
+	// 与吊销同一秒、但在吊销之前签发的Token
+	second := revokedAt.Truncate(time.Second)
+	if err := RevokeUserTokensBefore(userEntity.Id, second.Add(500*time.Millisecond)); err != nil {
+		t.Fatalf("RevokeUserTokensBefore failed: %v", err)
+	}
+	claims.IssuedAt = jwt.NewNumericDate(second.Add(400 * time.Millisecond))
+	if revoked, err := IsTokenRevoked(claims); err != nil || !revoked {
+		t.Errorf("Token issued earlier in the same second should be revoked, revoked=%v err=%v", revoked, err)
+	}
+	claims.IssuedAt = jwt.NewNumericDate(second.Add(600 * time.Millisecond))
+	if revoked, err := IsTokenRevoked(claims); err != nil || revoked {
+		t.Errorf("Token issued later in the same second should be valid, revoked=%v err=%v", revoked, err)
+	}
+}
+
+// TestRevokeOtherSessions 下线其他会话后，保留的会话仍有效，其余会话的Access Token失效（需要 redis）
+func TestRevokeOtherSessions(t *testing.T) {
+	if err := redis.Ping(context.Background()); err != nil {
+		t.Skipf("redis unavailable: %v", err)
+	}
+	userEntity := &UserEntity{Username: "test_revoke_others"}
+	userEntity.Id = uint64(time.Now().UnixNano())
+
+	claims := make([]*UserClaims, 0, 2)
+	for range 2 {
+		pair, err := userEntity.GenerateToken()
+		if err != nil {
+			t.Fatalf("GenerateToken failed: %v", err)
+		}
+		if err := SaveRefreshFamily(userEntity.Id, pair); err != nil {
+			t.Fatalf("SaveRefreshFamily failed: %v", err)
+		}
+		c, err := ParseToken(pair.AccessToken)
+		if err != nil {
+			t.Fatalf("ParseToken failed: %v", err)
+		}
+		claims = append(claims, c)
+	}
+	current, other := claims[0], claims[1]
+
+	revoked, err := RevokeOtherSessions(userEntity.Id, current.FamilyID)
+	if err != nil {
+		t.Fatalf("RevokeOtherSessions failed: %v", err)
+	}
+	if len(revoked) != 1 || revoked[0] != other.FamilyID {
+		t.Errorf("expected only family %s revoked, got %v", other.FamilyID, revoked)
+	}
+	if ok, err := IsTokenRevoked(current); err != nil || ok {
+		t.Errorf("current session should stay valid, revoked=%v err=%v", ok, err)
+	}
+	if ok, err := IsTokenRevoked(other); err != nil || !ok {
+		t.Errorf("other session should be revoked, revoked=%v err=%v", ok, err)
+	}
+}
+
+func TestUserEntity_RoleIds(t *testing.T) {
+	userEntity := &UserEntity{RoleIds: "1, 2,abc,,3"}
+	ids := userEntity.RoleIdList()
+	if len(ids) != 3 || ids[0] != 1 || ids[1] != 2 || ids[2] != 3 {
+		t.Errorf("RoleIdList mismatch: %v", ids)
+	}
+
+	userEntity.SetRoleIds([]uint64{3, 0, 1, 3})
+	if userEntity.RoleIds != "3,1" {
+		t.Errorf("SetRoleIds mismatch: expected '3,1', got '%s'", userEntity.RoleIds)
+	}
+}
+
+// TestLoginBackoff 测试登录失败指数退避
+func TestLoginBackoff(t *testing.T) {
+	cases := []struct{ failures, base, max, expected int }{
+		{0, 1, 60, 0},
+		{1, 1, 60, 1},
+		{2, 1, 60, 2},
+		{4, 1, 60, 8},
+		{10, 1, 60, 60},
+		{3, 0, 60, 0},
+		{3, 5, 12, 12},
+	}
+	for _, c := range cases {
+		if got := LoginBackoff(c.failures, c.base, c.max); got != c.expected {
+			t.Errorf("LoginBackoff(%d, %d, %d) = %d, expected %d", c.failures, c.base, c.max, got, c.expected)
+		}
+	}
+}
+
+// TestRecoveryCodes 测试恢复码生成与一次性使用
+func TestRecoveryCodes(t *testing.T) {
+	userEntity := &UserEntity{}
+	codes, err := userEntity.RegenerateRecoveryCodes()
+	if err != nil {
+		t.Fatalf("RegenerateRecoveryCodes failed: %v", err)
+	}
+	if len(codes) != recoveryCodeCount || userEntity.RecoveryCodesLeft() != recoveryCodeCount {
+		t.Fatalf("expected %d recovery codes, got %d", recoveryCodeCount, len(codes))
+	}
+	if strings.Contains(userEntity.RecoveryCodes, codes[0]) {
+		t.Error("recovery codes should be stored as digests")
+	}
+
+	// 忽略大小写与分隔符，且只能使用一次
+	if !userEntity.UseRecoveryCode(" " + strings.ToUpper(strings.ReplaceAll(codes[0], "-", "")) + " ") {
+		t.Error("UseRecoveryCode should accept normalized code")
+	}
+	if userEntity.UseRecoveryCode(codes[0]) {
+		t.Error("recovery code should be single use")
+	}
+	if userEntity.RecoveryCodesLeft() != recoveryCodeCount-1 {
+		t.Errorf("expected %d codes left, got %d", recoveryCodeCount-1, userEntity.RecoveryCodesLeft())
+	}
+
+	// 重新生成后旧恢复码失效
+	if _, err = userEntity.RegenerateRecoveryCodes(); err != nil {
+		t.Fatalf("RegenerateRecoveryCodes failed: %v", err)
+	}
+	if userEntity.UseRecoveryCode(codes[1]) {
+		t.Error("old recovery code should be invalid after regenerate")
+	}
+
+	userEntity.DisableTotp()
+	if userEntity.RecoveryCodesLeft() != 0 || userEntity.TotpSecret != "" {
+		t.Error("DisableTotp should clear secret and recovery codes")
+	}
+}
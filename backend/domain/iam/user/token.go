@@ -0,0 +1,265 @@
+package user
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"thinkingModels/component/errs"
+	"thinkingModels/component/redis"
+)
+
+// ========== 刷新令牌家族（Refresh Token Rotation）==========
+// 每次登录开启一个家族，家族内只有最近一次签发的 Refresh Token 有效；
+// 已被轮换掉的 Refresh Token 再次出现时视为泄露，整个家族立即作废。
+
+const (
+	refreshFamilyKey  = "refresh_family:%s"   // 家族信息(hash): userId、当前有效的jti
+	userFamiliesKey   = "refresh_families:%d" // 用户名下的家族集合(set)
+	refreshFamilyLock = "refresh_family_lock:%s"
+)
+
+var (
+	ErrRefreshTokenReused  = errs.Unauthorized("refresh token已被使用，登录会话已撤销，请重新登录")
+	ErrRefreshTokenRevoked = errs.Unauthorized("登录会话已失效，请重新登录")
+	ErrRefreshTokenBusy    = errs.Conflict("token刷新中，请稍后重试")
+)
+
+// SaveRefreshFamily 记录家族当前有效的Refresh Token
+func SaveRefreshFamily(userId uint64, pair *TokenPair) error {
+	expire := int(pair.RefreshExpiresIn)
+	key := fmt.Sprintf(refreshFamilyKey, pair.FamilyId)
+	values := map[string]interface{}{
+		"userId": strconv.FormatUint(userId, 10),
+		"jti":    pair.RefreshTokenId,
+	}
+	if err := redis.HMSet(key, values, expire); err != nil {
+		return err
+	}
+	return redis.SAdd(fmt.Sprintf(userFamiliesKey, userId), expire, pair.FamilyId)
+}
+
+// CheckRefreshFamily 校验Refresh Token是否为家族内当前有效的令牌
+// 家族不存在返回 ErrRefreshTokenRevoked；令牌已被轮换过则作废整个家族并返回 ErrRefreshTokenReused
+func CheckRefreshFamily(claims *RefreshClaims) error {
+	family, err := redis.HMGet(fmt.Sprintf(refreshFamilyKey, claims.FamilyID), "userId", "jti")
+	if err != nil {
+		return err
+	}
+	if family["jti"] == "" {
+		return ErrRefreshTokenRevoked
+	}
+	if family["userId"] != strconv.FormatUint(claims.UserID, 10) || family["jti"] != claims.ID {
+		if err := RevokeRefreshFamily(claims.UserID, claims.FamilyID); err != nil {
+			return err
+		}
+		return ErrRefreshTokenReused
+	}
+	return nil
+}
+
+// LockRefreshFamily 家族级互斥锁，防止并发刷新导致误判为重用
+func LockRefreshFamily(familyId string) (unlock func(), err error) {
+	key := fmt.Sprintf(refreshFamilyLock, familyId)
+	conn, ok, err := redis.GetLock(key, 5)
+	if err != nil || !ok {
+		conn.Close()
+		if err == nil {
+			err = ErrRefreshTokenBusy
+		}
+		return nil, err
+	}
+	return func() { redis.Unlock(conn, key) }, nil
+}
+
+// RevokeRefreshFamily 作废指定家族
+func RevokeRefreshFamily(userId uint64, familyId string) error {
+	if err := redis.Del(fmt.Sprintf(refreshFamilyKey, familyId)); err != nil {
+		return err
+	}
+	return redis.SRem(fmt.Sprintf(userFamiliesKey, userId), familyId)
+}
+
+// RevokeUserRefreshFamilies 作废用户名下的全部家族
+func RevokeUserRefreshFamilies(userId uint64) error {
+	setKey := fmt.Sprintf(userFamiliesKey, userId)
+	familyIds, err := redis.SMembers(setKey)
+	if err != nil {
+		return err
+	}
+	keys := make([]string, 0, len(familyIds)+1)
+	for _, familyId := range familyIds {
+		keys = append(keys, fmt.Sprintf(refreshFamilyKey, familyId))
+	}
+	keys = append(keys, setKey)
+	return redis.Del(keys...)
+}
+
+// ========== Access Token 吊销 ==========
+// 按时间点吊销时需与签发时间比较，JWT 时间字段精确到毫秒，避免与吊销同一秒内签发的Token无法区分
+
+func init() {
+	jwt.TimePrecision = time.Millisecond
+}
+
+const (
+	blacklistedTokenKey = "blacklisted_token:%s"     // 已登出的Access Token(jti)
+	tokensRevokedAtKey  = "tokens_revoked_before:%d" // 用户全部下线的时间点(unix毫秒)，此前（含）签发的Token失效
+	revokedFamilyKey    = "revoked_family:%s"        // 已下线的登录会话(家族)
+
+	legacyRevokedAtLimit = 1e12 // 小于该值的吊销时间点为旧版按秒记录
+)
+
+// RevokeAccessToken 吊销单个Access Token，过期时间为token剩余有效期
+func RevokeAccessToken(claims *UserClaims) error {
+	if claims.ExpiresAt == nil || claims.ID == "" {
+		return nil
+	}
+	ttl := int(time.Until(claims.ExpiresAt.Time).Seconds()) + 1
+	if ttl <= 0 {
+		return nil
+	}
+	return redis.SetEx(fmt.Sprintf(blacklistedTokenKey, claims.ID), "1", ttl)
+}
+
+// RevokeUserTokensBefore 吊销用户在指定时间点（含）之前签发的全部Token
+// Access Token 通过记录时间点拦截，Refresh Token 直接作废全部家族
+func RevokeUserTokensBefore(userId uint64, before time.Time) error {
+	key := fmt.Sprintf(tokensRevokedAtKey, userId)
+	value := strconv.FormatInt(before.UnixMilli(), 10)
+	// 超过Access Token有效期后旧token已自然过期，记录可随之清除
+	if err := redis.SetEx(key, value, int(accessTokenTTL.Seconds())); err != nil {
+		return err
+	}
+	return RevokeUserRefreshFamilies(userId)
+}
+
+// RevokeSession 下线单个登录会话：作废刷新令牌家族，并拦截该会话已签发、尚未过期的Access Token
+func RevokeSession(userId uint64, familyId string) error {
+	if err := RevokeRefreshFamily(userId, familyId); err != nil {
+		return err
+	}
+	return redis.SetEx(fmt.Sprintf(revokedFamilyKey, familyId), "1", int(accessTokenTTL.Seconds()))
+}
+
+// RevokeOtherSessions 下线用户除 keepFamilyId 外的全部登录会话，返回被下线的家族
+func RevokeOtherSessions(userId uint64, keepFamilyId string) ([]string, error) {
+	familyIds, err := redis.SMembers(fmt.Sprintf(userFamiliesKey, userId))
+	if err != nil {
+		return nil, err
+	}
+	revoked := make([]string, 0, len(familyIds))
+	for _, familyId := range familyIds {
+		if familyId == keepFamilyId {
+			continue
+		}
+		if err := RevokeSession(userId, familyId); err != nil {
+			return revoked, err
+		}
+		revoked = append(revoked, familyId)
+	}
+	return revoked, nil
+}
+
+// IsTokenRevoked 判断Access Token是否已被吊销
+func IsTokenRevoked(claims *UserClaims) (bool, error) {
+	values, err := redis.MGet(
+		fmt.Sprintf(blacklistedTokenKey, claims.ID),
+		fmt.Sprintf(tokensRevokedAtKey, claims.UserID),
+		fmt.Sprintf(revokedFamilyKey, claims.FamilyID),
+	)
+	if err != nil {
+		return false, err
+	}
+	if values[0] != "" || (claims.FamilyID != "" && values[2] != "") {
+		return true, nil
+	}
+	if values[1] != "" && claims.IssuedAt != nil {
+		revokedAt, err := strconv.ParseInt(values[1], 10, 64)
+		if err == nil && revokedAt < legacyRevokedAtLimit {
+			// 升级前按秒记录的时间点，该秒内签发的Token同样失效
+			revokedAt = revokedAt*1000 + 999
+		}
+		if err == nil && claims.IssuedAt.UnixMilli() <= revokedAt {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// ========== 密码重置 ==========
+// 重置令牌一次性有效，Redis 中只保存令牌摘要；同一用户重新申请时旧令牌失效
+
+const (
+	passwordResetKey     = "password_reset:%s"      // 令牌摘要 -> 用户ID
+	passwordResetUserKey = "password_reset_user:%d" // 用户当前有效的令牌摘要
+	passwordResetTTL     = 30 * time.Minute         // 重置令牌有效期
+)
+
+var ErrPasswordResetInvalid = errs.Validation("重置链接无效或已过期")
+
+// IssuePasswordReset 为用户签发密码重置令牌，返回令牌原文（仅用于投递给用户）
+func IssuePasswordReset(userId uint64) (token string, expire time.Duration, err error) {
+	token, err = issueOnceToken(passwordResetKey, passwordResetUserKey, userId, passwordResetTTL)
+	return token, passwordResetTTL, err
+}
+
+// ConsumePasswordReset 校验并消费密码重置令牌，返回对应用户ID
+func ConsumePasswordReset(token string) (uint64, error) {
+	userId, err := consumeOnceToken(passwordResetKey, passwordResetUserKey, token)
+	if err == nil && userId == 0 {
+		err = ErrPasswordResetInvalid
+	}
+	return userId, err
+}
+
+// issueOnceToken 签发一次性令牌：Redis 中保存 摘要->用户ID 与 用户->当前摘要，并作废该用户之前的令牌
+func issueOnceToken(tokenKey, userKey string, userId uint64, ttl time.Duration) (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	token := hex.EncodeToString(b)
+	digest := hashToken(token)
+
+	userKey = fmt.Sprintf(userKey, userId)
+	if old, err := redis.GetDel(userKey); err == nil && old != "" {
+		_ = redis.Del(fmt.Sprintf(tokenKey, old))
+	}
+
+	seconds := int(ttl.Seconds())
+	if err := redis.SetEx(fmt.Sprintf(tokenKey, digest), strconv.FormatUint(userId, 10), seconds); err != nil {
+		return "", err
+	}
+	if err := redis.SetEx(userKey, digest, seconds); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// consumeOnceToken 校验并消费一次性令牌，令牌无效时返回用户ID 0
+func consumeOnceToken(tokenKey, userKey, token string) (uint64, error) {
+	if token == "" {
+		return 0, nil
+	}
+	value, err := redis.GetDel(fmt.Sprintf(tokenKey, hashToken(token)))
+	if err != nil {
+		return 0, err
+	}
+	userId, err := strconv.ParseUint(value, 10, 64)
+	if err != nil || userId == 0 {
+		return 0, nil
+	}
+	_ = redis.Del(fmt.Sprintf(userKey, userId))
+	return userId, nil
+}
+
+// hashToken 一次性令牌摘要（Redis中只保存摘要）
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,205 @@
+package user
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"thinkingModels/component/errs"
+	"thinkingModels/component/redis"
+	"thinkingModels/component/totp"
+)
+
+// ========== 两步验证（TOTP）==========
+// 启用流程：EnrollTotp 生成密钥 -> 用户在验证器App中添加 -> ConfirmTotp 校验首个验证码后启用并下发恢复码。
+// 启用后密码登录只返回挑战令牌，提交验证码（或恢复码）通过后才签发正式Token。
+
+const (
+	recoveryCodeCount = 10 // 恢复码数量
+
+	totpUsedKey               = "totp_used:%d:%d"         // 已使用的时间步，防止验证码重放
+	loginChallengeKey         = "login_challenge:%s"      // 挑战令牌摘要 -> 用户ID
+	loginChallengeFailKey     = "login_challenge_fail:%s" // 挑战令牌验证失败次数
+	loginChallengeTTL         = 5 * time.Minute           // 挑战令牌有效期
+	loginChallengeMaxFailures = 5                         // 挑战令牌允许的验证失败次数
+)
+
+var (
+	ErrTotpAlreadyEnabled    = errs.Conflict("两步验证已启用")
+	ErrTotpNotEnrolled       = errs.Validation("请先生成两步验证密钥")
+	ErrTotpNotEnabled        = errs.Conflict("未启用两步验证")
+	ErrTotpCodeInvalid       = errs.Validation("验证码错误")
+	ErrLoginChallengeInvalid = errs.Validation("登录验证已失效，请重新登录")
+)
+
+var recoveryEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// EnrollTotp 生成新的TOTP密钥（未启用前可重复生成），返回密钥与 otpauth 地址
+func (u *UserEntity) EnrollTotp() (secret, uri string, err error) {
+	if u.TotpEnabled {
+		return "", "", ErrTotpAlreadyEnabled
+	}
+	secret, err = totp.GenerateSecret()
+	if err != nil {
+		return "", "", err
+	}
+	u.TotpSecret = secret
+	return secret, totp.URI(secret, jwtIssuer, u.Username), nil
+}
+
+// ConfirmTotp 校验首个验证码并启用两步验证，返回恢复码原文（仅此一次）
+func (u *UserEntity) ConfirmTotp(code string) ([]string, error) {
+	if u.TotpEnabled {
+		return nil, ErrTotpAlreadyEnabled
+	}
+	if u.TotpSecret == "" {
+		return nil, ErrTotpNotEnrolled
+	}
+	if !u.VerifyTotp(code) {
+		return nil, ErrTotpCodeInvalid
+	}
+	u.TotpEnabled = true
+	return u.RegenerateRecoveryCodes()
+}
+
+// VerifyTotp 校验验证码，同一时间步的验证码只能使用一次（Redis 异常时不做重放校验）
+func (u *UserEntity) VerifyTotp(code string) bool {
+	if u.TotpSecret == "" {
+		return false
+	}
+	step, ok := totp.Validate(u.TotpSecret, code, time.Now())
+	if !ok {
+		return false
+	}
+	fresh, err := redis.SetNx(fmt.Sprintf(totpUsedKey, u.Id, step), "1", totp.Period*(2*totp.Skew+1))
+	return err != nil || fresh
+}
+
+// UseRecoveryCode 使用恢复码（一次性，使用后从列表移除，需调用方保存实体）
+func (u *UserEntity) UseRecoveryCode(code string) bool {
+	digest := hashToken(normalizeRecoveryCode(code))
+	codes := u.recoveryCodeList()
+	index := slices.Index(codes, digest)
+	if index < 0 {
+		return false
+	}
+	u.RecoveryCodes = strings.Join(slices.Delete(codes, index, index+1), ",")
+	return true
+}
+
+// RegenerateRecoveryCodes 重新生成恢复码，旧恢复码全部失效，返回恢复码原文
+func (u *UserEntity) RegenerateRecoveryCodes() ([]string, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	digests := make([]string, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		b := make([]byte, 7)
+		if _, err := rand.Read(b); err != nil {
+			return nil, err
+		}
+		raw := strings.ToLower(recoveryEncoding.EncodeToString(b))[:10]
+		codes = append(codes, raw[:5]+"-"+raw[5:])
+		digests = append(digests, hashToken(raw))
+	}
+	u.RecoveryCodes = strings.Join(digests, ",")
+	return codes, nil
+}
+
+// DisableTotp 关闭两步验证并清除密钥与恢复码
+func (u *UserEntity) DisableTotp() {
+	u.TotpEnabled = false
+	u.TotpSecret = ""
+	u.RecoveryCodes = ""
+}
+
+// RecoveryCodesLeft 剩余可用恢复码数量
+func (u *UserEntity) RecoveryCodesLeft() int {
+	return len(u.recoveryCodeList())
+}
+
+// recoveryCodeList 恢复码摘要列表
+func (u *UserEntity) recoveryCodeList() []string {
+	if u.RecoveryCodes == "" {
+		return []string{}
+	}
+	return strings.Split(u.RecoveryCodes, ",")
+}
+
+// normalizeRecoveryCode 忽略大小写与分隔符
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	return strings.ReplaceAll(code, "-", "")
+}
+
+// ========== 登录挑战 ==========
+
+// IssueLoginChallenge 密码校验通过后签发两步验证挑战令牌
+func IssueLoginChallenge(userId uint64) (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	token := hex.EncodeToString(b)
+	ttl := int(loginChallengeTTL.Seconds())
+	err := redis.SetEx(fmt.Sprintf(loginChallengeKey, hashToken(token)), strconv.FormatUint(userId, 10), ttl)
+	if err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// LoginChallengeUser 查询挑战令牌对应的用户ID
+func LoginChallengeUser(token string) (uint64, error) {
+	if token == "" {
+		return 0, ErrLoginChallengeInvalid
+	}
+	values, err := redis.MGet(fmt.Sprintf(loginChallengeKey, hashToken(token)))
+	if err != nil {
+		return 0, err
+	}
+	userId, err := strconv.ParseUint(values[0], 10, 64)
+	if err != nil || userId == 0 {
+		return 0, ErrLoginChallengeInvalid
+	}
+	return userId, nil
+}
+
+// RecordLoginChallengeFailure 记录一次验证失败
+// 失败同时计入该用户名的登录失败次数（重新登录不会清除），达到上限后锁定账号并作废挑战令牌；
+// 单个挑战令牌超过次数或无法计数时同样作废，需重新登录
+func RecordLoginChallengeFailure(token, username, ip string) error {
+	digest := hashToken(token)
+	count, err := redis.Incr(fmt.Sprintf(loginChallengeFailKey, digest), int(loginChallengeTTL.Seconds()))
+	if err != nil {
+		FinishLoginChallenge(token)
+		return ErrLoginChallengeInvalid
+	}
+
+	remaining := loginChallengeMaxFailures - count
+	var failed *LoginFailedError
+	if errors.As(RecordLoginFailure(username, ip), &failed) {
+		if failed.limited {
+			FinishLoginChallenge(token)
+			return failed
+		}
+		if failed.RemainingAttempts >= 0 && failed.RemainingAttempts < remaining {
+			remaining = failed.RemainingAttempts
+		}
+	}
+	if remaining <= 0 {
+		FinishLoginChallenge(token)
+		return ErrLoginChallengeInvalid
+	}
+	return errs.Validation(fmt.Sprintf("验证码错误，还可尝试%d次", remaining))
+}
+
+// FinishLoginChallenge 作废挑战令牌
+func FinishLoginChallenge(token string) {
+	digest := hashToken(token)
+	_ = redis.Del(fmt.Sprintf(loginChallengeKey, digest), fmt.Sprintf(loginChallengeFailKey, digest))
+}
@@ -1,21 +1,45 @@
 package user
 
+import (
+	"thinkingModels/component/captcha"
+	"thinkingModels/domain/practice/model"
+)
+
 // ==================== 请求DTO ====================
 
 // LoginRequest 登录请求
 type LoginRequest struct {
-	Username string `json:"username" binding:"required"` // 用户名
-	Password string `json:"password" binding:"required"` // 密码
-	Captcha  bool   `json:"captcha"`                     // 验证码（可选）
+	Username      string              `json:"username" binding:"required"` // 用户名
+	Password      string              `json:"password" binding:"required"` // 密码
+	CaptchaId     string              `json:"captchaId"`                   // 验证码ID（需要人机验证时必填）
+	CaptchaAnswer *captcha.Submission `json:"captchaAnswer"`               // 验证码答案（需要人机验证时必填）
 }
 
 // RegisterRequest 注册请求
 type RegisterRequest struct {
-	Username string `json:"username" binding:"required,min=3,max=20"`  // 用户名
-	Password string `json:"password" binding:"required,min=8"`         // 密码
-	Nickname string `json:"nickname" binding:"max=50"`                 // 昵称
-	Email    string `json:"email" binding:"omitempty,email"`           // 邮箱
-	Phone    string `json:"phone" binding:"omitempty,len=11,numeric"`  // 手机号
+	Username      string              `json:"username" binding:"required,min=3,max=20"` // 用户名
+	Password      string              `json:"password" binding:"required,min=8"`        // 密码
+	Nickname      string              `json:"nickname" binding:"max=50"`                // 昵称
+	Email         string              `json:"email" binding:"omitempty,email"`          // 邮箱（开启邮箱验证时必填）
+	Phone         string              `json:"phone" binding:"omitempty,len=11,numeric"` // 手机号
+	InviteCode    string              `json:"inviteCode" binding:"max=32"`              // 邀请码（仅限邀请注册时必填）
+	CaptchaId     string              `json:"captchaId"`                                // 验证码ID（注册需要人机验证时必填）
+	CaptchaAnswer *captcha.Submission `json:"captchaAnswer"`                            // 验证码答案
+}
+
+// CaptchaRequest 获取验证码请求
+type CaptchaRequest struct {
+	Kind string `form:"kind" binding:"omitempty,oneof=slider point"` // 验证码类型：slider=滑块拼图（默认），point=图形点选
+}
+
+// RefreshRequest 刷新Token请求
+type RefreshRequest struct {
+	RefreshToken string `json:"refreshToken" binding:"required"` // Refresh Token
+}
+
+// LogoutRequest 登出请求
+type LogoutRequest struct {
+	RefreshToken string `json:"refreshToken"` // Refresh Token（可选，传入时一并作废当前登录会话）
 }
 
 // UpdateUserRequest 更新用户信息请求
@@ -25,6 +49,7 @@ type UpdateUserRequest struct {
 	Email    string `json:"email" binding:"omitempty,email"`
 	Phone    string `json:"phone" binding:"omitempty,len=11,numeric"`
 	Avatar   string `json:"avatar" binding:"max=255"`
+	Bio      string `json:"bio" binding:"max=500"` // 个人简介（公开展示）
 }
 
 // UpdatePasswordRequest 修改密码请求
@@ -33,14 +58,116 @@ type UpdatePasswordRequest struct {
 	NewPassword string `json:"newPassword" binding:"required,min=8"`
 }
 
+// ForgotPasswordRequest 忘记密码请求
+type ForgotPasswordRequest struct {
+	Email string `json:"email" binding:"required,email"` // 注册邮箱
+}
+
+// ResetPasswordRequest 重置密码请求
+type ResetPasswordRequest struct {
+	Token       string `json:"token" binding:"required"`           // 重置令牌
+	NewPassword string `json:"newPassword" binding:"required,min=8"` // 新密码
+}
+
+// VerifyEmailRequest 验证邮箱请求
+type VerifyEmailRequest struct {
+	Token string `json:"token" binding:"required"` // 邮件中的验证令牌
+}
+
+// ResendVerificationRequest 重新发送验证邮件请求
+type ResendVerificationRequest struct {
+	Email string `json:"email" binding:"required,email"` // 注册邮箱
+}
+
+// UnlockLoginRequest 解除登录锁定请求（用户名与IP至少填一个）
+type UnlockLoginRequest struct {
+	Username string `json:"username"` // 用户名
+	Ip       string `json:"ip"`       // IP地址
+}
+
+// VerifyTwoFactorRequest 两步验证登录请求
+type VerifyTwoFactorRequest struct {
+	ChallengeToken string `json:"challengeToken" binding:"required"` // 登录返回的挑战令牌
+	Code           string `json:"code" binding:"required"`           // 6位验证码或恢复码
+}
+
+// TotpCodeRequest 两步验证码请求
+type TotpCodeRequest struct {
+	Code string `json:"code" binding:"required"` // 6位验证码
+}
+
+// DisableTotpRequest 关闭两步验证请求
+type DisableTotpRequest struct {
+	Password string `json:"password" binding:"required"` // 登录密码
+	Code     string `json:"code" binding:"required"`     // 6位验证码或恢复码
+}
+
+// ProfileRequest 用户主页请求（已发布模型分页）
+type ProfileRequest struct {
+	Page     int64 `form:"page"`                                // 页码，默认1
+	PageSize int64 `form:"pageSize" binding:"omitempty,max=50"` // 每页数量，默认10
+}
+
+// AssignUserRoles 为用户分配角色请求（全量覆盖）
+type AssignUserRoles struct {
+	UserId  uint64   `json:"userId" binding:"required"`
+	RoleIds []uint64 `json:"roleIds"`
+}
+
 // ==================== 响应DTO ====================
 
 // LoginResponse 登录响应
+// 已启用两步验证时只返回 twoFactorRequired 与 challengeToken，需调用 /auth/2fa/verify 完成登录
 type LoginResponse struct {
-	AccessToken  string   `json:"accessToken"`  // Access Token
-	RefreshToken string   `json:"refreshToken"` // Refresh Token
-	ExpiresIn    int64    `json:"expiresIn"`    // 过期时间（秒）
-	UserInfo     UserInfo `json:"userInfo"`     // 用户信息
+	AccessToken       string   `json:"accessToken"`                 // Access Token
+	RefreshToken      string   `json:"refreshToken"`                // Refresh Token
+	ExpiresIn         int64    `json:"expiresIn"`                   // 过期时间（秒）
+	UserInfo          UserInfo `json:"userInfo"`                    // 用户信息
+	TwoFactorRequired bool     `json:"twoFactorRequired,omitempty"` // 是否需要两步验证
+	ChallengeToken    string   `json:"challengeToken,omitempty"`    // 两步验证挑战令牌
+}
+
+// CaptchaResponse 验证码挑战（答案只保存在服务端）
+type CaptchaResponse struct {
+	CaptchaId string `json:"captchaId"` // 验证码ID，提交答案时回传
+	ExpiresIn int    `json:"expiresIn"` // 有效期（秒）
+	*captcha.Challenge
+}
+
+// RegisterResponse 注册响应
+type RegisterResponse struct {
+	UserInfo
+	VerificationRequired bool `json:"verificationRequired"` // 是否需要验证邮箱后才能登录
+}
+
+// CaptchaStatus 当前是否需要人机验证
+type CaptchaStatus struct {
+	Login    bool `json:"login"`    // 登录是否需要
+	Register bool `json:"register"` // 注册是否需要
+}
+
+// TotpStatus 两步验证状态
+type TotpStatus struct {
+	Enabled           bool `json:"enabled"`           // 是否已启用
+	RecoveryCodesLeft int  `json:"recoveryCodesLeft"` // 剩余恢复码数量
+}
+
+// TotpSetupResponse 生成两步验证密钥响应
+type TotpSetupResponse struct {
+	Secret string `json:"secret"` // Base32 密钥，可手动输入验证器App
+	Uri    string `json:"uri"`    // otpauth:// 地址，用于生成二维码
+}
+
+// RecoveryCodesResponse 恢复码响应（原文只返回一次）
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
+
+// RefreshResponse 刷新Token响应
+type RefreshResponse struct {
+	AccessToken  string `json:"accessToken"`  // Access Token
+	RefreshToken string `json:"refreshToken"` // 轮换后的新 Refresh Token，旧的立即失效
+	ExpiresIn    int64  `json:"expiresIn"`    // 过期时间（秒）
 }
 
 // UserInfo 用户信息DTO（脱敏，不含密码）
@@ -51,11 +178,28 @@ type UserInfo struct {
 	Email         string `json:"email"`
 	Phone         string `json:"phone"`
 	Avatar        string `json:"avatar"`
-	Status        int    `json:"status"`
+	Bio           string `json:"bio"`
+	Status        int    `json:"status"`        // 状态:0=禁用,1=正常,2=待验证邮箱
+	EmailVerified bool   `json:"emailVerified"` // 邮箱是否已验证
+	InvitedBy     uint64 `json:"invitedBy"`     // 邀请人用户ID
 	LastLoginTime string `json:"lastLoginTime"`
 	CreatedAt     string `json:"createdAt"`
 }
 
+// UserProfile 用户公开主页（只含公开资料，不含邮箱、手机号等隐私字段）
+type UserProfile struct {
+	ID             uint64                   `json:"id"`
+	Nickname       string                   `json:"nickname"`       // 昵称，未设置时为用户名
+	Avatar         string                   `json:"avatar"`
+	Bio            string                   `json:"bio"`            // 个人简介
+	FollowerCount  int64                    `json:"followerCount"`  // 粉丝数
+	FollowingCount int64                    `json:"followingCount"` // 关注数
+	Followed       bool                     `json:"followed"`       // 当前用户是否已关注
+	JoinedAt       string                   `json:"joinedAt"`       // 注册时间
+	Stats          model.AuthorStats        `json:"stats"`          // 已发布模型汇总数据
+	Models         *model.ListModelResponse `json:"models"`         // 已发布模型（分页）
+}
+
 // ==================== 搜索条件 ====================
 
 // SearchUser 用户搜索条件
@@ -67,6 +211,8 @@ type SearchUser struct {
 	Status     int    `json:"status" form:"status" search:"type:eq;column:status;table:users"`         // 状态
 	Email      string `json:"email" form:"email" search:"type:eq;column:email;table:users"`            // 邮箱
 	Phone      string `json:"phone" form:"phone" search:"type:eq;column:phone;table:users"`            // 手机号
+	InvitedBy  uint64 `json:"invitedBy" form:"invitedBy" search:"type:eq;column:invited_by;table:users"` // 邀请人
+	InviteCodeId uint64 `json:"inviteCodeId" form:"inviteCodeId" search:"type:eq;column:invite_code_id;table:users"` // 邀请码
 	CreatedAt  []string `json:"createdAt" form:"createdAt" search:"type:between;column:created_at;table:users"` // 创建时间范围
 }
 
@@ -0,0 +1,59 @@
+package user
+
+import (
+	"time"
+
+	"thinkingModels/component/errs"
+	"thinkingModels/config"
+)
+
+// ========== 注册邮箱验证 ==========
+// 开启邮箱验证时，自助注册的账号处于待验证状态，点击邮件中的一次性链接后激活。
+// 令牌的存储方式与密码重置一致：Redis 中只保存摘要，重新发送时旧令牌失效。
+
+const (
+	emailVerifyKey     = "email_verify:%s"      // 令牌摘要 -> 用户ID
+	emailVerifyUserKey = "email_verify_user:%d" // 用户当前有效的令牌摘要
+	emailVerifyTTL     = 24 * time.Hour         // 未配置时的默认有效期
+)
+
+var (
+	ErrEmailVerifyInvalid = errs.Validation("验证链接无效或已过期")
+	ErrEmailNotVerified   = errs.Forbidden("邮箱尚未验证，请先完成邮件中的验证")
+	ErrEmailRequired      = errs.Validation("请填写邮箱")
+	ErrEmailExists        = errs.Conflict("邮箱已被注册")
+)
+
+// EmailVerifyRequired 自助注册是否需要验证邮箱
+func EmailVerifyRequired() bool {
+	return config.Config.Register.EmailVerify
+}
+
+// InviteRequired 注册是否需要邀请码
+func InviteRequired() bool {
+	return config.Config.Register.InviteRequired
+}
+
+// verifyTTL 验证链接有效期
+func verifyTTL() time.Duration {
+	if ttl := config.Config.Register.VerifyTTL; ttl > 0 {
+		return time.Duration(ttl) * time.Second
+	}
+	return emailVerifyTTL
+}
+
+// IssueEmailVerification 为用户签发邮箱验证令牌，返回令牌原文（仅用于投递给用户）
+func IssueEmailVerification(userId uint64) (token string, expire time.Duration, err error) {
+	expire = verifyTTL()
+	token, err = issueOnceToken(emailVerifyKey, emailVerifyUserKey, userId, expire)
+	return token, expire, err
+}
+
+// ConsumeEmailVerification 校验并消费邮箱验证令牌，返回对应用户ID
+func ConsumeEmailVerification(token string) (uint64, error) {
+	userId, err := consumeOnceToken(emailVerifyKey, emailVerifyUserKey, token)
+	if err == nil && userId == 0 {
+		err = ErrEmailVerifyInvalid
+	}
+	return userId, err
+}
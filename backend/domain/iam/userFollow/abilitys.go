@@ -0,0 +1,34 @@
+package userFollow
+
+// UserFollowAbility 关注关系能力接口定义
+type UserFollowAbility interface {
+	// IsFollowing 是否已关注
+	IsFollowing(followerId, followeeId uint64) (bool, error)
+	// Unfollow 取消关注（物理删除，便于再次关注）
+	Unfollow(followerId, followeeId uint64) error
+	// CountFollowers 粉丝数
+	CountFollowers(userId uint64) (int64, error)
+	// CountFollowing 关注数
+	CountFollowing(userId uint64) (int64, error)
+}
+
+// IsFollowing 是否已关注
+func (m *UserFollowEntity) IsFollowing(followerId, followeeId uint64) (bool, error) {
+	count, err := m.Count(m.MakeConditon(SearchUserFollow{FollowerId: followerId, FolloweeId: followeeId}))
+	return count > 0, err
+}
+
+// Unfollow 取消关注（物理删除，便于再次关注）
+func (m *UserFollowEntity) Unfollow(followerId, followeeId uint64) error {
+	return m.Tx().Exec("DELETE FROM user_follows WHERE follower_id = ? AND followee_id = ?", followerId, followeeId).Error
+}
+
+// CountFollowers 粉丝数
+func (m *UserFollowEntity) CountFollowers(userId uint64) (int64, error) {
+	return m.Count(m.MakeConditon(SearchUserFollow{FolloweeId: userId}))
+}
+
+// CountFollowing 关注数
+func (m *UserFollowEntity) CountFollowing(userId uint64) (int64, error) {
+	return m.Count(m.MakeConditon(SearchUserFollow{FollowerId: userId}))
+}
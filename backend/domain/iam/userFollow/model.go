@@ -0,0 +1,61 @@
+package userFollow
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/jianyuezhexue/base"
+	"thinkingModels/component/db"
+	"thinkingModels/component/errs"
+)
+
+// UserFollowEntityInterface 关注关系实体接口
+type UserFollowEntityInterface interface {
+	base.BaseModelInterface[UserFollowEntity]
+	UserFollowAbility
+}
+
+// UserFollowEntity 关注关系实体：follower 关注 followee
+type UserFollowEntity struct {
+	base.BaseModel[UserFollowEntity]
+	FollowerId uint64 `json:"followerId" type:"db" comment:"关注者用户ID"`
+	FolloweeId uint64 `json:"followeeId" type:"db" comment:"被关注者用户ID"`
+}
+
+// NewUserFollowEntity 实例化关注关系实体
+func NewUserFollowEntity(ctx *gin.Context, opt ...base.Option[UserFollowEntity]) UserFollowEntityInterface {
+	entity := &UserFollowEntity{}
+	entity.BaseModel = base.NewBaseModel(ctx, db.InitDb(), entity.TableName(), entity)
+
+	// 自定义配置选项
+	if len(opt) > 0 {
+		for _, fc := range opt {
+			fc(&entity.BaseModel)
+		}
+	}
+	return entity
+}
+
+// TableName 数据表名
+func (m *UserFollowEntity) TableName() string {
+	return "user_follows"
+}
+
+// Validate 数据校验
+func (m *UserFollowEntity) Validate() error {
+	if m.FollowerId == 0 || m.FolloweeId == 0 {
+		return errs.Validation("用户ID不能为空")
+	}
+	if m.FollowerId == m.FolloweeId {
+		return errs.Validation("不能关注自己")
+	}
+	return nil
+}
+
+// Repair 数据修复
+func (m *UserFollowEntity) Repair() error {
+	return nil
+}
+
+// Complete 数据完善
+func (m *UserFollowEntity) Complete() error {
+	return nil
+}
@@ -0,0 +1,13 @@
+package userFollow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserFollowEntity_Validate(t *testing.T) {
+	assert.NotNil(t, (&UserFollowEntity{FollowerId: 1}).Validate())
+	assert.NotNil(t, (&UserFollowEntity{FollowerId: 1, FolloweeId: 1}).Validate()) // 不能关注自己
+	assert.Nil(t, (&UserFollowEntity{FollowerId: 1, FolloweeId: 2}).Validate())
+}
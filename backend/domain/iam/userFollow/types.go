@@ -0,0 +1,14 @@
+package userFollow
+
+// ==================== 请求DTO ====================
+
+// FollowRequest 关注/取消关注请求
+type FollowRequest struct {
+	UserId uint64 `json:"userId" binding:"required"` // 被关注的用户ID
+}
+
+// SearchUserFollow 关注关系搜索条件
+type SearchUserFollow struct {
+	FollowerId uint64 `json:"-" form:"-" search:"type:eq;column:follower_id;table:user_follows"` // 关注者
+	FolloweeId uint64 `json:"-" form:"-" search:"type:eq;column:followee_id;table:user_follows"` // 被关注者
+}
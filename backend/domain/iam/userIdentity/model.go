@@ -0,0 +1,62 @@
+package userIdentity
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/jianyuezhexue/base"
+	"thinkingModels/component/db"
+	"thinkingModels/component/errs"
+)
+
+// UserIdentityEntityInterface 第三方登录身份实体接口
+type UserIdentityEntityInterface interface {
+	base.BaseModelInterface[UserIdentityEntity]
+}
+
+// UserIdentityEntity 第三方登录身份实体：提供方用户(provider+subject)与本地用户的绑定关系
+type UserIdentityEntity struct {
+	base.BaseModel[UserIdentityEntity]
+	UserId   uint64 `json:"userId" type:"db" comment:"本地用户ID"`
+	Provider string `json:"provider" type:"db" comment:"身份提供方"`
+	Subject  string `json:"subject" type:"db" comment:"提供方用户标识(sub)"`
+	Email    string `json:"email" type:"db" comment:"绑定时提供方返回的邮箱"`
+}
+
+// NewUserIdentityEntity 实例化第三方登录身份实体
+func NewUserIdentityEntity(ctx *gin.Context, opt ...base.Option[UserIdentityEntity]) UserIdentityEntityInterface {
+	entity := &UserIdentityEntity{}
+	entity.BaseModel = base.NewBaseModel(ctx, db.InitDb(), entity.TableName(), entity)
+
+	// 自定义配置选项
+	if len(opt) > 0 {
+		for _, fc := range opt {
+			fc(&entity.BaseModel)
+		}
+	}
+	return entity
+}
+
+// TableName 数据表名
+func (m *UserIdentityEntity) TableName() string {
+	return "user_identities"
+}
+
+// Validate 数据校验
+func (m *UserIdentityEntity) Validate() error {
+	if m.UserId == 0 {
+		return errs.Validation("用户ID不能为空")
+	}
+	if m.Provider == "" || m.Subject == "" {
+		return errs.Validation("身份提供方和用户标识不能为空")
+	}
+	return nil
+}
+
+// Repair 数据修复
+func (m *UserIdentityEntity) Repair() error {
+	return nil
+}
+
+// Complete 数据完善
+func (m *UserIdentityEntity) Complete() error {
+	return nil
+}
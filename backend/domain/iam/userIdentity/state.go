@@ -0,0 +1,57 @@
+package userIdentity
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"thinkingModels/component/errs"
+	"thinkingModels/component/redis"
+	"thinkingModels/config"
+)
+
+// ========== 授权流程状态 ==========
+// 发起授权时生成 state，并把 PKCE code_verifier、nonce 存入Redis；
+// 回调时按 state 一次性取出，防止CSRF与授权码重放。
+
+const oauthStateKey = "oauth_state:%s"
+
+var ErrOauthStateInvalid = errs.Validation("登录请求已失效，请重新发起")
+
+// OauthState 授权流程状态
+type OauthState struct {
+	Provider string `json:"provider"`
+	Verifier string `json:"verifier"` // PKCE code_verifier
+	Nonce    string `json:"nonce"`
+}
+
+// SaveOauthState 保存授权流程状态
+func SaveOauthState(state string, s *OauthState) error {
+	ttl := config.Config.Oauth2.StateTTL
+	if ttl <= 0 {
+		ttl = 600
+	}
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	return redis.SetEx(fmt.Sprintf(oauthStateKey, state), string(data), ttl)
+}
+
+// ConsumeOauthState 取出并作废授权流程状态
+func ConsumeOauthState(state string) (*OauthState, error) {
+	if state == "" {
+		return nil, ErrOauthStateInvalid
+	}
+	value, err := redis.GetDel(fmt.Sprintf(oauthStateKey, state))
+	if err != nil {
+		return nil, err
+	}
+	if value == "" {
+		return nil, ErrOauthStateInvalid
+	}
+	s := &OauthState{}
+	if err = json.Unmarshal([]byte(value), s); err != nil {
+		return nil, ErrOauthStateInvalid
+	}
+	return s, nil
+}
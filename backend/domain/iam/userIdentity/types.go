@@ -0,0 +1,33 @@
+package userIdentity
+
+// ==================== 请求DTO ====================
+
+// CreateUserIdentity 绑定第三方身份
+type CreateUserIdentity struct {
+	UserId   uint64 `json:"userId"`
+	Provider string `json:"provider"`
+	Subject  string `json:"subject"`
+	Email    string `json:"email"`
+}
+
+// SearchUserIdentity 第三方身份搜索条件
+type SearchUserIdentity struct {
+	UserId   uint64 `json:"userId" form:"userId" search:"type:eq;column:user_id;table:user_identities"`      // 本地用户ID
+	Provider string `json:"provider" form:"provider" search:"type:eq;column:provider;table:user_identities"` // 身份提供方
+	Subject  string `json:"subject" form:"subject" search:"type:eq;column:subject;table:user_identities"`    // 提供方用户标识
+}
+
+// OauthCallbackRequest 提供方回调参数
+type OauthCallbackRequest struct {
+	Code             string `json:"code" form:"code"`                          // 授权码
+	State            string `json:"state" form:"state" binding:"required"`     // 授权流程标识
+	Error            string `json:"error" form:"error"`                        // 提供方返回的错误码（用户拒绝授权等）
+	ErrorDescription string `json:"errorDescription" form:"error_description"` // 错误描述
+}
+
+// ==================== 响应DTO ====================
+
+// OauthProviders 可用的第三方登录方式
+type OauthProviders struct {
+	Providers []string `json:"providers"`
+}
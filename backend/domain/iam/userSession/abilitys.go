@@ -0,0 +1,70 @@
+package userSession
+
+import (
+	"time"
+)
+
+// UserSessionAbility 登录会话能力接口定义
+type UserSessionAbility interface {
+	// LoadByFamilyId 按刷新令牌家族查找会话
+	LoadByFamilyId(familyId string) (*UserSessionEntity, error)
+	// ListActive 用户当前有效的会话（按最后活跃时间倒序）
+	ListActive(userId uint64) ([]*UserSessionEntity, error)
+	// Touch 更新最后活跃时间与IP
+	Touch(familyId, ip string) error
+	// Extend 刷新令牌轮换后延长会话有效期
+	Extend(familyId string, expiresAt time.Time) error
+	// Offline 标记会话下线
+	Offline(familyIds ...string) error
+	// OfflineByUser 标记用户全部会话下线，keepFamilyIds 中的会话保留
+	OfflineByUser(userId uint64, keepFamilyIds ...string) error
+}
+
+// LoadByFamilyId 按刷新令牌家族查找会话
+func (m *UserSessionEntity) LoadByFamilyId(familyId string) (*UserSessionEntity, error) {
+	cond := m.MakeConditon(SearchUserSession{FamilyId: familyId})
+	return m.LoadData(cond)
+}
+
+// ListActive 用户当前有效的会话（按最后活跃时间倒序）
+func (m *UserSessionEntity) ListActive(userId uint64) ([]*UserSessionEntity, error) {
+	list := make([]*UserSessionEntity, 0)
+	err := m.Tx().Table(m.TableName()).
+		Where("user_id = ? AND status = 1 AND expires_at > ? AND deleted_at IS NULL", userId, time.Now()).
+		Order("last_seen_at DESC, id DESC").
+		Find(&list).Error
+	return list, err
+}
+
+// Touch 更新最后活跃时间与IP
+func (m *UserSessionEntity) Touch(familyId, ip string) error {
+	return m.Tx().Table(m.TableName()).
+		Where("family_id = ? AND status = 1", familyId).
+		UpdateColumns(map[string]any{"last_seen_at": time.Now(), "last_seen_ip": ip}).Error
+}
+
+// Extend 刷新令牌轮换后延长会话有效期
+func (m *UserSessionEntity) Extend(familyId string, expiresAt time.Time) error {
+	return m.Tx().Table(m.TableName()).
+		Where("family_id = ? AND status = 1", familyId).
+		UpdateColumns(map[string]any{"expires_at": expiresAt, "last_seen_at": time.Now()}).Error
+}
+
+// Offline 标记会话下线
+func (m *UserSessionEntity) Offline(familyIds ...string) error {
+	if len(familyIds) == 0 {
+		return nil
+	}
+	return m.Tx().Table(m.TableName()).
+		Where("family_id IN ? AND status = 1", familyIds).
+		UpdateColumn("status", 0).Error
+}
+
+// OfflineByUser 标记用户全部会话下线，keepFamilyIds 中的会话保留
+func (m *UserSessionEntity) OfflineByUser(userId uint64, keepFamilyIds ...string) error {
+	query := m.Tx().Table(m.TableName()).Where("user_id = ? AND status = 1", userId)
+	if len(keepFamilyIds) > 0 {
+		query = query.Where("family_id NOT IN ?", keepFamilyIds)
+	}
+	return query.UpdateColumn("status", 0).Error
+}
@@ -0,0 +1,75 @@
+package userSession
+
+import (
+	"fmt"
+	"strings"
+
+	"thinkingModels/component/redis"
+)
+
+// ========== 设备识别与活跃节流 ==========
+
+const (
+	lastSeenKey      = "session_seen:%s" // 会话最近一次写入活跃时间的标记
+	lastSeenInterval = 60                // 活跃时间最小写入间隔(秒)
+)
+
+// ShouldTouch 是否需要写入会话活跃时间（每个会话每分钟最多一次，Redis异常时跳过）
+func ShouldTouch(familyId string) bool {
+	if familyId == "" {
+		return false
+	}
+	ok, err := redis.SetNx(fmt.Sprintf(lastSeenKey, familyId), "1", lastSeenInterval)
+	return err == nil && ok
+}
+
+// browsers 浏览器识别规则，按顺序匹配（Edge、Opera 等的UA同时包含 Chrome，需放在前面）
+var browsers = []struct{ Token, Name string }{
+	{"MicroMessenger", "微信"},
+	{"Edg/", "Edge"},
+	{"OPR/", "Opera"},
+	{"Firefox/", "Firefox"},
+	{"Chrome/", "Chrome"},
+	{"Safari/", "Safari"},
+	{"curl/", "curl"},
+	{"python-requests", "Python"},
+	{"Go-http-client", "Go"},
+	{"PostmanRuntime", "Postman"},
+}
+
+// systems 操作系统识别规则（iPhone/iPad 的UA包含 Mac OS X，需放在前面）
+var systems = []struct{ Token, Name string }{
+	{"iPhone", "iOS"},
+	{"iPad", "iPadOS"},
+	{"Android", "Android"},
+	{"Windows", "Windows"},
+	{"Mac OS X", "macOS"},
+	{"Linux", "Linux"},
+}
+
+// ParseDevice 从 User-Agent 解析设备描述，如 "Chrome / Windows"
+func ParseDevice(userAgent string) string {
+	var browser, system string
+	for _, b := range browsers {
+		if strings.Contains(userAgent, b.Token) {
+			browser = b.Name
+			break
+		}
+	}
+	for _, s := range systems {
+		if strings.Contains(userAgent, s.Token) {
+			system = s.Name
+			break
+		}
+	}
+
+	switch {
+	case browser != "" && system != "":
+		return browser + " / " + system
+	case browser != "":
+		return browser
+	case system != "":
+		return system
+	}
+	return "未知设备"
+}
@@ -0,0 +1,75 @@
+package userSession
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jianyuezhexue/base"
+	"thinkingModels/component/db"
+	"thinkingModels/component/errs"
+)
+
+// UserSessionEntityInterface 登录会话实体接口
+type UserSessionEntityInterface interface {
+	base.BaseModelInterface[UserSessionEntity]
+	UserSessionAbility
+}
+
+// UserSessionEntity 登录会话实体：每次登录一条，与刷新令牌家族一一对应
+type UserSessionEntity struct {
+	base.BaseModel[UserSessionEntity]
+	UserId     uint64       `json:"userId" type:"db" comment:"用户ID"`
+	FamilyId   string       `json:"-" type:"db" comment:"刷新令牌家族ID"`
+	Device     string       `json:"device" type:"db" comment:"设备描述"`
+	UserAgent  string       `json:"userAgent" type:"db" comment:"User-Agent"`
+	Ip         string       `json:"ip" type:"db" comment:"登录IP"`
+	LastSeenAt db.LocalTime `json:"lastSeenAt" type:"db" comment:"最后活跃时间"`
+	LastSeenIp string       `json:"lastSeenIp" type:"db" comment:"最后活跃IP"`
+	ExpiresAt  db.LocalTime `json:"expiresAt" type:"db" comment:"会话过期时间(Refresh Token过期时间)"`
+	Status     int          `json:"status" type:"db" comment:"状态:0=已下线,1=在线"`
+}
+
+// NewUserSessionEntity 实例化登录会话实体
+func NewUserSessionEntity(ctx *gin.Context, opt ...base.Option[UserSessionEntity]) UserSessionEntityInterface {
+	entity := &UserSessionEntity{}
+	entity.BaseModel = base.NewBaseModel(ctx, db.InitDb(), entity.TableName(), entity)
+
+	// 自定义配置选项
+	if len(opt) > 0 {
+		for _, fc := range opt {
+			fc(&entity.BaseModel)
+		}
+	}
+	return entity
+}
+
+// TableName 数据表名
+func (m *UserSessionEntity) TableName() string {
+	return "user_sessions"
+}
+
+// Validate 数据校验
+func (m *UserSessionEntity) Validate() error {
+	if m.UserId == 0 || m.FamilyId == "" {
+		return errs.Validation("用户ID和会话标识不能为空")
+	}
+	return nil
+}
+
+// Repair 数据修复：截断超长的客户端信息
+func (m *UserSessionEntity) Repair() error {
+	if len(m.UserAgent) > 500 {
+		m.UserAgent = m.UserAgent[:500]
+	}
+	return nil
+}
+
+// Complete 数据完善
+func (m *UserSessionEntity) Complete() error {
+	return nil
+}
+
+// IsActive 会话是否有效
+func (m *UserSessionEntity) IsActive() bool {
+	return m.Status == 1 && time.Now().Before(time.Time(m.ExpiresAt))
+}
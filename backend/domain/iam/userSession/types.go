@@ -0,0 +1,30 @@
+package userSession
+
+// ==================== 请求DTO ====================
+
+// RevokeUserSession 下线指定会话请求
+type RevokeUserSession struct {
+	Id uint64 `json:"id" binding:"required"` // 会话ID
+}
+
+// SearchUserSession 登录会话搜索条件
+type SearchUserSession struct {
+	UserId   uint64 `json:"userId" form:"userId" search:"type:eq;column:user_id;table:user_sessions"` // 用户ID
+	FamilyId string `json:"-" form:"-" search:"type:eq;column:family_id;table:user_sessions"`         // 刷新令牌家族ID
+	Status   *int   `json:"status" form:"status" search:"type:eq;column:status;table:user_sessions"`  // 状态
+}
+
+// ==================== 响应DTO ====================
+
+// UserSessionInfo 登录会话信息
+type UserSessionInfo struct {
+	Id         uint64 `json:"id"`
+	Device     string `json:"device"`     // 设备描述，如 Chrome / Windows
+	UserAgent  string `json:"userAgent"`  // 原始 User-Agent
+	Ip         string `json:"ip"`         // 登录IP
+	LastSeenAt string `json:"lastSeenAt"` // 最后活跃时间
+	LastSeenIp string `json:"lastSeenIp"` // 最后活跃IP
+	ExpiresAt  string `json:"expiresAt"`  // 会话过期时间
+	CreatedAt  string `json:"createdAt"`  // 登录时间
+	Current    bool   `json:"current"`    // 是否为当前请求所在会话
+}
@@ -1,7 +1,7 @@
 package category
 
 import (
-	"errors"
+	"thinkingModels/component/errs"
 )
 
 // CategoryAbility 分类能力接口定义
@@ -33,12 +33,12 @@ func (m *CategoryEntity) All() ([]*CategoryEntity, error) {
 func (m *CategoryEntity) CreateCategory(req *CreateCategory) (*CategoryEntity, error) {
 	// 校验名称必填
 	if req.Name == "" {
-		return nil, errors.New("分类名称不能为空")
+		return nil, errs.Validation("分类名称不能为空")
 	}
 
 	// 校验名称长度
 	if len(req.Name) > 50 {
-		return nil, errors.New("分类名称长度不能超过50个字符")
+		return nil, errs.Validation("分类名称长度不能超过50个字符")
 	}
 
 	// 校验名称唯一性
@@ -47,7 +47,7 @@ func (m *CategoryEntity) CreateCategory(req *CreateCategory) (*CategoryEntity, e
 		return nil, err
 	}
 	if exists {
-		return nil, errors.New("分类名称已存在")
+		return nil, errs.Conflict("分类名称已存在")
 	}
 
 	// 初始化热度为0
@@ -88,7 +88,7 @@ func (m *CategoryEntity) CreateCategory(req *CreateCategory) (*CategoryEntity, e
 // 用于热门分类排序，delta 为增加的热度值
 func (m *CategoryEntity) IncreaseHeat(id uint64, delta int) error {
 	if delta <= 0 {
-		return errors.New("热度增加值必须大于0")
+		return errs.Validation("热度增加值必须大于0")
 	}
 
 	// 加载实体
@@ -1,11 +1,11 @@
 package category
 
 import (
-	"errors"
 
 	"github.com/gin-gonic/gin"
 	"github.com/jianyuezhexue/base"
 	"thinkingModels/component/db"
+	"thinkingModels/component/errs"
 )
 
 // CategoryEntity 分类实体业务模型
@@ -15,12 +15,14 @@ type CategoryEntity struct {
 	Icon        string `json:"icon" type:"db" comment:"分类图标"`           // 分类图标URL
 	Description string `json:"description" type:"db" comment:"分类描述"`    // 分类描述
 	Heat        int    `json:"heat" type:"db" comment:"热度值"`             // 热度值，用于排序
+	EnterpriseId uint64 `json:"enterpriseId" type:"db" comment:"企业ID"` // 企业ID
+	IsShared    bool   `json:"isShared" type:"db" comment:"是否跨企业共享"` // 共享分类对所有企业可见
 }
 
 // 实例化领域业务模型
 func NewCategoryEntity(ctx *gin.Context) *CategoryEntity {
 	entity := &CategoryEntity{}
-	entity.BaseModel = base.NewBaseModel(ctx, db.InitDb(), entity.TableName(), entity)
+	entity.BaseModel = base.NewBaseModel(ctx, db.CtxDb(ctx), entity.TableName(), entity)
 	return entity
 }
 
@@ -32,13 +34,13 @@ func (m *CategoryEntity) TableName() string {
 // ValidateFunc 数据校验
 func (m *CategoryEntity) Validate() error {
 	if m.Name == "" {
-		return errors.New("分类名称不能为空")
+		return errs.Validation("分类名称不能为空")
 	}
 	if len(m.Name) > 50 {
-		return errors.New("分类名称长度不能超过50个字符")
+		return errs.Validation("分类名称长度不能超过50个字符")
 	}
 	if len(m.Description) > 500 {
-		return errors.New("分类描述长度不能超过500个字符")
+		return errs.Validation("分类描述长度不能超过500个字符")
 	}
 	return nil
 }
@@ -1,13 +1,13 @@
 package action
 
 import (
-	"errors"
 	"time"
 
 	"thinkingModels/component/db"
 
 	"github.com/gin-gonic/gin"
 	"github.com/jianyuezhexue/base"
+	"thinkingModels/component/errs"
 )
 
 // ActionEntityInterface 行动实体接口
@@ -38,12 +38,13 @@ type ActionEntity struct {
 	Deadline       db.LocalTime `json:"deadline" type:"db" comment:"截止日期"`
 	CompletedAt    db.LocalTime `json:"completedAt" type:"db" comment:"完成时间"`
 	FollowUpCount  int          `json:"followUpCount" type:"db" comment:"跟进记录数"`
+	EnterpriseId   uint64       `json:"enterpriseId" type:"db" comment:"企业ID"`
 }
 
 // NewActionEntity 实例化行动实体
 func NewActionEntity(ctx *gin.Context, opt ...base.Option[ActionEntity]) ActionEntityInterface {
 	entity := &ActionEntity{}
-	entity.BaseModel = base.NewBaseModel(ctx, db.InitDb(), entity.TableName(), entity)
+	entity.BaseModel = base.NewBaseModel(ctx, db.CtxDb(ctx), entity.TableName(), entity)
 	if len(opt) > 0 {
 		for _, fc := range opt {
 			fc(&entity.BaseModel)
@@ -60,19 +61,19 @@ func (a *ActionEntity) TableName() string {
 // Validate 数据校验
 func (a *ActionEntity) Validate() error {
 	if a.Title == "" {
-		return errors.New("行动标题不能为空")
+		return errs.Validation("行动标题不能为空")
 	}
 	if len(a.Title) > 200 {
-		return errors.New("行动标题不能超过200字符")
+		return errs.Validation("行动标题不能超过200字符")
 	}
 	if a.UserId == 0 {
-		return errors.New("用户ID不能为空")
+		return errs.Validation("用户ID不能为空")
 	}
 	if a.Priority < 1 || a.Priority > 3 {
 		a.Priority = 2
 	}
 	if a.Progress < 0 || a.Progress > 100 {
-		return errors.New("进度必须在0-100之间")
+		return errs.Validation("进度必须在0-100之间")
 	}
 	return nil
 }
@@ -96,7 +97,7 @@ func (a *ActionEntity) Complete() error {
 // UpdateProgress 更新进度
 func (a *ActionEntity) UpdateProgress(progress int, note string) error {
 	if progress < 0 || progress > 100 {
-		return errors.New("进度必须在0-100之间")
+		return errs.Validation("进度必须在0-100之间")
 	}
 	a.Progress = progress
 	// 自动更新状态
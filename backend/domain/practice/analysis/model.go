@@ -1,9 +1,8 @@
 package analysis
 
 import (
-	"errors"
-
 	"thinkingModels/component/db"
+	"thinkingModels/component/errs"
 
 	"github.com/gin-gonic/gin"
 	"github.com/jianyuezhexue/base"
@@ -34,12 +33,13 @@ type AnalysisEntity struct {
 	Version      int    `json:"version" type:"db" comment:"版本号"`
 	IsCurrent    bool   `json:"isCurrent" type:"db" comment:"是否当前版本"`
 	Status       int    `json:"status" type:"db" comment:"状态: 0=草稿, 1=分析中, 2=已完成, 3=失败"`
+	EnterpriseId uint64 `json:"enterpriseId" type:"db" comment:"企业ID"`
 }
 
 // NewAnalysisEntity 实例化分析实体
 func NewAnalysisEntity(ctx *gin.Context, opt ...base.Option[AnalysisEntity]) AnalysisEntityInterface {
 	entity := &AnalysisEntity{}
-	entity.BaseModel = base.NewBaseModel(ctx, db.InitDb(), entity.TableName(), entity)
+	entity.BaseModel = base.NewBaseModel(ctx, db.CtxDb(ctx), entity.TableName(), entity)
 	if len(opt) > 0 {
 		for _, fc := range opt {
 			fc(&entity.BaseModel)
@@ -56,10 +56,10 @@ func (a *AnalysisEntity) TableName() string {
 // Validate 数据校验
 func (a *AnalysisEntity) Validate() error {
 	if a.TopicId == 0 {
-		return errors.New("课题ID不能为空")
+		return errs.Validation("课题ID不能为空")
 	}
 	if a.UserId == 0 {
-		return errors.New("用户ID不能为空")
+		return errs.Validation("用户ID不能为空")
 	}
 	return nil
 }
@@ -1,5 +1,7 @@
 package analysis
 
+import "thinkingModels/component/metrics"
+
 // ==================== 请求DTO ====================
 
 // CreateAnalysis 创建分析请求
@@ -23,6 +25,8 @@ type SaveWithAi struct {
 	ModelId   uint64 `json:"modelId" binding:"required"`
 	ModelName string `json:"modelName"`
 	Content   string `json:"content" binding:"required"`
+
+	Usage *metrics.AiUsage `json:"usage" binding:"omitempty"` // 本次 AI 调用的 Token 消耗（可选，用于监控统计）
 }
 
 // SetCurrentAnalysis 设为当前版本请求
@@ -1,9 +1,8 @@
 package category
 
 import (
-	"errors"
-
 	"thinkingModels/component/db"
+	"thinkingModels/component/errs"
 
 	"github.com/gin-gonic/gin"
 	"github.com/jianyuezhexue/base"
@@ -21,22 +20,24 @@ type CategoryEntityInterface interface {
 // CategoryEntity 分类实体
 type CategoryEntity struct {
 	base.BaseModel[CategoryEntity]
-	Code        string `json:"code" type:"db" comment:"分类编码"`
-	Name        string `json:"name" type:"db" comment:"分类名称"`
-	Icon        string `json:"icon" type:"db" comment:"分类图标"`
-	Description string `json:"description" type:"db" comment:"分类描述"`
-	ParentId    uint64 `json:"parentId" type:"db" comment:"父分类ID"`
-	Sort        int    `json:"sort" type:"db" comment:"排序"`
-	Level       int    `json:"level" type:"db" comment:"层级"`
-	Path        string `json:"path" type:"db" comment:"路径"`
-	ModelCount  int    `json:"modelCount" type:"db" comment:"模型数量"`
-	Status      int    `json:"status" type:"db" comment:"状态: 0=禁用, 1=启用"`
+	Code         string `json:"code" type:"db" comment:"分类编码"`
+	Name         string `json:"name" type:"db" comment:"分类名称"`
+	Icon         string `json:"icon" type:"db" comment:"分类图标"`
+	Description  string `json:"description" type:"db" comment:"分类描述"`
+	ParentId     uint64 `json:"parentId" type:"db" comment:"父分类ID"`
+	Sort         int    `json:"sort" type:"db" comment:"排序"`
+	Level        int    `json:"level" type:"db" comment:"层级"`
+	Path         string `json:"path" type:"db" comment:"路径"`
+	ModelCount   int    `json:"modelCount" type:"db" comment:"模型数量"`
+	Status       int    `json:"status" type:"db" comment:"状态: 0=禁用, 1=启用"`
+	EnterpriseId uint64 `json:"enterpriseId" type:"db" comment:"企业ID"`
+	IsShared     bool   `json:"isShared" type:"db" comment:"是否跨企业共享"`
 }
 
 // NewCategoryEntity 实例化分类实体
 func NewCategoryEntity(ctx *gin.Context, opt ...base.Option[CategoryEntity]) CategoryEntityInterface {
 	entity := &CategoryEntity{}
-	entity.BaseModel = base.NewBaseModel(ctx, db.InitDb(), entity.TableName(), entity)
+	entity.BaseModel = base.NewBaseModel(ctx, db.CtxDb(ctx), entity.TableName(), entity)
 	if len(opt) > 0 {
 		for _, fc := range opt {
 			fc(&entity.BaseModel)
@@ -53,10 +54,10 @@ func (c *CategoryEntity) TableName() string {
 // Validate 数据校验
 func (c *CategoryEntity) Validate() error {
 	if c.Name == "" {
-		return errors.New("分类名称不能为空")
+		return errs.Validation("分类名称不能为空")
 	}
 	if len(c.Name) > 50 {
-		return errors.New("分类名称不能超过50字符")
+		return errs.Validation("分类名称不能超过50字符")
 	}
 	return nil
 }
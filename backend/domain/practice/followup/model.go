@@ -1,9 +1,8 @@
 package followup
 
 import (
-	"errors"
-
 	"thinkingModels/component/db"
+	"thinkingModels/component/errs"
 
 	"github.com/gin-gonic/gin"
 	"github.com/jianyuezhexue/base"
@@ -23,12 +22,13 @@ type FollowUpEntity struct {
 	Content        string `json:"content" type:"db" comment:"跟进内容"`
 	ProgressBefore int    `json:"progressBefore" type:"db" comment:"跟进前进度"`
 	ProgressAfter  int    `json:"progressAfter" type:"db" comment:"跟进后进度"`
+	EnterpriseId   uint64 `json:"enterpriseId" type:"db" comment:"企业ID"`
 }
 
 // NewFollowUpEntity 实例化跟进记录实体
 func NewFollowUpEntity(ctx *gin.Context, opt ...base.Option[FollowUpEntity]) FollowUpEntityInterface {
 	entity := &FollowUpEntity{}
-	entity.BaseModel = base.NewBaseModel(ctx, db.InitDb(), entity.TableName(), entity)
+	entity.BaseModel = base.NewBaseModel(ctx, db.CtxDb(ctx), entity.TableName(), entity)
 	if len(opt) > 0 {
 		for _, fc := range opt {
 			fc(&entity.BaseModel)
@@ -45,19 +45,19 @@ func (f *FollowUpEntity) TableName() string {
 // Validate 数据校验
 func (f *FollowUpEntity) Validate() error {
 	if f.ActionId == 0 {
-		return errors.New("行动ID不能为空")
+		return errs.Validation("行动ID不能为空")
 	}
 	if f.UserId == 0 {
-		return errors.New("用户ID不能为空")
+		return errs.Validation("用户ID不能为空")
 	}
 	if f.Content == "" {
-		return errors.New("跟进内容不能为空")
+		return errs.Validation("跟进内容不能为空")
 	}
 	if f.ProgressBefore < 0 || f.ProgressBefore > 100 {
-		return errors.New("跟进前进度必须在0-100之间")
+		return errs.Validation("跟进前进度必须在0-100之间")
 	}
 	if f.ProgressAfter < 0 || f.ProgressAfter > 100 {
-		return errors.New("跟进后进度必须在0-100之间")
+		return errs.Validation("跟进后进度必须在0-100之间")
 	}
 	return nil
 }
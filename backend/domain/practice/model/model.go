@@ -1,13 +1,13 @@
 package model
 
 import (
-	"errors"
 	"time"
 
 	"thinkingModels/component/db"
 
 	"github.com/gin-gonic/gin"
 	"github.com/jianyuezhexue/base"
+	"thinkingModels/component/errs"
 )
 
 // ModelEntityInterface 思维模型实体接口
@@ -15,42 +15,50 @@ type ModelEntityInterface interface {
 	base.BaseModelInterface[ModelEntity]
 	Publish() error
 	Unpublish() error
+	Share(shared bool) error
 	IncrementUsageCount()
 	IncrementAdoptCount()
 	IncrementLikeCount()
 	IncrementCommentCount()
 	Fork() ModelEntityInterface
+	AuthorStats(authorId uint64) (*AuthorStats, error)
 }
 
 // ModelEntity 思维模型实体
 type ModelEntity struct {
 	base.BaseModel[ModelEntity]
-	Code          string `json:"code" type:"db" comment:"模型编码"`
-	Name          string `json:"name" type:"db" comment:"模型名称"`
-	Description   string `json:"description" type:"db" comment:"简短描述"`
-	Overview      string `json:"overview" type:"db" comment:"概述"`
-	Icon          string `json:"icon" type:"db" comment:"图标"`
-	CategoryId    uint64 `json:"categoryId" type:"db" comment:"分类ID"`
-	Content       string `json:"content" type:"db" comment:"模型内容(JSON)"`
-	UsageGuide    string `json:"usageGuide" type:"db" comment:"使用指南"`
-	Examples      string `json:"examples" type:"db" comment:"案例(JSON)"`
-	AiPrompt      string `json:"aiPrompt" type:"db" comment:"AI提示词模板"`
-	Difficulty    int    `json:"difficulty" type:"db" comment:"难度: 1=简单, 2=中等, 3=困难"`
-	EstimatedTime int    `json:"estimatedTime" type:"db" comment:"预计用时(分钟)"`
-	Status        int    `json:"status" type:"db" comment:"状态: 0=草稿, 1=已发布, 2=已下架"`
-	Version       string `json:"version" type:"db" comment:"版本号"`
-	IsOfficial    bool   `json:"isOfficial" type:"db" comment:"是否官方"`
-	SourceModelId uint64 `json:"sourceModelId" type:"db" comment:"派生来源ID"`
-	UsageCount    int64  `json:"usageCount" type:"db" comment:"使用次数"`
-	AdoptCount    int64  `json:"adoptCount" type:"db" comment:"采纳次数"`
-	LikeCount     int64  `json:"likeCount" type:"db" comment:"点赞数"`
-	CommentCount  int64  `json:"commentCount" type:"db" comment:"评论数"`
+	Code          string  `json:"code" type:"db" comment:"模型编码"`
+	Name          string  `json:"name" type:"db" comment:"模型名称"`
+	Description   string  `json:"description" type:"db" comment:"简短描述"`
+	Overview      string  `json:"overview" type:"db" comment:"概述"`
+	CoverImage    string  `json:"coverImage" type:"db" comment:"封面图片URL"`
+	Icon          string  `json:"icon" type:"db" comment:"图标"`
+	CategoryId    uint64  `json:"categoryId" type:"db" comment:"分类ID"`
+	Price         float64 `json:"price" type:"db" comment:"价格，0表示免费"`
+	Content       string  `json:"content" type:"db" comment:"模型内容(JSON)"`
+	UsageGuide    string  `json:"usageGuide" type:"db" comment:"使用指南"`
+	Examples      string  `json:"examples" type:"db" comment:"案例(JSON)"`
+	AiPrompt      string  `json:"aiPrompt" type:"db" comment:"AI提示词模板"`
+	Difficulty    int     `json:"difficulty" type:"db" comment:"难度: 1=简单, 2=中等, 3=困难"`
+	EstimatedTime int     `json:"estimatedTime" type:"db" comment:"预计用时(分钟)"`
+	Status        int     `json:"status" type:"db" comment:"状态: 0=草稿, 1=已发布, 2=已下架"`
+	Version       string  `json:"version" type:"db" comment:"版本号"`
+	AuthorId      uint64  `json:"authorId" type:"db" comment:"作者ID"`
+	AuthorName    string  `json:"authorName" type:"db" comment:"作者名称"`
+	IsOfficial    bool    `json:"isOfficial" type:"db" comment:"是否官方"`
+	SourceModelId uint64  `json:"sourceModelId" type:"db" comment:"派生来源ID"`
+	UsageCount    int64   `json:"usageCount" type:"db" comment:"使用次数"`
+	AdoptCount    int64   `json:"adoptCount" type:"db" comment:"采纳次数"`
+	LikeCount     int64   `json:"likeCount" type:"db" comment:"点赞数"`
+	CommentCount  int64   `json:"commentCount" type:"db" comment:"评论数"`
+	EnterpriseId  uint64  `json:"enterpriseId" type:"db" comment:"企业ID"`
+	IsShared      bool    `json:"isShared" type:"db" comment:"是否跨企业共享"`
 }
 
 // NewModelEntity 实例化思维模型实体
 func NewModelEntity(ctx *gin.Context, opt ...base.Option[ModelEntity]) ModelEntityInterface {
 	entity := &ModelEntity{}
-	entity.BaseModel = base.NewBaseModel(ctx, db.InitDb(), entity.TableName(), entity)
+	entity.BaseModel = base.NewBaseModel(ctx, db.CtxDb(ctx), entity.TableName(), entity)
 	if len(opt) > 0 {
 		for _, fc := range opt {
 			fc(&entity.BaseModel)
@@ -67,10 +75,10 @@ func (m *ModelEntity) TableName() string {
 // Validate 数据校验
 func (m *ModelEntity) Validate() error {
 	if m.Name == "" {
-		return errors.New("模型名称不能为空")
+		return errs.Validation("模型名称不能为空")
 	}
 	if len(m.Name) > 100 {
-		return errors.New("模型名称不能超过100字符")
+		return errs.Validation("模型名称不能超过100字符")
 	}
 	if m.Difficulty < 1 || m.Difficulty > 3 {
 		m.Difficulty = 1
@@ -100,7 +108,7 @@ func (m *ModelEntity) Complete() error {
 // Publish 发布模型
 func (m *ModelEntity) Publish() error {
 	if m.Status == 1 {
-		return errors.New("模型已发布")
+		return errs.Conflict("模型已发布")
 	}
 	m.Status = 1
 	return nil
@@ -109,12 +117,21 @@ func (m *ModelEntity) Publish() error {
 // Unpublish 下架模型
 func (m *ModelEntity) Unpublish() error {
 	if m.Status != 1 {
-		return errors.New("模型未发布，无法下架")
+		return errs.Conflict("模型未发布，无法下架")
 	}
 	m.Status = 2
 	return nil
 }
 
+// Share 设置跨企业共享，仅已发布模型可共享
+func (m *ModelEntity) Share(shared bool) error {
+	if shared && m.Status != 1 {
+		return errs.Conflict("模型未发布，无法共享")
+	}
+	m.IsShared = shared
+	return nil
+}
+
 // IncrementUsageCount 增加使用次数
 func (m *ModelEntity) IncrementUsageCount() {
 	m.UsageCount++
@@ -142,6 +159,7 @@ func (m *ModelEntity) Fork() ModelEntityInterface {
 		Name:          m.Name + " (派生)",
 		Description:   m.Description,
 		Overview:      m.Overview,
+		CoverImage:    m.CoverImage,
 		Icon:          m.Icon,
 		CategoryId:    m.CategoryId,
 		Content:       m.Content,
@@ -158,3 +176,15 @@ func (m *ModelEntity) Fork() ModelEntityInterface {
 	newEntity.BaseModel = m.BaseModel
 	return newEntity
 }
+
+// AuthorStats 作者已发布模型的数量与统计数据汇总（单条聚合查询）
+// 结果集不是模型实体，租户插件无法识别，需手动按企业过滤（含共享模型）
+func (m *ModelEntity) AuthorStats(authorId uint64) (*AuthorStats, error) {
+	stats := &AuthorStats{}
+	err := m.Tx().Table(m.TableName()).Scopes(db.TenantScope(m.Ctx, true)).
+		Select("COUNT(*) AS model_count, COALESCE(SUM(usage_count), 0) AS usage_count, COALESCE(SUM(adopt_count), 0) AS adopt_count,"+
+			" COALESCE(SUM(like_count), 0) AS like_count, COALESCE(SUM(comment_count), 0) AS comment_count").
+		Where("author_id = ? AND status = 1 AND deleted_at IS NULL", authorId).
+		Scan(stats).Error
+	return stats, err
+}
@@ -47,6 +47,12 @@ type UnpublishModel struct {
 	Id uint64 `json:"id" binding:"required"`
 }
 
+// ShareModel 设置模型跨企业共享请求
+type ShareModel struct {
+	Id       uint64 `json:"id" binding:"required"`
+	IsShared bool   `json:"isShared"`
+}
+
 // ForkModel 引用创建模型请求
 type ForkModel struct {
 	SourceModelId uint64 `json:"sourceModelId" binding:"required"`
@@ -94,6 +100,15 @@ type ModelStats struct {
 	CommentCount int `json:"commentCount"`
 }
 
+// AuthorStats 作者已发布模型的汇总数据
+type AuthorStats struct {
+	ModelCount   int64 `json:"modelCount"`   // 已发布模型数
+	UsageCount   int64 `json:"usageCount"`   // 累计使用次数
+	AdoptCount   int64 `json:"adoptCount"`   // 累计采纳次数
+	LikeCount    int64 `json:"likeCount"`    // 累计点赞数
+	CommentCount int64 `json:"commentCount"` // 累计评论数
+}
+
 // ModelInfo 思维模型信息DTO
 type ModelInfo struct {
 	Id            uint64      `json:"id"`
@@ -111,6 +126,7 @@ type ModelInfo struct {
 	Status        int         `json:"status"`
 	Version       string      `json:"version"`
 	IsOfficial    bool        `json:"isOfficial"`
+	IsShared      bool        `json:"isShared"`
 	SourceModelId uint64      `json:"sourceModelId,omitempty"`
 	Author        ModelAuthor `json:"author"`
 	Stats         ModelStats  `json:"stats"`
@@ -1,9 +1,8 @@
 package tag
 
 import (
-	"errors"
-
 	"thinkingModels/component/db"
+	"thinkingModels/component/errs"
 
 	"github.com/gin-gonic/gin"
 	"github.com/jianyuezhexue/base"
@@ -21,14 +20,15 @@ type TagEntityInterface interface {
 // TagEntity 标签实体
 type TagEntity struct {
 	base.BaseModel[TagEntity]
-	ModelId uint64 `json:"modelId" type:"db" comment:"模型ID"`
-	TagName string `json:"tagName" type:"db" comment:"标签名称"`
+	ModelId      uint64 `json:"modelId" type:"db" comment:"模型ID"`
+	TagName      string `json:"tagName" type:"db" comment:"标签名称"`
+	EnterpriseId uint64 `json:"enterpriseId" type:"db" comment:"企业ID"`
 }
 
 // NewTagEntity 实例化标签实体
 func NewTagEntity(ctx *gin.Context, opt ...base.Option[TagEntity]) TagEntityInterface {
 	entity := &TagEntity{}
-	entity.BaseModel = base.NewBaseModel(ctx, db.InitDb(), entity.TableName(), entity)
+	entity.BaseModel = base.NewBaseModel(ctx, db.CtxDb(ctx), entity.TableName(), entity)
 	if len(opt) > 0 {
 		for _, fc := range opt {
 			fc(&entity.BaseModel)
@@ -45,13 +45,13 @@ func (t *TagEntity) TableName() string {
 // Validate 数据校验
 func (t *TagEntity) Validate() error {
 	if t.ModelId == 0 {
-		return errors.New("模型ID不能为空")
+		return errs.Validation("模型ID不能为空")
 	}
 	if t.TagName == "" {
-		return errors.New("标签名称不能为空")
+		return errs.Validation("标签名称不能为空")
 	}
 	if len(t.TagName) > 50 {
-		return errors.New("标签名称不能超过50字符")
+		return errs.Validation("标签名称不能超过50字符")
 	}
 	return nil
 }
@@ -108,7 +108,8 @@ func (t *TagEntity) RemoveFromModel(modelId uint64, tags []string) error {
 func (t *TagEntity) GetHotTags(limit int) ([]*HotTag, error) {
 	// 使用原生SQL查询热门标签
 	var hotTags []*HotTag
-	err := db.InitDb().Table(t.TableName()).
+	// 结果集不是标签实体，租户插件无法识别，需手动按企业过滤
+	err := db.CtxDb(t.Ctx).Table(t.TableName()).Scopes(db.TenantScope(t.Ctx, false)).
 		Select("tag_name, COUNT(*) as count").
 		Group("tag_name").
 		Order("count DESC").
@@ -1,9 +1,8 @@
 package topic
 
 import (
-	"errors"
-
 	"thinkingModels/component/db"
+	"thinkingModels/component/errs"
 
 	"github.com/gin-gonic/gin"
 	"github.com/jianyuezhexue/base"
@@ -37,12 +36,13 @@ type TopicEntity struct {
 	Deadline      db.LocalTime `json:"deadline" type:"db" comment:"截止日期"`
 	AnalysisCount int          `json:"analysisCount" type:"db" comment:"分析次数"`
 	ActionCount   int          `json:"actionCount" type:"db" comment:"行动数量"`
+	EnterpriseId  uint64       `json:"enterpriseId" type:"db" comment:"企业ID"`
 }
 
 // NewTopicEntity 实例化课题实体
 func NewTopicEntity(ctx *gin.Context, opt ...base.Option[TopicEntity]) TopicEntityInterface {
 	entity := &TopicEntity{}
-	entity.BaseModel = base.NewBaseModel(ctx, db.InitDb(), entity.TableName(), entity)
+	entity.BaseModel = base.NewBaseModel(ctx, db.CtxDb(ctx), entity.TableName(), entity)
 	if len(opt) > 0 {
 		for _, fc := range opt {
 			fc(&entity.BaseModel)
@@ -59,13 +59,13 @@ func (t *TopicEntity) TableName() string {
 // Validate 数据校验
 func (t *TopicEntity) Validate() error {
 	if t.Title == "" {
-		return errors.New("课题标题不能为空")
+		return errs.Validation("课题标题不能为空")
 	}
 	if len(t.Title) > 200 {
-		return errors.New("课题标题不能超过200字符")
+		return errs.Validation("课题标题不能超过200字符")
 	}
 	if t.UserId == 0 {
-		return errors.New("用户ID不能为空")
+		return errs.Validation("用户ID不能为空")
 	}
 	return nil
 }
@@ -106,7 +106,7 @@ func (t *TopicEntity) RemoveModel() error {
 // MarkComplete 标记完成
 func (t *TopicEntity) MarkComplete() error {
 	if t.Status == 2 {
-		return errors.New("课题已完成")
+		return errs.Conflict("课题已完成")
 	}
 	t.Status = 2
 	return nil
@@ -115,7 +115,7 @@ func (t *TopicEntity) MarkComplete() error {
 // Archive 归档
 func (t *TopicEntity) Archive() error {
 	if t.Status == 3 {
-		return errors.New("课题已归档")
+		return errs.Conflict("课题已归档")
 	}
 	t.Status = 3
 	return nil
@@ -124,7 +124,7 @@ func (t *TopicEntity) Archive() error {
 // Reopen 重新打开
 func (t *TopicEntity) Reopen() error {
 	if t.Status == 1 {
-		return errors.New("课题已在进行中")
+		return errs.Conflict("课题已在进行中")
 	}
 	t.Status = 1
 	return nil
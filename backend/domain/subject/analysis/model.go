@@ -2,11 +2,11 @@ package analysis
 
 import (
 	"encoding/json"
-	"errors"
 
 	"github.com/gin-gonic/gin"
 	"github.com/jianyuezhexue/base"
 	"thinkingModels/component/db"
+	"thinkingModels/component/errs"
 )
 
 // AnalysisEntityInterface 分析记录实体接口
@@ -35,12 +35,13 @@ type AnalysisEntity struct {
 	Version       int    `json:"version" type:"db" comment:"版本号"`
 	IsCurrent     int    `json:"isCurrent" type:"db" comment:"是否为当前版本: 0=否, 1=是"`
 	UserId        uint64 `json:"userId" type:"db" comment:"用户ID"`
+	EnterpriseId  uint64 `json:"enterpriseId" type:"db" comment:"企业ID"`
 }
 
 // NewAnalysisEntity 实例化分析记录实体
 func NewAnalysisEntity(ctx *gin.Context, opt ...base.Option[AnalysisEntity]) AnalysisEntityInterface {
 	entity := &AnalysisEntity{}
-	entity.BaseModel = base.NewBaseModel(ctx, db.InitDb(), entity.TableName(), entity)
+	entity.BaseModel = base.NewBaseModel(ctx, db.CtxDb(ctx), entity.TableName(), entity)
 
 	// 自定义配置选项
 	if len(opt) > 0 {
@@ -59,18 +60,18 @@ func (a *AnalysisEntity) TableName() string {
 // Validate 数据校验
 func (a *AnalysisEntity) Validate() error {
 	if a.TopicId == 0 {
-		return errors.New("课题ID不能为空")
+		return errs.Validation("课题ID不能为空")
 	}
 	if a.ModelId == 0 {
-		return errors.New("思维模型ID不能为空")
+		return errs.Validation("思维模型ID不能为空")
 	}
 	if a.Content == "" {
-		return errors.New("分析内容不能为空")
+		return errs.Validation("分析内容不能为空")
 	}
 	// 校验Content是否为有效的JSON
 	var contentMap map[string]interface{}
 	if err := json.Unmarshal([]byte(a.Content), &contentMap); err != nil {
-		return errors.New("分析内容必须是有效的JSON格式")
+		return errs.Validation("分析内容必须是有效的JSON格式")
 	}
 	return nil
 }
@@ -1,5 +1,7 @@
 package analysis
 
+import "thinkingModels/component/metrics"
+
 // ==================== 请求DTO ====================
 
 // CreateAnalysis 创建分析记录请求
@@ -25,6 +27,8 @@ type SaveAnalysisWithAi struct {
 	Content       string `json:"content" binding:"required"`
 	AiAnalysis    string `json:"aiAnalysis" binding:"required"`
 	AiSuggestions string `json:"aiSuggestions" binding:"required"`
+
+	Usage *metrics.AiUsage `json:"usage" binding:"omitempty"` // 本次 AI 调用的 Token 消耗（可选，用于监控统计）
 }
 
 // SetCurrentVersion 设置当前版本请求
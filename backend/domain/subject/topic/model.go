@@ -1,13 +1,13 @@
 package topic
 
 import (
-	"errors"
 	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/jianyuezhexue/base"
 	"thinkingModels/component/db"
+	"thinkingModels/component/errs"
 )
 
 // TopicEntityInterface 课题实体接口
@@ -38,12 +38,13 @@ type TopicEntity struct {
 	Tags         string       `json:"tags" type:"db" comment:"标签，逗号分隔"`
 	Deadline     db.LocalTime `json:"deadline" type:"db" comment:"截止日期"`
 	CompleteTime db.LocalTime `json:"completeTime" type:"db" comment:"完成时间"`
+	EnterpriseId uint64       `json:"enterpriseId" type:"db" comment:"企业ID"`
 }
 
 // NewTopicEntity 实例化课题实体
 func NewTopicEntity(ctx *gin.Context, opt ...base.Option[TopicEntity]) TopicEntityInterface {
 	entity := &TopicEntity{}
-	entity.BaseModel = base.NewBaseModel(ctx, db.InitDb(), entity.TableName(), entity)
+	entity.BaseModel = base.NewBaseModel(ctx, db.CtxDb(ctx), entity.TableName(), entity)
 
 	// 自定义配置选项
 	if len(opt) > 0 {
@@ -62,13 +63,13 @@ func (t *TopicEntity) TableName() string {
 // Validate 数据校验
 func (t *TopicEntity) Validate() error {
 	if t.Title == "" {
-		return errors.New("课题标题不能为空")
+		return errs.Validation("课题标题不能为空")
 	}
 	if len(t.Title) > 200 {
-		return errors.New("课题标题不能超过200字符")
+		return errs.Validation("课题标题不能超过200字符")
 	}
 	if len(t.Description) > 2000 {
-		return errors.New("课题描述不能超过2000字符")
+		return errs.Validation("课题描述不能超过2000字符")
 	}
 	// 优先级校验
 	if t.Priority < 1 || t.Priority > 3 {
@@ -76,7 +77,7 @@ func (t *TopicEntity) Validate() error {
 	}
 	// 标签长度校验
 	if len(t.Tags) > 500 {
-		return errors.New("标签总长度不能超过500字符")
+		return errs.Validation("标签总长度不能超过500字符")
 	}
 	return nil
 }
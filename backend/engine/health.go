@@ -0,0 +1,122 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"thinkingModels/component/db"
+	"thinkingModels/component/redis"
+	"thinkingModels/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	statusOk   = "ok"
+	statusFail = "fail"
+)
+
+var startedAt = time.Now()
+
+// health 存活与就绪探针
+// livez 只反映进程是否正常，不检查依赖，避免依赖故障时被反复重启；
+// readyz 检查依赖，任一依赖不可用或正在退出时返回 503，负载均衡据此摘除流量
+type health struct {
+	shuttingDown atomic.Bool
+}
+
+// checker 依赖检查
+type checker struct {
+	name string
+	ping func(ctx context.Context) error
+}
+
+var checkers = []checker{
+	{"mysql", db.Ping},
+	{"redis", redis.Ping},
+	{"schema", checkSchema}, // 数据库已执行全部迁移
+}
+
+// componentStatus 单个依赖的检查结果
+type componentStatus struct {
+	Status  string `json:"status"`
+	Latency string `json:"latency"`
+	Error   string `json:"error,omitempty"`
+}
+
+// Livez 存活探针
+func (h *health) Livez(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, gin.H{
+		"status": statusOk,
+		"uptime": time.Since(startedAt).Round(time.Second).String(),
+	})
+}
+
+// Readyz 就绪探针：并发检查各依赖，返回每个依赖的状态与耗时
+func (h *health) Readyz(ctx *gin.Context) {
+	if h.shuttingDown.Load() {
+		ctx.JSON(http.StatusServiceUnavailable, gin.H{"status": statusFail, "reason": "shutting down"})
+		return
+	}
+
+	timeout := time.Duration(config.Config.Server.ProbeTimeout) * time.Second
+	checkCtx, cancel := context.WithTimeout(ctx.Request.Context(), timeout)
+	defer cancel()
+
+	var (
+		mu         sync.Mutex
+		wg         sync.WaitGroup
+		components = make(map[string]componentStatus, len(checkers))
+		status     = statusOk
+	)
+	for _, c := range checkers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			start := time.Now()
+			err := ping(checkCtx, c.ping)
+			res := componentStatus{Status: statusOk, Latency: time.Since(start).Round(time.Microsecond).String()}
+			if err != nil {
+				res.Status, res.Error = statusFail, err.Error()
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			components[c.name] = res
+			if err != nil {
+				status = statusFail
+			}
+		}()
+	}
+	wg.Wait()
+
+	code := http.StatusOK
+	if status != statusOk {
+		code = http.StatusServiceUnavailable
+	}
+	ctx.JSON(code, gin.H{"status": status, "components": components})
+}
+
+// ping 执行检查，超时或检查本身 panic（如数据库首次连接失败）时返回错误
+func ping(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- fmt.Errorf("%v", r)
+			}
+		}()
+		done <- fn(ctx)
+	}()
+
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+	return err
+}
@@ -0,0 +1,72 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type readyzResponse struct {
+	Status     string                     `json:"status"`
+	Components map[string]componentStatus `json:"components"`
+}
+
+func serveProbe(t *testing.T, h *health, path string) (int, readyzResponse) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/livez", h.Livez)
+	r.GET("/readyz", h.Readyz)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+	res := readyzResponse{}
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &res))
+	return w.Code, res
+}
+
+func TestReadyz(t *testing.T) {
+	old := checkers
+	defer func() { checkers = old }()
+
+	ok := func(ctx context.Context) error { return nil }
+	down := func(ctx context.Context) error { return errors.New("connection refused") }
+	hang := func(ctx context.Context) error { <-ctx.Done(); return ctx.Err() }
+	broken := func(ctx context.Context) error { panic("数据库连接失败") }
+
+	h := &health{}
+	checkers = []checker{{"mysql", ok}, {"redis", ok}}
+	code, res := serveProbe(t, h, "/readyz")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, statusOk, res.Components["redis"].Status)
+
+	checkers = []checker{{"mysql", broken}, {"redis", down}}
+	code, res = serveProbe(t, h, "/readyz")
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, statusFail, res.Status)
+	assert.Equal(t, "数据库连接失败", res.Components["mysql"].Error)
+	assert.Equal(t, "connection refused", res.Components["redis"].Error)
+
+	// 依赖无响应时按 probeTimeout 超时，不阻塞探针
+	checkers = []checker{{"mysql", ok}, {"redis", hang}}
+	start := time.Now()
+	code, res = serveProbe(t, h, "/readyz")
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, statusOk, res.Components["mysql"].Status)
+	assert.Less(t, time.Since(start), 5*time.Second)
+
+	// 退出中：就绪探针失败，存活探针不受影响
+	checkers = []checker{{"mysql", ok}}
+	h.shuttingDown.Store(true)
+	code, _ = serveProbe(t, h, "/readyz")
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	code, res = serveProbe(t, h, "/livez")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, statusOk, res.Status)
+}
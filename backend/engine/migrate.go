@@ -0,0 +1,179 @@
+package engine
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+
+	"github.com/go-sql-driver/mysql"
+	"thinkingModels/component/db"
+	"thinkingModels/component/migrate"
+	"thinkingModels/config"
+	"thinkingModels/migrations"
+)
+
+const migrateUsage = `用法: thinkingModels migrate <命令> [参数]
+
+  up [N]          执行未执行的迁移，默认全部
+  down [N|all]    回滚已执行的迁移，默认最近 1 个
+  status          查看各版本的执行状态
+  create NAME     在 -dir 目录下生成下一个版本的迁移脚本
+  force VERSION   将数据库标记为指定版本（迁移失败修复后，或纳管手工建表的数据库）
+
+参数:
+`
+
+// Migrate 数据库迁移命令，连接配置与服务相同（config.yaml 的 mysql 配置及 TM_* 环境变量），返回进程退出码
+func Migrate(args []string) int {
+	fset := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	dir := fset.String("dir", "migrations", "迁移脚本目录（仅 create 使用，其余命令使用编译时嵌入的脚本）")
+	fset.Usage = func() {
+		fmt.Fprint(fset.Output(), migrateUsage)
+		fset.PrintDefaults()
+	}
+	if err := fset.Parse(args); err != nil || fset.NArg() == 0 {
+		fset.Usage()
+		return 2
+	}
+
+	if err := runMigrate(fset.Arg(0), fset.Args()[1:], *dir); err != nil {
+		fmt.Fprintln(os.Stderr, "migrate:", err)
+		return 1
+	}
+	return 0
+}
+
+func runMigrate(cmd string, args []string, dir string) error {
+	if cmd == "create" {
+		if len(args) != 1 {
+			return fmt.Errorf("用法: migrate create NAME")
+		}
+		up, down, err := migrate.Create(dir, args[0])
+		if err != nil {
+			return err
+		}
+		fmt.Println("已创建", up)
+		fmt.Println("已创建", down)
+		return nil
+	}
+	if !slices.Contains([]string{"up", "down", "status", "force"}, cmd) {
+		return fmt.Errorf("未知命令 %q，可用命令: up、down、status、create、force", cmd)
+	}
+
+	sqlDB, err := openMigrateDb()
+	if err != nil {
+		return err
+	}
+	defer sqlDB.Close()
+	m, err := migrate.New(sqlDB, migrations.FS)
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	switch cmd {
+	case "up":
+		steps, err := migrateSteps(args, 0)
+		if err != nil {
+			return err
+		}
+		done, err := m.Up(ctx, steps)
+		for _, mg := range done {
+			fmt.Printf("已执行 %04d_%s\n", mg.Version, mg.Name)
+		}
+		if err == nil && len(done) == 0 {
+			fmt.Println("数据库已是最新版本", m.Latest())
+		}
+		return err
+	case "down":
+		steps := 0
+		if len(args) == 0 || args[0] != "all" {
+			if steps, err = migrateSteps(args, 1); err != nil {
+				return err
+			}
+		}
+		done, err := m.Down(ctx, steps)
+		for _, mg := range done {
+			fmt.Printf("已回滚 %04d_%s\n", mg.Version, mg.Name)
+		}
+		return err
+	case "status":
+		list, err := m.Status(ctx)
+		if err != nil {
+			return err
+		}
+		for _, s := range list {
+			status := "未执行"
+			switch {
+			case s.Dirty:
+				status = "失败"
+			case s.Unknown:
+				status = "已执行（代码中不存在）"
+			case s.Applied:
+				status = "已执行 " + s.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%04d_%-30s %s\n", s.Version, s.Name, status)
+		}
+		return nil
+	case "force":
+		if len(args) != 1 {
+			return fmt.Errorf("用法: migrate force VERSION")
+		}
+		version, err := strconv.ParseUint(args[0], 10, 64)
+		if err != nil {
+			return fmt.Errorf("版本号无效: %s", args[0])
+		}
+		if err := m.Force(ctx, version); err != nil {
+			return err
+		}
+		fmt.Println("数据库已标记为版本", version)
+	}
+	return nil
+}
+
+// migrateSteps 解析执行数量
+func migrateSteps(args []string, def int) (int, error) {
+	if len(args) == 0 {
+		return def, nil
+	}
+	steps, err := strconv.Atoi(args[0])
+	if err != nil || steps <= 0 {
+		return 0, fmt.Errorf("数量无效: %s", args[0])
+	}
+	return steps, nil
+}
+
+// openMigrateDb 迁移专用连接：单个迁移脚本包含多条语句，需开启 multiStatements
+func openMigrateDb() (*sql.DB, error) {
+	conf, err := mysql.ParseDSN(config.Config.Mysql.DbSource)
+	if err != nil {
+		return nil, fmt.Errorf("mysql.dbSource 格式错误: %w", err)
+	}
+	conf.MultiStatements = true
+	sqlDB, err := sql.Open("mysql", conf.FormatDSN())
+	if err != nil {
+		return nil, err
+	}
+	if err = sqlDB.Ping(); err != nil {
+		sqlDB.Close()
+		return nil, fmt.Errorf("数据库连接失败: %w", err)
+	}
+	return sqlDB, nil
+}
+
+// checkSchema 检查数据库是否已执行当前代码的全部迁移
+func checkSchema(ctx context.Context) error {
+	sqlDB, err := db.InitDb().DB()
+	if err != nil {
+		return err
+	}
+	m, err := migrate.New(sqlDB, migrations.FS)
+	if err != nil {
+		return err
+	}
+	return m.Check(ctx)
+}
@@ -1,37 +1,156 @@
 package engine
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"log/slog"
+	"net/http"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
 
+	"thinkingModels/component/db"
+	"thinkingModels/component/jwtkey"
+	"thinkingModels/component/logger"
+	"thinkingModels/component/metrics"
+	"thinkingModels/component/migrate"
+	"thinkingModels/component/redis"
 	"thinkingModels/config"
+	"thinkingModels/logic/iam"
+	"thinkingModels/middleware"
 	"thinkingModels/router"
 
 	"github.com/gin-gonic/gin"
 )
 
-// 启动服务
+// schemaCheckTimeout 启动时检查数据库版本的超时时间
+const schemaCheckTimeout = 5 * time.Second
+
+// 启动服务，收到 SIGINT/SIGTERM 后优雅退出：
+//  1. /readyz 返回 503，继续服务 server.shutdownDelay 秒，等待负载均衡摘除流量
+//  2. 停止接收新连接，等待进行中的请求完成
+//  3. 通知后台任务退出并等待其完成当前任务
+//  4. 关闭数据库与 redis 连接池
+//
+// 2、3 共用 server.shutdownTimeout 的等待时间
 func Run() {
 
-	// todo 设置模式
-	// gin.SetMode(gin.ReleaseMode)
+	// 结构化日志；生产环境关闭 gin 的调试输出
+	logger.Init(config.Config.Log.Level, config.Config.Log.Format)
+	if config.Config.IsProd() {
+		gin.SetMode(gin.ReleaseMode)
+	}
+
+	// 配置已在加载时校验，这里输出脱敏后的配置便于排查
+	slog.Info("加载配置", "env", config.Config.Env, "config", config.Config.Redacted())
+
+	// 签名密钥配置错误时拒绝启动，避免上线后才发现无法登录
+	if _, err := jwtkey.Default(); err != nil {
+		panic(fmt.Errorf("加载JWT签名密钥失败: %w", err))
+	}
 
-	// 实例化引擎
-	r := gin.Default()
+	// 数据库版本落后于代码时拒绝启动；数据库暂不可用时跳过，由就绪探针持续检查
+	checkCtx, cancelCheck := context.WithTimeout(context.Background(), schemaCheckTimeout)
+	err := ping(checkCtx, checkSchema)
+	cancelCheck()
+	switch {
+	case errors.Is(err, migrate.ErrPending), errors.Is(err, migrate.ErrDirty):
+		panic(fmt.Errorf("%w，请先执行 migrate up（见 migrate status）", err))
+	case err != nil:
+		slog.Warn("数据库版本检查跳过", "error", err)
+	}
+
+	// 实例化引擎：trace id → 访问日志 → 请求指标 → panic 恢复
+	r := gin.New()
+	// 只采信可信代理转发的 X-Forwarded-For，否则客户端可伪造来源IP绕过限流、登录保护与指标白名单
+	if err := r.SetTrustedProxies(config.Config.Server.TrustedProxies); err != nil {
+		panic(fmt.Errorf("设置可信代理失败: %w", err))
+	}
+	r.Use(middleware.Trace(), middleware.AccessLog(), middleware.Metrics(), middleware.Recovery())
 
 	// 健康检查
-	r.GET("/health", func(c *gin.Context) {
-		c.JSON(200, gin.H{
-			"message": "pong",
-		})
-	})
+	probe := &health{}
+	r.GET("/health", probe.Livez) // 兼容旧的健康检查地址
+	r.GET("/livez", probe.Livez)
+	r.GET("/readyz", probe.Readyz)
+
+	// 监控指标（同样不受限流；访问控制见 config.yaml 的 metrics 配置）
+	if conf := config.Config.Metrics; conf.Enabled {
+		r.GET(conf.Path, middleware.MetricsAuth(), gin.WrapH(metrics.Handler()))
+	}
 
-	// 使用全局限流中间件
+	// 允许跨域、使用全局限流中间件（注册在探针之后，探针不受限；跨域在限流之前，429 响应浏览器同样可读）
+	r.Use(middleware.Cors(), middleware.RateLimit())
 
 	// 注册所有路由
 	router.InitRouter(r)
 
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
 	// 启动定时脚本
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	var workers sync.WaitGroup
+	for _, worker := range []func(context.Context){iam.RunAuditRetention, iam.RunAccountDeletionWorker} {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			worker(workerCtx)
+		}()
+	}
 
 	// 2500端口
-	r.Run(fmt.Sprintf("%s:%s", config.Config.Host, config.Config.Port))
+	conf := config.Config.Server
+	srv := &http.Server{
+		Addr:              fmt.Sprintf("%s:%s", config.Config.Host, config.Config.Port),
+		Handler:           r,
+		ReadHeaderTimeout: time.Duration(conf.ReadHeaderTimeout) * time.Second,
+		IdleTimeout:       time.Duration(conf.IdleTimeout) * time.Second,
+		ErrorLog:          logger.StdLogger(slog.LevelWarn),
+	}
+	serveErr := make(chan error, 1)
+	go func() {
+		slog.Info("服务启动", "addr", srv.Addr)
+		serveErr <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serveErr:
+		if !errors.Is(err, http.ErrServerClosed) {
+			slog.Error("服务异常退出", "error", err)
+		}
+	case <-ctx.Done():
+		stop() // 再次收到信号时直接退出
+		slog.Info("收到退出信号，延迟后停止接收请求", "delay", time.Duration(conf.ShutdownDelay)*time.Second)
+		probe.shuttingDown.Store(true)
+		time.Sleep(time.Duration(conf.ShutdownDelay) * time.Second)
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), time.Duration(conf.ShutdownTimeout)*time.Second)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		slog.Warn("等待请求完成超时", "error", err)
+	}
+
+	stopWorkers()
+	done := make(chan struct{})
+	go func() {
+		workers.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-shutdownCtx.Done():
+		slog.Warn("等待后台任务退出超时")
+	}
+
+	if err := db.Close(); err != nil {
+		slog.Error("关闭数据库连接池失败", "error", err)
+	}
+	if err := redis.Close(); err != nil {
+		slog.Error("关闭redis连接池失败", "error", err)
+	}
+	slog.Info("服务已退出")
 }
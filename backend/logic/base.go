@@ -1,15 +1,94 @@
 package logic
 
-import "github.com/gin-gonic/gin"
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"thinkingModels/component/errs"
+	"thinkingModels/domain/iam/auditLog"
+	"thinkingModels/domain/iam/permission"
+)
 
 // todo
 // 这里可以放一些公共信息，如租户信息，用户信息等
 
+// DataAdminPermission 数据管理员权限码，拥有者可越过数据归属校验
+const DataAdminPermission = "DATA_ADMIN"
+
+var (
+	ErrNotLogin = errs.Unauthorized("用户未登录")
+	ErrNotOwner = errs.Forbidden("无权操作他人的数据")
+)
+
 type BaseLogic struct {
 	Ctx      *gin.Context // 上下文
 	CurrUser any          // 当前登陆用户
 }
 
+// CurrUserId 当前登录用户ID，未登录返回0
+func (l *BaseLogic) CurrUserId() uint64 {
+	if l.Ctx == nil {
+		return 0
+	}
+	userId, _ := strconv.ParseUint(l.Ctx.GetString("currUserId"), 10, 64)
+	return userId
+}
+
+// MustCurrUserId 当前登录用户ID，未登录返回错误
+func (l *BaseLogic) MustCurrUserId() (uint64, error) {
+	userId := l.CurrUserId()
+	if userId == 0 {
+		return 0, ErrNotLogin
+	}
+	return userId, nil
+}
+
+// HasPermission 当前用户是否拥有权限码，超级管理员拥有全部权限
+func (l *BaseLogic) HasPermission(code string) bool {
+	if l.Ctx == nil {
+		return false
+	}
+	granted, isSuper, err := permission.CurrPermissions(l.Ctx)
+	if err != nil {
+		return false
+	}
+	return isSuper || granted[code]
+}
+
+// IsSuperAdmin 当前用户是否拥有超级管理员角色
+func (l *BaseLogic) IsSuperAdmin() bool {
+	if l.Ctx == nil {
+		return false
+	}
+	_, isSuper, err := permission.CurrPermissions(l.Ctx)
+	return err == nil && isSuper
+}
+
+// IsDataAdmin 是否可越过数据归属校验
+// 仅超级管理员角色或显式授予 DATA_ADMIN 权限码的角色才可越过
+func (l *BaseLogic) IsDataAdmin() bool {
+	return l.HasPermission(DataAdminPermission)
+}
+
+// CheckOwner 数据归属校验，所有数据必须属于当前用户，数据管理员直接通过
+func (l *BaseLogic) CheckOwner(ownerIds ...uint64) error {
+	userId, err := l.MustCurrUserId()
+	if err != nil {
+		return err
+	}
+	for _, ownerId := range ownerIds {
+		if ownerId != userId && !l.IsDataAdmin() {
+			return ErrNotOwner
+		}
+	}
+	return nil
+}
+
+// Audit 记录审计日志（操作人、IP、trace id 从上下文补全，写入失败不影响业务）
+func (l *BaseLogic) Audit(entry *auditLog.Entry) {
+	auditLog.Record(l.Ctx, entry)
+}
+
 // todo
 
 // 列表返回
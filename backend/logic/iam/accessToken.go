@@ -0,0 +1,140 @@
+package iam
+
+import (
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"thinkingModels/component/db"
+	"thinkingModels/domain/iam/accessToken"
+	"thinkingModels/domain/iam/auditLog"
+	"thinkingModels/logic"
+)
+
+// AccessTokenLogic 个人访问令牌业务逻辑
+type AccessTokenLogic struct {
+	logic.BaseLogic
+}
+
+// 初始化AccessTokenLogic
+func NewAccessTokenLogic(ctx *gin.Context) *AccessTokenLogic {
+	return &AccessTokenLogic{BaseLogic: logic.BaseLogic{Ctx: ctx}}
+}
+
+// Scopes 可授予的权限范围
+func (l *AccessTokenLogic) Scopes() []accessToken.ScopeInfo {
+	return accessToken.Scopes
+}
+
+// Create 为当前用户创建个人访问令牌，令牌原文只在本次返回
+func (l *AccessTokenLogic) Create(req *accessToken.CreateAccessToken) (*accessToken.CreateAccessTokenResponse, error) {
+	userId, err := l.MustCurrUserId()
+	if err != nil {
+		return nil, err
+	}
+	scopes, err := accessToken.NormalizeScopes(req.Scopes)
+	if err != nil {
+		return nil, err
+	}
+	token, hash, prefix, err := accessToken.GenerateToken()
+	if err != nil {
+		return nil, err
+	}
+
+	// 数据赋值（请求中的权限范围为数组，直接写入实体字段）
+	entity := accessToken.NewAccessTokenEntity(l.Ctx)
+	if data, ok := entity.(*accessToken.AccessTokenEntity); ok {
+		data.UserId = userId
+		data.Name = req.Name
+		data.TokenPrefix = prefix
+		data.TokenHash = hash
+		data.Scopes = strings.Join(scopes, ",")
+		data.Status = 1
+		if req.ExpiresInDays > 0 {
+			data.ExpiresAt = db.LocalTime(time.Now().AddDate(0, 0, req.ExpiresInDays))
+		}
+	}
+
+	err = entity.Validate()
+	if err != nil {
+		return nil, err
+	}
+	res, err := entity.Create()
+	if err != nil {
+		return nil, err
+	}
+	l.Audit(&auditLog.Entry{Action: auditLog.ActionTokenCreate, TargetType: auditLog.TargetAccessToken, TargetId: res.Id, After: res})
+
+	return &accessToken.CreateAccessTokenResponse{
+		AccessTokenInfo: convertToAccessTokenInfo(res),
+		Token:           token,
+	}, nil
+}
+
+// ListMy 当前用户的个人访问令牌
+func (l *AccessTokenLogic) ListMy(req *accessToken.SearchAccessToken) (*logic.ListReap, error) {
+	userId, err := l.MustCurrUserId()
+	if err != nil {
+		return nil, err
+	}
+	req.UserId = userId
+	req.TokenHash = ""
+
+	entity := accessToken.NewAccessTokenEntity(l.Ctx)
+	cond := entity.MakeConditon(*req)
+	total, err := entity.Count(cond)
+	if err != nil {
+		return nil, err
+	}
+	list, err := entity.List(cond)
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]accessToken.AccessTokenInfo, 0, len(list))
+	for _, item := range list {
+		infos = append(infos, convertToAccessTokenInfo(item))
+	}
+	return &logic.ListReap{List: infos, Page: req.Page, PageSize: req.PageSize, Total: total}, nil
+}
+
+// Revoke 吊销令牌，只能吊销本人的令牌（数据管理员除外）
+func (l *AccessTokenLogic) Revoke(req *accessToken.RevokeAccessToken) error {
+	entity := accessToken.NewAccessTokenEntity(l.Ctx)
+	list, err := entity.ListByIds(req.Ids)
+	if err != nil {
+		return err
+	}
+
+	ownerIds := make([]uint64, 0, len(list))
+	for _, item := range list {
+		ownerIds = append(ownerIds, item.UserId)
+	}
+	if err = l.CheckOwner(ownerIds...); err != nil {
+		return err
+	}
+
+	for _, item := range list {
+		if err = item.Revoke(); err != nil {
+			return err
+		}
+		l.Audit(&auditLog.Entry{Action: auditLog.ActionTokenRevoke, TargetType: auditLog.TargetAccessToken, TargetId: item.Id, Remark: item.Name})
+	}
+	return nil
+}
+
+// convertToAccessTokenInfo 转换为令牌信息DTO（不含摘要）
+func convertToAccessTokenInfo(m *accessToken.AccessTokenEntity) accessToken.AccessTokenInfo {
+	return accessToken.AccessTokenInfo{
+		Id:          m.Id,
+		Name:        m.Name,
+		TokenPrefix: m.TokenPrefix,
+		Scopes:      m.ScopeList(),
+		ExpiresAt:   m.ExpiresAt.String(),
+		LastUsedAt:  m.LastUsedAt.String(),
+		LastUsedIp:  m.LastUsedIp,
+		Status:      m.Status,
+		Expired:     m.IsExpired(),
+		CreatedAt:   m.CreatedAt.String(),
+	}
+}
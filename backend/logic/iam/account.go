@@ -0,0 +1,279 @@
+package iam
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"thinkingModels/component/archive"
+	"thinkingModels/component/errs"
+	"thinkingModels/component/ratelimit"
+	"thinkingModels/component/redis"
+	"thinkingModels/domain/iam/accessToken"
+	"thinkingModels/domain/iam/accountDeletion"
+	"thinkingModels/domain/iam/auditLog"
+	"thinkingModels/domain/iam/user"
+	"thinkingModels/domain/practice/action"
+	"thinkingModels/domain/practice/analysis"
+	"thinkingModels/domain/practice/followup"
+	"thinkingModels/domain/practice/model"
+	"thinkingModels/domain/practice/topic"
+	"thinkingModels/logic"
+)
+
+const (
+	exportLimitKey         = "limit:user_export:%d" // 导出频率限制
+	exportLimitCycle       = time.Hour              // 限制周期
+	exportLimitCount       = 5                      // 周期内最多导出次数
+	accountDeletionLock    = "lock:account_deletion"
+	accountDeletionLockTTL = 5 * time.Minute // 处理锁有效期，超出前停止领取新任务
+	deletionBatchSize      = 20              // 每轮处理的任务数
+)
+
+var ErrExportTooFrequent = errs.RateLimited("导出过于频繁，请稍后再试")
+
+// accountDeletionTrigger 提交注销后通知后台任务立即处理，无需等到下一分钟
+var accountDeletionTrigger = make(chan struct{}, 1)
+
+// AccountLogic 个人数据导出与注销账号业务逻辑
+type AccountLogic struct {
+	logic.BaseLogic
+}
+
+// 初始化AccountLogic
+func NewAccountLogic(ctx *gin.Context) *AccountLogic {
+	return &AccountLogic{BaseLogic: logic.BaseLogic{Ctx: ctx}}
+}
+
+// Export 导出当前用户的个人数据，返回 ZIP 文件名与内容
+func (l *AccountLogic) Export() (string, []byte, error) {
+	dbUser, err := NewUserLogic(l.Ctx).currUser()
+	if err != nil {
+		return "", nil, err
+	}
+	if !ratelimit.Allow(l.Ctx.Request.Context(), fmt.Sprintf(exportLimitKey, dbUser.Id), exportLimitCount, exportLimitCycle).Allowed {
+		return "", nil, ErrExportTooFrequent
+	}
+
+	data, err := l.loadExportData(dbUser)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var buf bytes.Buffer
+	w := archive.NewWriter(&buf)
+	if err = data.writeArchive(w); err != nil {
+		return "", nil, err
+	}
+	if err = w.Close(); err != nil {
+		return "", nil, err
+	}
+
+	l.Audit(&auditLog.Entry{Action: auditLog.ActionUserExport, TargetType: auditLog.TargetUser, TargetId: dbUser.Id})
+	name := fmt.Sprintf("thinkingModels-export-%s-%s.zip", archive.FileName(dbUser.Username), time.Now().Format("20060102150405"))
+	return name, buf.Bytes(), nil
+}
+
+// loadExportData 查询用户的全部实践数据（不分页，按ID升序）
+func (l *AccountLogic) loadExportData(dbUser *user.UserEntity) (*exportData, error) {
+	byId := func(db *gorm.DB) *gorm.DB {
+		return db.Order("id ASC")
+	}
+	data := &exportData{Profile: convertToUserInfo(dbUser)}
+
+	var err error
+	topicEntity := topic.NewTopicEntity(l.Ctx)
+	data.Topics, err = topicEntity.List(topicEntity.MakeConditon(topic.SearchTopic{Page: 1, PageSize: -1, UserId: dbUser.Id}), byId)
+	if err != nil {
+		return nil, err
+	}
+	analysisEntity := analysis.NewAnalysisEntity(l.Ctx)
+	data.Analyses, err = analysisEntity.List(analysisEntity.MakeConditon(analysis.SearchAnalysis{Page: 1, PageSize: -1, UserId: dbUser.Id}), byId)
+	if err != nil {
+		return nil, err
+	}
+	actionEntity := action.NewActionEntity(l.Ctx)
+	data.Actions, err = actionEntity.List(actionEntity.MakeConditon(action.SearchAction{Page: 1, PageSize: -1, UserId: dbUser.Id}), byId)
+	if err != nil {
+		return nil, err
+	}
+	followUpEntity := followup.NewFollowUpEntity(l.Ctx)
+	data.FollowUps, err = followUpEntity.List(followUpEntity.MakeConditon(followup.SearchFollowUp{Page: 1, PageSize: -1, UserId: dbUser.Id}), byId)
+	if err != nil {
+		return nil, err
+	}
+	modelEntity := model.NewModelEntity(l.Ctx)
+	data.Models, err = modelEntity.List(modelEntity.MakeConditon(model.SearchModel{Page: 1, PageSize: -1, AuthorId: dbUser.Id}), byId)
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// DeleteAccount 注销当前账号：校验密码（及两步验证）后立即停用账号并吊销全部凭据，数据由后台任务清理
+func (l *AccountLogic) DeleteAccount(req *accountDeletion.DeleteAccountRequest) (*accountDeletion.AccountDeletionInfo, error) {
+	dbUser, err := NewUserLogic(l.Ctx).currUser()
+	if err != nil {
+		return nil, err
+	}
+	if !dbUser.VerifyPassword(req.Password) {
+		return nil, errs.Validation("密码错误")
+	}
+	if dbUser.TotpEnabled && !verifySecondFactor(dbUser, req.Code) {
+		return nil, user.ErrTotpCodeInvalid
+	}
+
+	// 已有未完成的任务时直接返回
+	jobEntity := accountDeletion.NewAccountDeletionEntity(l.Ctx)
+	existing, err := jobEntity.LoadData(jobEntity.MakeConditon(accountDeletion.SearchAccountDeletion{UserId: dbUser.Id}))
+	if err == nil && existing.Id > 0 && existing.Status != accountDeletion.StatusFinished {
+		return convertToAccountDeletionInfo(existing), nil
+	}
+
+	// 1. 停用账号并吊销全部凭据：登录Token、会话、个人访问令牌
+	dbUser.Status = user.StatusDisabled
+	if _, err = dbUser.Update(); err != nil {
+		return nil, err
+	}
+	if err = NewUserLogic(l.Ctx).revokeAllSessions(dbUser.Id); err != nil {
+		return nil, err
+	}
+	if err = accessToken.NewAccessTokenEntity(l.Ctx).RevokeByUser(dbUser.Id); err != nil {
+		return nil, err
+	}
+
+	// 2. 创建清理任务
+	b := make([]byte, 16)
+	if _, err = rand.Read(b); err != nil {
+		return nil, err
+	}
+	jobEntity = accountDeletion.NewAccountDeletionEntity(l.Ctx)
+	if job, ok := jobEntity.(*accountDeletion.AccountDeletionEntity); ok {
+		job.JobId = hex.EncodeToString(b)
+		job.UserId = dbUser.Id
+		job.Status = accountDeletion.StatusPending
+	}
+	if err = jobEntity.Validate(); err != nil {
+		return nil, err
+	}
+	res, err := jobEntity.Create()
+	if err != nil {
+		return nil, err
+	}
+	l.Audit(&auditLog.Entry{Action: auditLog.ActionUserDeleteAccount, TargetType: auditLog.TargetUser, TargetId: dbUser.Id, Remark: res.JobId})
+
+	// 3. 通知后台任务立即执行一轮清理，失败的任务由定时任务重试
+	select {
+	case accountDeletionTrigger <- struct{}{}:
+	default:
+	}
+	return convertToAccountDeletionInfo(res), nil
+}
+
+// DeletionStatus 按任务ID查询注销进度（凭据已吊销，无需登录）
+func (l *AccountLogic) DeletionStatus(jobId string) (*accountDeletion.AccountDeletionInfo, error) {
+	res, err := accountDeletion.NewAccountDeletionEntity(l.Ctx).LoadByJobId(jobId)
+	if err != nil || res.Id == 0 {
+		return nil, errs.NotFound("注销任务不存在")
+	}
+	return convertToAccountDeletionInfo(res), nil
+}
+
+// RunAccountDeletionWorker 注销任务处理：启动时执行一次，之后每分钟或收到新任务时执行
+// ctx 取消后处理完当前任务即返回，未处理的任务留给下次启动
+func RunAccountDeletionWorker(ctx context.Context) {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+	for {
+		processAccountDeletions(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-accountDeletionTrigger:
+		}
+	}
+}
+
+// processAccountDeletions 处理排队中及可重试的注销任务（多实例时只允许一个实例执行）
+// GetLock 的有效期上限为10秒，不足以覆盖一轮处理，这里直接以 SetNx 按处理锁有效期加锁
+func processAccountDeletions(ctx context.Context) {
+	ok, err := redis.SetNx(accountDeletionLock, "1", int(accountDeletionLockTTL.Seconds()))
+	if err != nil || !ok {
+		return
+	}
+	defer redis.Del(accountDeletionLock)
+	deadline := time.Now().Add(accountDeletionLockTTL - time.Minute)
+
+	jobs, err := accountDeletion.NewAccountDeletionEntity(nil).ListRetryable(deletionBatchSize)
+	if err != nil {
+		slog.Error("查询注销任务失败", "error", err)
+		return
+	}
+	for _, job := range jobs {
+		// 锁即将过期时留给下一轮，避免与其他实例同时执行
+		if ctx.Err() != nil || time.Now().After(deadline) {
+			return
+		}
+		runAccountDeletion(job)
+	}
+}
+
+// runAccountDeletion 执行单个注销任务
+func runAccountDeletion(job *accountDeletion.AccountDeletionEntity) {
+	// 列表查询的结果不带数据库连接，重新加载后再更新
+	entity, err := accountDeletion.NewAccountDeletionEntity(nil).LoadById(job.Id)
+	if err != nil {
+		slog.Error("加载注销任务失败", "job_id", job.JobId, "error", err)
+		return
+	}
+	if err = entity.Start(); err != nil {
+		slog.Error("注销任务启动失败", "job_id", entity.JobId, "error", err)
+		return
+	}
+
+	// 再次吊销凭据，覆盖申请后到执行前 Redis 故障等情况
+	_ = user.RevokeUserTokensBefore(entity.UserId, time.Now())
+
+	stats, err := entity.Erase()
+	if err != nil {
+		slog.Error("注销任务执行失败", "job_id", entity.JobId, "attempts", entity.Attempts, "error", err)
+		if err = entity.Fail(err); err != nil {
+			slog.Error("注销任务状态更新失败", "job_id", entity.JobId, "error", err)
+		}
+		return
+	}
+	if err = entity.Finish(stats); err != nil {
+		slog.Error("注销任务状态更新失败", "job_id", entity.JobId, "error", err)
+		return
+	}
+	auditLog.Record(nil, &auditLog.Entry{
+		Action:     auditLog.ActionUserErased,
+		TargetType: auditLog.TargetUser,
+		TargetId:   entity.UserId,
+		ActorName:  "系统",
+		Remark:     entity.JobId,
+	})
+}
+
+// convertToAccountDeletionInfo 转换为注销进度DTO
+func convertToAccountDeletionInfo(m *accountDeletion.AccountDeletionEntity) *accountDeletion.AccountDeletionInfo {
+	info := &accountDeletion.AccountDeletionInfo{
+		JobId:      m.JobId,
+		Status:     m.Status,
+		StatusText: m.StatusText(),
+		CreatedAt:  m.CreatedAt.String(),
+		FinishedAt: m.FinishedAt.String(),
+	}
+	if m.Stats != "" && json.Valid([]byte(m.Stats)) {
+		info.Stats = json.RawMessage(m.Stats)
+	}
+	return info
+}
@@ -0,0 +1,118 @@
+package iam
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"thinkingModels/component/redis"
+	"thinkingModels/config"
+	"thinkingModels/domain/iam/auditLog"
+	"thinkingModels/logic"
+)
+
+// auditPurgeLock 多实例部署时只允许一个实例执行清理
+const auditPurgeLock = "lock:audit_purge"
+
+// AuditLogLogic 审计日志业务逻辑（只读，审计日志不可修改和删除）
+type AuditLogLogic struct {
+	logic.BaseLogic
+}
+
+// 初始化AuditLogLogic
+func NewAuditLogLogic(ctx *gin.Context) *AuditLogLogic {
+	return &AuditLogLogic{BaseLogic: logic.BaseLogic{Ctx: ctx}}
+}
+
+// List 查询审计日志（按时间倒序）
+func (l *AuditLogLogic) List(req *auditLog.SearchAuditLog) (*logic.ListReap, error) {
+	entity := auditLog.NewAuditLogEntity(l.Ctx)
+	cond := entity.MakeConditon(*req)
+	total, err := entity.Count(cond)
+	if err != nil {
+		return nil, err
+	}
+	list, err := entity.List(cond, func(db *gorm.DB) *gorm.DB {
+		return db.Order("id DESC")
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]auditLog.AuditLogInfo, 0, len(list))
+	for _, item := range list {
+		infos = append(infos, convertToAuditLogInfo(item))
+	}
+	return &logic.ListReap{List: infos, Page: req.Page, PageSize: req.PageSize, Total: total}, nil
+}
+
+// Get 查询审计日志详情
+func (l *AuditLogLogic) Get(id uint64) (*auditLog.AuditLogInfo, error) {
+	res, err := auditLog.NewAuditLogEntity(l.Ctx).LoadById(id)
+	if err != nil {
+		return nil, err
+	}
+	info := convertToAuditLogInfo(res)
+	return &info, nil
+}
+
+// RunAuditRetention 审计日志清理任务：启动时执行一次，之后每天执行一次，ctx 取消后返回
+func RunAuditRetention(ctx context.Context) {
+	if config.Config.Audit.RetentionDays <= 0 {
+		return
+	}
+	ticker := time.NewTicker(24 * time.Hour)
+	defer ticker.Stop()
+	for {
+		purgeAuditLogs(config.Config.Audit.RetentionDays)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// purgeAuditLogs 删除超过保留天数的审计日志
+func purgeAuditLogs(retentionDays int) {
+	conn, ok, err := redis.GetLock(auditPurgeLock, 10)
+	if err != nil || !ok {
+		conn.Close()
+		return
+	}
+	defer redis.Unlock(conn, auditPurgeLock)
+
+	before := time.Now().AddDate(0, 0, -retentionDays)
+	count, err := auditLog.NewAuditLogEntity(nil).Purge(before)
+	if err != nil {
+		slog.Error("清理审计日志失败", "error", err)
+		return
+	}
+	if count > 0 {
+		slog.Info("已清理过期审计日志", "before", before.Format(time.DateOnly), "count", count)
+	}
+}
+
+// convertToAuditLogInfo 转换为审计日志DTO
+func convertToAuditLogInfo(m *auditLog.AuditLogEntity) auditLog.AuditLogInfo {
+	info := auditLog.AuditLogInfo{
+		Id:         m.Id,
+		ActorId:    m.ActorId,
+		ActorName:  m.ActorName,
+		Action:     m.Action,
+		TargetType: m.TargetType,
+		TargetId:   m.TargetId,
+		Ip:         m.Ip,
+		UserAgent:  m.UserAgent,
+		TraceId:    m.TraceId,
+		Remark:     m.Remark,
+		CreatedAt:  m.CreatedAt.String(),
+	}
+	if m.Diff != "" && json.Valid([]byte(m.Diff)) {
+		info.Diff = json.RawMessage(m.Diff)
+	}
+	return info
+}
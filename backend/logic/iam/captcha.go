@@ -0,0 +1,16 @@
+package iam
+
+import "thinkingModels/domain/iam/user"
+
+// Captcha 获取人机验证码
+func (l *UserLogic) Captcha(req *user.CaptchaRequest) (*user.CaptchaResponse, error) {
+	return user.IssueCaptcha(req.Kind)
+}
+
+// CaptchaStatus 当前IP登录、注册是否需要人机验证
+func (l *UserLogic) CaptchaStatus() *user.CaptchaStatus {
+	return &user.CaptchaStatus{
+		Login:    user.LoginCaptchaRequired(l.Ctx.ClientIP()),
+		Register: user.RegisterCaptchaRequired(),
+	}
+}
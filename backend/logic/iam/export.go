@@ -0,0 +1,211 @@
+package iam
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"thinkingModels/component/archive"
+	"thinkingModels/domain/iam/user"
+	"thinkingModels/domain/practice/action"
+	"thinkingModels/domain/practice/analysis"
+	"thinkingModels/domain/practice/followup"
+	"thinkingModels/domain/practice/model"
+	"thinkingModels/domain/practice/topic"
+)
+
+// exportData 个人数据导出内容
+type exportData struct {
+	Profile   *user.UserInfo
+	Topics    []*topic.TopicEntity
+	Analyses  []*analysis.AnalysisEntity
+	Actions   []*action.ActionEntity
+	FollowUps []*followup.FollowUpEntity
+	Models    []*model.ModelEntity
+}
+
+// exportReadme 归档说明
+const exportReadme = `# 个人数据导出
+
+- profile.json：账号信息
+- topics.json / analyses.json / actions.json / followups.json：课题、全部分析版本、行动与跟进记录
+- models.json：本人创作的思维模型
+- topics/*.md：每个课题一份 Markdown，包含其分析版本、行动与跟进记录
+- models/*.md：每个思维模型一份 Markdown
+`
+
+var (
+	topicStatusText    = []string{"草稿", "进行中", "已完成", "已归档"}
+	analysisStatusText = []string{"草稿", "分析中", "已完成", "失败"}
+	actionStatusText   = []string{"待执行", "进行中", "已完成", "已取消"}
+	modelStatusText    = []string{"草稿", "已发布", "已下架"}
+	priorityText       = []string{"", "低", "中", "高"}
+	difficultyText     = []string{"", "简单", "中等", "困难"}
+)
+
+// writeArchive 写入 JSON 原始数据与 Markdown 阅读版本
+func (d *exportData) writeArchive(w *archive.Writer) error {
+	files := []struct {
+		name string
+		data any
+	}{
+		{"profile.json", d.Profile},
+		{"topics.json", d.Topics},
+		{"analyses.json", d.Analyses},
+		{"actions.json", d.Actions},
+		{"followups.json", d.FollowUps},
+		{"models.json", d.Models},
+	}
+	if err := w.AddFile("README.md", []byte(exportReadme)); err != nil {
+		return err
+	}
+	for _, f := range files {
+		if err := w.AddJSON(f.name, f.data); err != nil {
+			return err
+		}
+	}
+	for _, t := range d.Topics {
+		name := "topics/" + archive.FileName(strconv.FormatUint(t.Id, 10), t.Title) + ".md"
+		if err := w.AddFile(name, []byte(d.topicMarkdown(t))); err != nil {
+			return err
+		}
+	}
+	for _, m := range d.Models {
+		name := "models/" + archive.FileName(strconv.FormatUint(m.Id, 10), m.Name) + ".md"
+		if err := w.AddFile(name, []byte(modelMarkdown(m))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// topicMarkdown 课题及其分析版本、行动、跟进记录
+func (d *exportData) topicMarkdown(t *topic.TopicEntity) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", t.Title)
+	fmt.Fprintf(&b, "- 状态：%s\n- 优先级：%s\n", textOf(topicStatusText, t.Status), textOf(priorityText, t.Priority))
+	writeItem(&b, "选用模型", t.ModelName)
+	writeItem(&b, "截止日期", t.Deadline.String())
+	writeItem(&b, "创建时间", t.CreatedAt.String())
+	b.WriteString("\n")
+	writeSection(&b, "##", "描述", t.Description)
+	writeSection(&b, "##", "背景", t.Background)
+	writeSection(&b, "##", "期望目标", t.Goal)
+	writeSection(&b, "##", "约束条件", t.Constraints)
+
+	analyses := make([]*analysis.AnalysisEntity, 0)
+	for _, a := range d.Analyses {
+		if a.TopicId == t.Id {
+			analyses = append(analyses, a)
+		}
+	}
+	if len(analyses) > 0 {
+		b.WriteString("## 分析记录\n\n")
+		for _, a := range analyses {
+			current := ""
+			if a.IsCurrent {
+				current = "（当前版本）"
+			}
+			fmt.Fprintf(&b, "### 版本 %d%s\n\n", a.Version, current)
+			fmt.Fprintf(&b, "- 状态：%s\n", textOf(analysisStatusText, a.Status))
+			writeItem(&b, "使用模型", a.ModelName)
+			writeItem(&b, "创建时间", a.CreatedAt.String())
+			b.WriteString("\n")
+			writeSection(&b, "####", "结论", a.Conclusion)
+			writeCode(&b, "输入内容", a.InputContent)
+			writeCode(&b, "AI 分析结果", a.AiResult)
+			writeCode(&b, "我的修改", a.UserResult)
+		}
+	}
+
+	actions := make([]*action.ActionEntity, 0)
+	for _, a := range d.Actions {
+		if a.TopicId == t.Id {
+			actions = append(actions, a)
+		}
+	}
+	if len(actions) > 0 {
+		b.WriteString("## 行动\n\n")
+		for _, a := range actions {
+			fmt.Fprintf(&b, "### %s\n\n", a.Title)
+			fmt.Fprintf(&b, "- 状态：%s\n- 进度：%d%%\n- 优先级：%s\n", textOf(actionStatusText, a.Status), a.Progress, textOf(priorityText, a.Priority))
+			writeItem(&b, "截止日期", a.Deadline.String())
+			writeItem(&b, "完成时间", a.CompletedAt.String())
+			b.WriteString("\n")
+			writeSection(&b, "####", "描述", a.Description)
+			writeSection(&b, "####", "预期结果", a.ExpectedResult)
+			writeSection(&b, "####", "实际结果", a.ActualResult)
+			d.writeFollowUps(&b, a.Id)
+		}
+	}
+	return b.String()
+}
+
+// writeFollowUps 行动的跟进记录
+func (d *exportData) writeFollowUps(b *strings.Builder, actionId uint64) {
+	header := false
+	for _, f := range d.FollowUps {
+		if f.ActionId != actionId {
+			continue
+		}
+		if !header {
+			b.WriteString("#### 跟进记录\n\n")
+			header = true
+		}
+		fmt.Fprintf(b, "- %s（进度 %d%% → %d%%）：%s\n", f.CreatedAt.String(), f.ProgressBefore, f.ProgressAfter, f.Content)
+	}
+	if header {
+		b.WriteString("\n")
+	}
+}
+
+// modelMarkdown 思维模型
+func modelMarkdown(m *model.ModelEntity) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", m.Name)
+	fmt.Fprintf(&b, "- 状态：%s\n", textOf(modelStatusText, m.Status))
+	writeItem(&b, "编码", m.Code)
+	writeItem(&b, "版本", m.Version)
+	writeItem(&b, "难度", textOf(difficultyText, m.Difficulty))
+	if m.EstimatedTime > 0 {
+		fmt.Fprintf(&b, "- 预计用时：%d 分钟\n", m.EstimatedTime)
+	}
+	writeItem(&b, "创建时间", m.CreatedAt.String())
+	b.WriteString("\n")
+	writeSection(&b, "##", "简介", m.Description)
+	writeSection(&b, "##", "概述", m.Overview)
+	writeSection(&b, "##", "使用指南", m.UsageGuide)
+	writeCode(&b, "模型内容", m.Content)
+	writeCode(&b, "案例", m.Examples)
+	writeSection(&b, "##", "AI 提示词模板", m.AiPrompt)
+	return b.String()
+}
+
+// writeItem 非空时写入列表项
+func writeItem(b *strings.Builder, label, value string) {
+	if value != "" {
+		fmt.Fprintf(b, "- %s：%s\n", label, value)
+	}
+}
+
+// writeSection 非空时写入段落
+func writeSection(b *strings.Builder, level, title, content string) {
+	if strings.TrimSpace(content) != "" {
+		fmt.Fprintf(b, "%s %s\n\n%s\n\n", level, title, strings.TrimSpace(content))
+	}
+}
+
+// writeCode 非空时以 JSON 代码块写入（结构化内容原样保留）
+func writeCode(b *strings.Builder, title, content string) {
+	if strings.TrimSpace(content) != "" {
+		fmt.Fprintf(b, "#### %s\n\n```json\n%s\n```\n\n", title, strings.TrimSpace(content))
+	}
+}
+
+// textOf 按枚举值取描述，越界时返回数值本身
+func textOf(texts []string, value int) string {
+	if value >= 0 && value < len(texts) && texts[value] != "" {
+		return texts[value]
+	}
+	return strconv.Itoa(value)
+}
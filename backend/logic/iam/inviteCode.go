@@ -0,0 +1,217 @@
+package iam
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"thinkingModels/component/db"
+	"thinkingModels/component/errs"
+	"thinkingModels/domain/iam/auditLog"
+	"thinkingModels/domain/iam/inviteCode"
+	"thinkingModels/domain/iam/user"
+	"thinkingModels/logic"
+)
+
+// InviteCodeLogic 邀请码管理业务逻辑
+type InviteCodeLogic struct {
+	logic.BaseLogic
+}
+
+// 初始化InviteCodeLogic
+func NewInviteCodeLogic(ctx *gin.Context) *InviteCodeLogic {
+	return &InviteCodeLogic{BaseLogic: logic.BaseLogic{Ctx: ctx}}
+}
+
+// Create 创建邀请码：指定邀请码时创建一个，否则按数量随机生成
+func (l *InviteCodeLogic) Create(req *inviteCode.CreateInviteCode) ([]inviteCode.InviteCodeInfo, error) {
+	count := max(req.Count, 1)
+	if req.Code != "" && count > 1 {
+		return nil, errs.Validation("自定义邀请码时只能创建一个")
+	}
+	expiresAt, err := parseExpiresAt(req.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	// 邀请人默认为当前管理员
+	ownerId := req.OwnerId
+	if ownerId == 0 {
+		if ownerId, err = l.MustCurrUserId(); err != nil {
+			return nil, err
+		}
+	} else if owner, err := user.NewUserEntity(l.Ctx).LoadById(ownerId); err != nil || owner.Id == 0 {
+		return nil, errs.NotFound("邀请人不存在")
+	}
+
+	infos := make([]inviteCode.InviteCodeInfo, 0, count)
+	for i := 0; i < count; i++ {
+		code, err := l.uniqueCode(req.Code)
+		if err != nil {
+			return nil, err
+		}
+
+		entity := inviteCode.NewInviteCodeEntity(l.Ctx)
+		if data, ok := entity.(*inviteCode.InviteCodeEntity); ok {
+			data.Code = code
+			data.OwnerId = ownerId
+			data.MaxUses = req.MaxUses
+			data.ExpiresAt = expiresAt
+			data.Status = 1
+			data.Remark = req.Remark
+		}
+		if err = entity.Repair(); err != nil {
+			return nil, err
+		}
+		if err = entity.Validate(); err != nil {
+			return nil, err
+		}
+		res, err := entity.Create()
+		if err != nil {
+			return nil, err
+		}
+		l.Audit(&auditLog.Entry{Action: auditLog.ActionInviteCreate, TargetType: auditLog.TargetInviteCode, TargetId: res.Id, After: res})
+		infos = append(infos, convertToInviteCodeInfo(res))
+	}
+	return infos, nil
+}
+
+// uniqueCode 校验自定义邀请码唯一，或随机生成一个未被占用的邀请码
+func (l *InviteCodeLogic) uniqueCode(custom string) (string, error) {
+	entity := inviteCode.NewInviteCodeEntity(l.Ctx)
+	if custom != "" {
+		code := inviteCode.NormalizeCode(custom)
+		exists, err := entity.CheckBusinessCodeExist("code", code)
+		if err != nil {
+			return "", err
+		}
+		if exists {
+			return "", errs.Conflict("邀请码已存在")
+		}
+		return code, nil
+	}
+
+	for i := 0; i < 5; i++ {
+		code, err := inviteCode.GenerateCode()
+		if err != nil {
+			return "", err
+		}
+		exists, err := entity.CheckBusinessCodeExist("code", code)
+		if err != nil {
+			return "", err
+		}
+		if !exists {
+			return code, nil
+		}
+	}
+	return "", errs.New(errs.CodeInternal, "邀请码生成失败，请重试")
+}
+
+// Update 更新邀请码（次数上限、过期时间、状态、备注）
+func (l *InviteCodeLogic) Update(req *inviteCode.UpdateInviteCode) (*inviteCode.InviteCodeInfo, error) {
+	expiresAt, err := parseExpiresAt(req.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	entity := inviteCode.NewInviteCodeEntity(l.Ctx)
+	old, err := entity.LoadById(req.Id)
+	if err != nil {
+		return nil, err
+	}
+	before := auditLog.Snapshot(old)
+
+	old.MaxUses = req.MaxUses
+	old.ExpiresAt = expiresAt
+	old.Status = req.Status
+	old.Remark = req.Remark
+	if err = old.Validate(); err != nil {
+		return nil, err
+	}
+	res, err := old.Update()
+	if err != nil {
+		return nil, err
+	}
+	l.Audit(&auditLog.Entry{Action: auditLog.ActionInviteUpdate, TargetType: auditLog.TargetInviteCode, TargetId: res.Id, Before: before, After: res})
+
+	info := convertToInviteCodeInfo(res)
+	return &info, nil
+}
+
+// Get 查询邀请码详情
+func (l *InviteCodeLogic) Get(id uint64) (*inviteCode.InviteCodeInfo, error) {
+	res, err := inviteCode.NewInviteCodeEntity(l.Ctx).LoadById(id)
+	if err != nil {
+		return nil, err
+	}
+	info := convertToInviteCodeInfo(res)
+	return &info, nil
+}
+
+// List 查询邀请码列表
+func (l *InviteCodeLogic) List(req *inviteCode.SearchInviteCode) (*logic.ListReap, error) {
+	req.Code = inviteCode.NormalizeCode(req.Code)
+
+	entity := inviteCode.NewInviteCodeEntity(l.Ctx)
+	cond := entity.MakeConditon(*req)
+	total, err := entity.Count(cond)
+	if err != nil {
+		return nil, err
+	}
+	list, err := entity.List(cond)
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]inviteCode.InviteCodeInfo, 0, len(list))
+	for _, item := range list {
+		infos = append(infos, convertToInviteCodeInfo(item))
+	}
+	return &logic.ListReap{List: infos, Page: req.Page, PageSize: req.PageSize, Total: total}, nil
+}
+
+// Del 删除邀请码（已注册用户的邀请关系保留）
+func (l *InviteCodeLogic) Del(req *inviteCode.DelInviteCode) (any, error) {
+	entity := inviteCode.NewInviteCodeEntity(l.Ctx)
+	list, err := entity.ListByIds(req.Ids)
+	if err != nil {
+		return nil, err
+	}
+	if err = entity.Del(req.Ids...); err != nil {
+		return nil, err
+	}
+	for _, item := range list {
+		l.Audit(&auditLog.Entry{Action: auditLog.ActionInviteDelete, TargetType: auditLog.TargetInviteCode, TargetId: item.Id, Before: item})
+	}
+	return nil, nil
+}
+
+// parseExpiresAt 解析过期时间，为空表示永不过期
+func parseExpiresAt(value string) (db.LocalTime, error) {
+	if value == "" {
+		return db.LocalTime{}, nil
+	}
+	t, err := time.ParseInLocation("2006-01-02 15:04:05", value, time.Local)
+	if err != nil {
+		return db.LocalTime{}, errs.Validation("过期时间格式错误")
+	}
+	if t.Before(time.Now()) {
+		return db.LocalTime{}, errs.Validation("过期时间不能早于当前时间")
+	}
+	return db.LocalTime(t), nil
+}
+
+// convertToInviteCodeInfo 转换为邀请码信息DTO
+func convertToInviteCodeInfo(m *inviteCode.InviteCodeEntity) inviteCode.InviteCodeInfo {
+	return inviteCode.InviteCodeInfo{
+		Id:        m.Id,
+		Code:      m.Code,
+		OwnerId:   m.OwnerId,
+		MaxUses:   m.MaxUses,
+		UsedCount: m.UsedCount,
+		ExpiresAt: m.ExpiresAt.String(),
+		Status:    m.Status,
+		Usable:    m.IsUsable(),
+		Remark:    m.Remark,
+		CreatedAt: m.CreatedAt.String(),
+	}
+}
@@ -0,0 +1,14 @@
+package iam
+
+import (
+	"thinkingModels/component/jwtkey"
+)
+
+// Jwks 公开的JWT校验公钥（仅 RS256/EdDSA 密钥）
+func (l *UserLogic) Jwks() (*jwtkey.JWKS, error) {
+	keys, err := jwtkey.Default()
+	if err != nil {
+		return nil, err
+	}
+	return keys.JWKS(), nil
+}
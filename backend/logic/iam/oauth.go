@@ -0,0 +1,227 @@
+package iam
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/gin-gonic/gin"
+	"thinkingModels/component/errs"
+	"thinkingModels/component/oauth"
+	"thinkingModels/domain/iam/auditLog"
+	"thinkingModels/domain/iam/user"
+	"thinkingModels/domain/iam/userIdentity"
+	"thinkingModels/logic"
+)
+
+var (
+	ErrOauthProvider = errs.NotFound("不支持的登录方式")
+	ErrOauthFailed   = errs.Validation("第三方登录失败，请重新发起")
+)
+
+// OauthLogic 第三方登录(OAuth2/OIDC)业务逻辑
+type OauthLogic struct {
+	logic.BaseLogic
+}
+
+// 初始化OauthLogic
+func NewOauthLogic(ctx *gin.Context) *OauthLogic {
+	return &OauthLogic{BaseLogic: logic.BaseLogic{Ctx: ctx}}
+}
+
+// Providers 可用的第三方登录方式
+func (l *OauthLogic) Providers() *userIdentity.OauthProviders {
+	return &userIdentity.OauthProviders{Providers: oauth.ProviderNames()}
+}
+
+// Authorize 发起授权：生成 state、nonce 与 PKCE，返回提供方授权地址
+func (l *OauthLogic) Authorize(providerName string) (string, error) {
+	provider, err := oauth.GetProvider(providerName)
+	if err != nil {
+		return "", ErrOauthProvider.Wrap(err)
+	}
+
+	state, err := oauth.RandomString()
+	if err != nil {
+		return "", err
+	}
+	nonce, err := oauth.RandomString()
+	if err != nil {
+		return "", err
+	}
+	verifier, challenge, err := oauth.NewPKCE()
+	if err != nil {
+		return "", err
+	}
+
+	err = userIdentity.SaveOauthState(state, &userIdentity.OauthState{
+		Provider: providerName,
+		Verifier: verifier,
+		Nonce:    nonce,
+	})
+	if err != nil {
+		return "", err
+	}
+	return provider.AuthCodeURL(l.Ctx.Request.Context(), state, nonce, challenge)
+}
+
+// Callback 处理提供方回调：换取令牌、解析身份、关联本地用户并签发登录Token
+// 返回结果与密码登录一致
+func (l *OauthLogic) Callback(providerName string, req *userIdentity.OauthCallbackRequest) (*user.LoginResponse, error) {
+	provider, err := oauth.GetProvider(providerName)
+	if err != nil {
+		return nil, ErrOauthProvider.Wrap(err)
+	}
+
+	// 1. 校验并作废 state（防CSRF、防重放）
+	state, err := userIdentity.ConsumeOauthState(req.State)
+	if err != nil {
+		return nil, err
+	}
+	if state.Provider != providerName {
+		return nil, userIdentity.ErrOauthStateInvalid
+	}
+	if req.Error != "" {
+		msg := req.ErrorDescription
+		if msg == "" {
+			msg = req.Error
+		}
+		return nil, errs.Validation("第三方授权失败: " + msg)
+	}
+	if req.Code == "" {
+		return nil, errs.Validation("缺少授权码")
+	}
+
+	// 2. 授权码 + code_verifier 换取令牌，解析第三方身份
+	ctx := l.Ctx.Request.Context()
+	token, err := provider.Exchange(ctx, req.Code, state.Verifier)
+	if err != nil {
+		return nil, ErrOauthFailed.Wrap(err)
+	}
+	identity, err := provider.Identity(ctx, token, state.Nonce)
+	if err != nil {
+		return nil, ErrOauthFailed.Wrap(err)
+	}
+
+	// 3. 关联本地用户
+	dbUser, err := l.resolveUser(providerName, identity)
+	if err != nil {
+		return nil, err
+	}
+	if dbUser.Status != user.StatusActive {
+		return nil, errs.Forbidden("账号已被禁用")
+	}
+
+	// 4. 签发Token（已启用两步验证时返回挑战令牌）
+	return NewUserLogic(l.Ctx).completeLogin(dbUser, l.Ctx.ClientIP())
+}
+
+// resolveUser 查找第三方身份对应的本地用户
+// 已绑定直接返回；未绑定时按已验证邮箱关联已有用户，仍找不到则自动注册，并记录绑定关系
+func (l *OauthLogic) resolveUser(providerName string, identity *oauth.Identity) (*user.UserEntity, error) {
+	identityEntity := userIdentity.NewUserIdentityEntity(l.Ctx)
+	cond := identityEntity.MakeConditon(userIdentity.SearchUserIdentity{Provider: providerName, Subject: identity.Subject})
+	bound, err := identityEntity.LoadData(cond)
+	if err == nil && bound.Id > 0 {
+		return user.NewUserEntity(l.Ctx).LoadById(bound.UserId)
+	}
+
+	// 未验证的邮箱不可信，不能用于关联已有账号
+	var dbUser *user.UserEntity
+	if identity.EmailVerified {
+		userEntity := user.NewUserEntity(l.Ctx)
+		found, err := userEntity.LoadData(userEntity.MakeConditon(user.SearchUser{Email: identity.Email}))
+		if err == nil && found.Id > 0 {
+			// 邮箱归属未经确认的账号不能关联（防止他人抢先注册或改填该邮箱后接管第三方登录）
+			if !found.EmailVerified {
+				return nil, errs.Conflict("该邮箱已被注册但尚未验证，请先完成邮箱验证")
+			}
+			dbUser = found
+		}
+	}
+	if dbUser == nil {
+		dbUser, err = l.register(providerName, identity)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// 记录绑定关系
+	_, err = identityEntity.SetData(&userIdentity.CreateUserIdentity{
+		UserId:   dbUser.Id,
+		Provider: providerName,
+		Subject:  identity.Subject,
+		Email:    identity.Email,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err = identityEntity.Validate(); err != nil {
+		return nil, err
+	}
+	if _, err = identityEntity.Create(); err != nil {
+		return nil, err
+	}
+	l.Audit(&auditLog.Entry{
+		Action:     auditLog.ActionOauthLink,
+		TargetType: auditLog.TargetUser,
+		TargetId:   dbUser.Id,
+		ActorId:    dbUser.Id,
+		ActorName:  dbUser.Username,
+		Remark:     providerName + ":" + identity.Subject,
+	})
+	return dbUser, nil
+}
+
+// register 为第三方身份自动注册本地用户（随机用户名与密码，可后续通过重置密码设置）
+// 仅限邀请注册时不自动注册
+func (l *OauthLogic) register(providerName string, identity *oauth.Identity) (*user.UserEntity, error) {
+	if user.InviteRequired() {
+		return nil, errs.Validation("当前仅支持凭邀请码注册，请先注册账号")
+	}
+	b := make([]byte, 4)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+	password, err := oauth.RandomString()
+	if err != nil {
+		return nil, err
+	}
+
+	req := &user.RegisterRequest{
+		Username: providerName + "_" + hex.EncodeToString(b),
+		Password: password,
+		Nickname: truncateRunes(identity.Name, 50),
+	}
+	if identity.EmailVerified {
+		req.Email = identity.Email
+	}
+
+	userEntity := user.NewUserEntity(l.Ctx)
+	_, err = userEntity.SetData(req)
+	if err != nil {
+		return nil, err
+	}
+	if entity, ok := userEntity.(*user.UserEntity); ok {
+		entity.Status = user.StatusActive
+		entity.EmailVerified = req.Email != ""
+		if len(identity.Avatar) <= 255 {
+			entity.Avatar = identity.Avatar
+		}
+	}
+	if err = userEntity.Validate(); err != nil {
+		return nil, err
+	}
+	if err = userEntity.HashPassword(); err != nil {
+		return nil, err
+	}
+	return userEntity.Create()
+}
+
+// truncateRunes 按字符截断
+func truncateRunes(s string, max int) string {
+	runes := []rune(s)
+	if len(runes) > max {
+		return string(runes[:max])
+	}
+	return s
+}
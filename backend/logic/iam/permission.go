@@ -0,0 +1,140 @@
+package iam
+
+import (
+	"github.com/gin-gonic/gin"
+	"thinkingModels/component/errs"
+	"thinkingModels/domain/iam/auditLog"
+	"thinkingModels/domain/iam/permission"
+	"thinkingModels/domain/iam/rolePermission"
+	"thinkingModels/logic"
+)
+
+// PermissionLogic 权限业务逻辑
+type PermissionLogic struct {
+	logic.BaseLogic
+}
+
+// NewPermissionLogic 初始化PermissionLogic
+func NewPermissionLogic(ctx *gin.Context) *PermissionLogic {
+	return &PermissionLogic{BaseLogic: logic.BaseLogic{Ctx: ctx}}
+}
+
+// Create 创建权限
+func (l *PermissionLogic) Create(req *permission.CreatePermission) (*permission.PermissionEntity, error) {
+	entity := permission.NewPermissionEntity(l.Ctx)
+
+	// 校验权限码唯一性
+	exists, err := entity.CheckBusinessCodeExist("code", req.Code)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, errs.Conflict("权限码已存在")
+	}
+
+	_, err = entity.SetData(req)
+	if err != nil {
+		return nil, err
+	}
+
+	err = entity.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := entity.Create()
+	if err != nil {
+		return nil, err
+	}
+	l.Audit(&auditLog.Entry{Action: auditLog.ActionPermissionCreate, TargetType: auditLog.TargetPermission, TargetId: res.Id, After: res})
+	return res, nil
+}
+
+// Update 更新权限
+func (l *PermissionLogic) Update(req *permission.UpdatePermission) (*permission.PermissionEntity, error) {
+	entity := permission.NewPermissionEntity(l.Ctx)
+
+	old, err := entity.LoadById(req.Id)
+	if err != nil {
+		return nil, err
+	}
+	before := auditLog.Snapshot(old)
+
+	_, err = entity.SetData(req)
+	if err != nil {
+		return nil, err
+	}
+
+	err = entity.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := entity.Update()
+	if err != nil {
+		return nil, err
+	}
+	l.Audit(&auditLog.Entry{Action: auditLog.ActionPermissionUpdate, TargetType: auditLog.TargetPermission, TargetId: res.Id, Before: before, After: res})
+	return res, nil
+}
+
+// Get 查询权限详情
+func (l *PermissionLogic) Get(id uint64) (*permission.PermissionEntity, error) {
+	entity := permission.NewPermissionEntity(l.Ctx)
+	return entity.LoadById(id)
+}
+
+// List 查询权限列表
+func (l *PermissionLogic) List(req *permission.SearchPermission) (*permission.ListReap, error) {
+	entity := permission.NewPermissionEntity(l.Ctx)
+	cond := entity.MakeConditon(*req)
+
+	total, err := entity.Count(cond)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := entity.List(cond)
+	if err != nil {
+		return nil, err
+	}
+
+	return &permission.ListReap{
+		Page:     req.Page,
+		PageSize: req.PageSize,
+		Total:    total,
+		List:     list,
+	}, nil
+}
+
+// All 获取全部权限
+func (l *PermissionLogic) All() (*permission.AllReap, error) {
+	entity := permission.NewPermissionEntity(l.Ctx)
+	list, err := entity.All()
+	if err != nil {
+		return nil, err
+	}
+	return &permission.AllReap{List: list}, nil
+}
+
+// Del 删除权限（同时清理角色权限关联）
+func (l *PermissionLogic) Del(req *permission.DelPermission) (any, error) {
+	entity := permission.NewPermissionEntity(l.Ctx)
+	list, err := entity.ListByIds(req.Ids)
+	if err != nil {
+		return nil, err
+	}
+	err = entity.Del(req.Ids...)
+	if err != nil {
+		return nil, err
+	}
+
+	err = rolePermission.NewRolePermissionEntity(l.Ctx).DeleteByPermissionIds(req.Ids...)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range list {
+		l.Audit(&auditLog.Entry{Action: auditLog.ActionPermissionDelete, TargetType: auditLog.TargetPermission, TargetId: item.Id, Before: item})
+	}
+	return nil, nil
+}
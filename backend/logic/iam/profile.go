@@ -0,0 +1,106 @@
+package iam
+
+import (
+	"thinkingModels/component/errs"
+	"thinkingModels/domain/iam/user"
+	"thinkingModels/domain/iam/userFollow"
+	"thinkingModels/domain/practice/model"
+	thinking "thinkingModels/logic/practice"
+)
+
+var ErrUserNotFound = errs.NotFound("用户不存在")
+
+// Profile 用户公开主页：公开资料、粉丝数、已发布模型及其汇总数据
+func (l *UserLogic) Profile(id uint64, req *user.ProfileRequest) (*user.UserProfile, error) {
+	dbUser, err := l.publicUser(id)
+	if err != nil {
+		return nil, err
+	}
+
+	followEntity := userFollow.NewUserFollowEntity(l.Ctx)
+	followers, err := followEntity.CountFollowers(id)
+	if err != nil {
+		return nil, err
+	}
+	following, err := followEntity.CountFollowing(id)
+	if err != nil {
+		return nil, err
+	}
+	followed := false
+	if currUserId := l.CurrUserId(); currUserId > 0 && currUserId != id {
+		if followed, err = followEntity.IsFollowing(currUserId, id); err != nil {
+			return nil, err
+		}
+	}
+
+	// 已发布模型：汇总数据与分页列表
+	stats, err := model.NewModelEntity(l.Ctx).AuthorStats(id)
+	if err != nil {
+		return nil, err
+	}
+	page, pageSize := max(req.Page, 1), req.PageSize
+	if pageSize <= 0 {
+		pageSize = 10
+	}
+	models, err := thinking.NewModelLogic(l.Ctx).List(&model.SearchModel{Page: page, PageSize: pageSize, AuthorId: id, Status: 1})
+	if err != nil {
+		return nil, err
+	}
+
+	return &user.UserProfile{
+		ID:             dbUser.Id,
+		Nickname:       dbUser.DisplayName(),
+		Avatar:         dbUser.Avatar,
+		Bio:            dbUser.Bio,
+		FollowerCount:  followers,
+		FollowingCount: following,
+		Followed:       followed,
+		JoinedAt:       dbUser.CreatedAt.String(),
+		Stats:          *stats,
+		Models:         models,
+	}, nil
+}
+
+// Follow 关注用户（重复关注直接返回成功）
+func (l *UserLogic) Follow(req *userFollow.FollowRequest) error {
+	userId, err := l.MustCurrUserId()
+	if err != nil {
+		return err
+	}
+	if _, err = l.publicUser(req.UserId); err != nil {
+		return err
+	}
+
+	entity := userFollow.NewUserFollowEntity(l.Ctx)
+	followed, err := entity.IsFollowing(userId, req.UserId)
+	if err != nil || followed {
+		return err
+	}
+	if follow, ok := entity.(*userFollow.UserFollowEntity); ok {
+		follow.FollowerId = userId
+		follow.FolloweeId = req.UserId
+	}
+	if err = entity.Validate(); err != nil {
+		return err
+	}
+	_, err = entity.Create()
+	return err
+}
+
+// Unfollow 取消关注
+func (l *UserLogic) Unfollow(req *userFollow.FollowRequest) error {
+	userId, err := l.MustCurrUserId()
+	if err != nil {
+		return err
+	}
+	return userFollow.NewUserFollowEntity(l.Ctx).Unfollow(userId, req.UserId)
+}
+
+// publicUser 可公开展示的用户（禁用、待验证、已注销的用户视为不存在）
+func (l *UserLogic) publicUser(id uint64) (*user.UserEntity, error) {
+	dbUser, err := user.NewUserEntity(l.Ctx).LoadById(id)
+	if err != nil || dbUser.Id == 0 || dbUser.Status != user.StatusActive {
+		return nil, ErrUserNotFound
+	}
+	return dbUser, nil
+}
@@ -0,0 +1,144 @@
+package iam
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"thinkingModels/component/errs"
+	"thinkingModels/component/logger"
+	"thinkingModels/component/mail"
+	"thinkingModels/component/ratelimit"
+	"thinkingModels/config"
+	"thinkingModels/domain/iam/auditLog"
+	"thinkingModels/domain/iam/inviteCode"
+	"thinkingModels/domain/iam/user"
+)
+
+const (
+	resendLimitKey   = "limit:email_verify:%s" // 重新发送验证邮件频率限制(按邮箱)
+	resendLimitCycle = time.Hour               // 限制周期
+	resendLimitCount = 5                       // 周期内最多发送次数
+)
+
+var ErrResendTooFrequent = errs.RateLimited("发送过于频繁，请稍后再试")
+
+// Register 用户自助注册：按配置校验人机验证、核销邀请码后创建用户
+// 开启邮箱验证时账号处于待验证状态，并向注册邮箱投递验证链接
+func (l *UserLogic) Register(req *user.RegisterRequest) (*user.RegisterResponse, error) {
+	if user.RegisterCaptchaRequired() {
+		if err := user.VerifyCaptcha(req.CaptchaId, req.CaptchaAnswer); err != nil {
+			return nil, err
+		}
+	}
+
+	// 1. 邮箱：开启验证时必填，且不能与已有账号重复
+	verify := user.EmailVerifyRequired()
+	if verify && req.Email == "" {
+		return nil, user.ErrEmailRequired
+	}
+	if req.Email != "" {
+		userEntity := user.NewUserEntity(l.Ctx)
+		count, err := userEntity.Count(userEntity.MakeConditon(user.SearchUser{Email: req.Email}))
+		if err != nil {
+			return nil, err
+		}
+		if count > 0 {
+			return nil, user.ErrEmailExists
+		}
+	}
+
+	// 2. 邀请码：仅限邀请注册时必填；未开启时填写了同样核销，用于记录邀请关系
+	var invite *inviteCode.InviteCodeEntity
+	if user.InviteRequired() || strings.TrimSpace(req.InviteCode) != "" {
+		var err error
+		invite, err = inviteCode.NewInviteCodeEntity(l.Ctx).Redeem(req.InviteCode)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// 3. 创建用户
+	res, err := l.createUser(req, func(entity *user.UserEntity) {
+		entity.Status = user.StatusActive
+		if verify {
+			entity.Status = user.StatusPending
+		}
+		if invite != nil {
+			entity.InvitedBy = invite.OwnerId
+			entity.InviteCodeId = invite.Id
+		}
+	})
+	if err != nil {
+		if invite != nil {
+			_ = invite.Release()
+		}
+		return nil, err
+	}
+
+	// 4. 投递验证邮件（失败时账号已创建，可通过重新发送补发）
+	if verify {
+		if err = l.sendVerification(res); err != nil {
+			logger.Ctx(l.Ctx).Error("验证邮件发送失败", "user_id", res.Id, "error", err)
+		}
+	}
+	return &user.RegisterResponse{UserInfo: *convertToUserInfo(res), VerificationRequired: verify}, nil
+}
+
+// VerifyEmail 使用邮件中的令牌验证邮箱，待验证的账号随之激活
+func (l *UserLogic) VerifyEmail(req *user.VerifyEmailRequest) error {
+	userId, err := user.ConsumeEmailVerification(req.Token)
+	if err != nil {
+		return err
+	}
+	dbUser, err := user.NewUserEntity(l.Ctx).LoadById(userId)
+	if err != nil || dbUser.Id == 0 {
+		return user.ErrEmailVerifyInvalid
+	}
+
+	dbUser.EmailVerified = true
+	if dbUser.Status == user.StatusPending {
+		dbUser.Status = user.StatusActive
+	}
+	if _, err = dbUser.Update(); err != nil {
+		return err
+	}
+	l.Audit(&auditLog.Entry{Action: auditLog.ActionUserVerifyEmail, TargetType: auditLog.TargetUser, TargetId: dbUser.Id, ActorId: dbUser.Id, ActorName: dbUser.Username})
+	return nil
+}
+
+// ResendVerification 重新发送验证邮件（待验证的注册账号、更换邮箱后未验证的账号）
+// 邮箱未注册或已验证时同样返回成功，避免泄露账号是否存在
+func (l *UserLogic) ResendVerification(req *user.ResendVerificationRequest) error {
+	if !ratelimit.Allow(l.Ctx.Request.Context(), fmt.Sprintf(resendLimitKey, strings.ToLower(req.Email)), resendLimitCount, resendLimitCycle).Allowed {
+		return ErrResendTooFrequent
+	}
+
+	userEntity := user.NewUserEntity(l.Ctx)
+	dbUser, err := userEntity.LoadData(userEntity.MakeConditon(user.SearchUser{Email: req.Email}))
+	if err != nil || dbUser.Id == 0 || dbUser.EmailVerified || dbUser.Status == user.StatusDisabled {
+		return nil
+	}
+	if err = l.sendVerification(dbUser); err != nil {
+		return errs.New(errs.CodeInternal, "邮件发送失败，请稍后重试").Wrap(err)
+	}
+	return nil
+}
+
+// sendVerification 签发验证令牌并投递验证邮件（重新签发时旧链接失效）
+func (l *UserLogic) sendVerification(dbUser *user.UserEntity) error {
+	token, expire, err := user.IssueEmailVerification(dbUser.Id)
+	if err != nil {
+		return err
+	}
+
+	link := fmt.Sprintf(config.Config.Register.VerifyEmailUrl, url.QueryEscape(token))
+	body := fmt.Sprintf("%s，您好：\n\n请在 %d 小时内打开以下链接验证您的邮箱：\n\n%s\n\n如果这不是您本人的操作，请忽略本邮件。",
+		dbUser.Username, int(expire.Hours()), link)
+	return mail.NewMailer().Send(&mail.Message{
+		To:      []string{dbUser.Email},
+		Subject: "验证邮箱",
+		Body:    body,
+	})
+}
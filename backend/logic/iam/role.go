@@ -0,0 +1,167 @@
+package iam
+
+import (
+	"github.com/gin-gonic/gin"
+	"thinkingModels/component/errs"
+	"thinkingModels/domain/iam/auditLog"
+	"thinkingModels/domain/iam/role"
+	"thinkingModels/domain/iam/rolePermission"
+	"thinkingModels/logic"
+)
+
+// RoleLogic 角色业务逻辑
+type RoleLogic struct {
+	logic.BaseLogic
+}
+
+// NewRoleLogic 初始化RoleLogic
+func NewRoleLogic(ctx *gin.Context) *RoleLogic {
+	return &RoleLogic{BaseLogic: logic.BaseLogic{Ctx: ctx}}
+}
+
+// Create 创建角色（超级管理员角色只能由超级管理员创建）
+func (l *RoleLogic) Create(req *role.CreateRole) (*role.RoleEntity, error) {
+	if err := role.CheckSuperGrant(l.IsSuperAdmin(), &role.RoleEntity{IsSuper: req.IsSuper}); err != nil {
+		return nil, err
+	}
+	entity := role.NewRoleEntity(l.Ctx)
+
+	// 校验编码唯一性
+	exists, err := entity.CheckBusinessCodeExist("code", req.Code)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, errs.Conflict("角色编码已存在")
+	}
+
+	_, err = entity.SetData(req)
+	if err != nil {
+		return nil, err
+	}
+
+	err = entity.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := entity.Create()
+	if err != nil {
+		return nil, err
+	}
+	l.Audit(&auditLog.Entry{Action: auditLog.ActionRoleCreate, TargetType: auditLog.TargetRole, TargetId: res.Id, After: res})
+	return res, nil
+}
+
+// Update 更新角色（超级管理员角色只能由超级管理员修改或授予）
+func (l *RoleLogic) Update(req *role.UpdateRole) (*role.RoleEntity, error) {
+	entity := role.NewRoleEntity(l.Ctx)
+
+	old, err := entity.LoadById(req.Id)
+	if err != nil || old.Id == 0 {
+		return nil, errs.NotFound("角色不存在")
+	}
+	if err := role.CheckSuperGrant(l.IsSuperAdmin(), old, &role.RoleEntity{IsSuper: req.IsSuper}); err != nil {
+		return nil, err
+	}
+	before := auditLog.Snapshot(old)
+
+	_, err = entity.SetData(req)
+	if err != nil {
+		return nil, err
+	}
+
+	err = entity.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := entity.Update()
+	if err != nil {
+		return nil, err
+	}
+	l.Audit(&auditLog.Entry{Action: auditLog.ActionRoleUpdate, TargetType: auditLog.TargetRole, TargetId: res.Id, Before: before, After: res})
+	return res, nil
+}
+
+// Get 查询角色详情（含已分配的权限）
+func (l *RoleLogic) Get(id uint64) (*role.RoleDetail, error) {
+	entity := role.NewRoleEntity(l.Ctx)
+	res, err := entity.LoadById(id)
+	if err != nil || res.Id == 0 {
+		return nil, errs.NotFound("角色不存在")
+	}
+
+	permissionIds, err := rolePermission.NewRolePermissionEntity(l.Ctx).PermissionIdsByRole(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &role.RoleDetail{RoleEntity: res, PermissionIds: permissionIds}, nil
+}
+
+// List 查询角色列表
+func (l *RoleLogic) List(req *role.SearchRole) (*role.ListReap, error) {
+	entity := role.NewRoleEntity(l.Ctx)
+	cond := entity.MakeConditon(*req)
+
+	total, err := entity.Count(cond)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := entity.List(cond)
+	if err != nil {
+		return nil, err
+	}
+
+	return &role.ListReap{
+		Page:     req.Page,
+		PageSize: req.PageSize,
+		Total:    total,
+		List:     list,
+	}, nil
+}
+
+// Del 删除角色（同时清理角色权限关联）
+func (l *RoleLogic) Del(req *role.DelRole) (any, error) {
+	entity := role.NewRoleEntity(l.Ctx)
+	list, err := entity.ListByIds(req.Ids)
+	if err != nil {
+		return nil, err
+	}
+	err = entity.Del(req.Ids...)
+	if err != nil {
+		return nil, err
+	}
+
+	err = rolePermission.NewRolePermissionEntity(l.Ctx).DeleteByRoleIds(req.Ids...)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range list {
+		l.Audit(&auditLog.Entry{Action: auditLog.ActionRoleDelete, TargetType: auditLog.TargetRole, TargetId: item.Id, Before: item})
+	}
+	return nil, nil
+}
+
+// AssignPermissions 为角色分配权限（全量覆盖）
+func (l *RoleLogic) AssignPermissions(req *role.AssignRolePermissions) (*role.RoleDetail, error) {
+	// 确认角色存在，并留存分配前的权限
+	before, err := l.Get(req.RoleId)
+	if err != nil {
+		return nil, err
+	}
+
+	err = rolePermission.NewRolePermissionEntity(l.Ctx).Replace(req.RoleId, req.PermissionIds)
+	if err != nil {
+		return nil, err
+	}
+
+	after, err := l.Get(req.RoleId)
+	if err != nil {
+		return nil, err
+	}
+	l.Audit(&auditLog.Entry{Action: auditLog.ActionRoleAssign, TargetType: auditLog.TargetRole, TargetId: req.RoleId, Before: before, After: after})
+	return after, nil
+}
@@ -0,0 +1,105 @@
+package iam
+
+import (
+	"github.com/gin-gonic/gin"
+	"thinkingModels/component/errs"
+	"thinkingModels/domain/iam/auditLog"
+	"thinkingModels/domain/iam/user"
+	"thinkingModels/domain/iam/userSession"
+	"thinkingModels/logic"
+)
+
+// SessionLogic 登录会话业务逻辑
+type SessionLogic struct {
+	logic.BaseLogic
+}
+
+// 初始化SessionLogic
+func NewSessionLogic(ctx *gin.Context) *SessionLogic {
+	return &SessionLogic{BaseLogic: logic.BaseLogic{Ctx: ctx}}
+}
+
+// ListMy 当前用户的在线会话，标记出当前请求所在会话
+func (l *SessionLogic) ListMy() ([]*userSession.UserSessionInfo, error) {
+	userId, err := l.MustCurrUserId()
+	if err != nil {
+		return nil, err
+	}
+	list, err := userSession.NewUserSessionEntity(l.Ctx).ListActive(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	currFamilyId := l.Ctx.GetString("currFamilyId")
+	infos := make([]*userSession.UserSessionInfo, 0, len(list))
+	for _, item := range list {
+		infos = append(infos, &userSession.UserSessionInfo{
+			Id:         item.Id,
+			Device:     item.Device,
+			UserAgent:  item.UserAgent,
+			Ip:         item.Ip,
+			LastSeenAt: item.LastSeenAt.String(),
+			LastSeenIp: item.LastSeenIp,
+			ExpiresAt:  item.ExpiresAt.String(),
+			CreatedAt:  item.CreatedAt.String(),
+			Current:    currFamilyId != "" && item.FamilyId == currFamilyId,
+		})
+	}
+	return infos, nil
+}
+
+// Revoke 下线本人的指定会话
+func (l *SessionLogic) Revoke(req *userSession.RevokeUserSession) error {
+	sessionEntity := userSession.NewUserSessionEntity(l.Ctx)
+	session, err := sessionEntity.LoadById(req.Id)
+	if err != nil || session.Id == 0 {
+		return errs.NotFound("会话不存在")
+	}
+	err = l.CheckOwner(session.UserId)
+	if err != nil {
+		return err
+	}
+
+	err = user.RevokeSession(session.UserId, session.FamilyId)
+	if err != nil {
+		return err
+	}
+	err = sessionEntity.Offline(session.FamilyId)
+	if err != nil {
+		return err
+	}
+	l.Audit(&auditLog.Entry{Action: auditLog.ActionSessionRevoke, TargetType: auditLog.TargetSession, TargetId: session.Id, Remark: session.Device + " " + session.Ip})
+	return nil
+}
+
+// RevokeOthers 下线当前会话以外的全部会话
+func (l *SessionLogic) RevokeOthers() (int, error) {
+	userId, err := l.MustCurrUserId()
+	if err != nil {
+		return 0, err
+	}
+	currFamilyId := l.Ctx.GetString("currFamilyId")
+	if currFamilyId == "" {
+		return 0, errs.Validation("当前登录方式不支持该操作")
+	}
+
+	sessionEntity := userSession.NewUserSessionEntity(l.Ctx)
+	list, err := sessionEntity.ListActive(userId)
+	if err != nil {
+		return 0, err
+	}
+
+	familyIds := make([]string, 0, len(list))
+	for _, item := range list {
+		if item.FamilyId == currFamilyId {
+			continue
+		}
+		err = user.RevokeSession(userId, item.FamilyId)
+		if err != nil {
+			return 0, err
+		}
+		familyIds = append(familyIds, item.FamilyId)
+		l.Audit(&auditLog.Entry{Action: auditLog.ActionSessionRevoke, TargetType: auditLog.TargetSession, TargetId: item.Id, Remark: item.Device + " " + item.Ip})
+	}
+	return len(familyIds), sessionEntity.Offline(familyIds...)
+}
@@ -0,0 +1,147 @@
+package iam
+
+import (
+	"thinkingModels/component/errs"
+	"thinkingModels/domain/iam/auditLog"
+	"thinkingModels/domain/iam/user"
+)
+
+// TotpStatus 当前用户的两步验证状态
+func (l *UserLogic) TotpStatus() (*user.TotpStatus, error) {
+	dbUser, err := l.currUser()
+	if err != nil {
+		return nil, err
+	}
+	return &user.TotpStatus{Enabled: dbUser.TotpEnabled, RecoveryCodesLeft: dbUser.RecoveryCodesLeft()}, nil
+}
+
+// SetupTotp 生成两步验证密钥（需再调用 EnableTotp 校验首个验证码后才生效）
+func (l *UserLogic) SetupTotp() (*user.TotpSetupResponse, error) {
+	dbUser, err := l.currUser()
+	if err != nil {
+		return nil, err
+	}
+	secret, uri, err := dbUser.EnrollTotp()
+	if err != nil {
+		return nil, err
+	}
+	_, err = dbUser.Update()
+	if err != nil {
+		return nil, err
+	}
+	return &user.TotpSetupResponse{Secret: secret, Uri: uri}, nil
+}
+
+// EnableTotp 校验首个验证码并启用两步验证，返回恢复码
+func (l *UserLogic) EnableTotp(req *user.TotpCodeRequest) (*user.RecoveryCodesResponse, error) {
+	dbUser, err := l.currUser()
+	if err != nil {
+		return nil, err
+	}
+	codes, err := dbUser.ConfirmTotp(req.Code)
+	if err != nil {
+		return nil, err
+	}
+	_, err = dbUser.Update()
+	if err != nil {
+		return nil, err
+	}
+	l.Audit(&auditLog.Entry{Action: auditLog.ActionTotpEnable, TargetType: auditLog.TargetUser, TargetId: dbUser.Id})
+	return &user.RecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+// DisableTotp 关闭两步验证，需同时校验密码与验证码（或恢复码）
+func (l *UserLogic) DisableTotp(req *user.DisableTotpRequest) error {
+	dbUser, err := l.currUser()
+	if err != nil {
+		return err
+	}
+	if !dbUser.TotpEnabled {
+		return user.ErrTotpNotEnabled
+	}
+	if !dbUser.VerifyPassword(req.Password) {
+		return errs.Validation("密码错误")
+	}
+	if !verifySecondFactor(dbUser, req.Code) {
+		return user.ErrTotpCodeInvalid
+	}
+	dbUser.DisableTotp()
+	_, err = dbUser.Update()
+	if err != nil {
+		return err
+	}
+	l.Audit(&auditLog.Entry{Action: auditLog.ActionTotpDisable, TargetType: auditLog.TargetUser, TargetId: dbUser.Id})
+	return nil
+}
+
+// RegenerateRecoveryCodes 校验验证码后重新生成恢复码，旧恢复码全部失效
+func (l *UserLogic) RegenerateRecoveryCodes(req *user.TotpCodeRequest) (*user.RecoveryCodesResponse, error) {
+	dbUser, err := l.currUser()
+	if err != nil {
+		return nil, err
+	}
+	if !dbUser.TotpEnabled {
+		return nil, user.ErrTotpNotEnabled
+	}
+	if !dbUser.VerifyTotp(req.Code) {
+		return nil, user.ErrTotpCodeInvalid
+	}
+	codes, err := dbUser.RegenerateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	_, err = dbUser.Update()
+	if err != nil {
+		return nil, err
+	}
+	l.Audit(&auditLog.Entry{Action: auditLog.ActionRecoveryRegenerate, TargetType: auditLog.TargetUser, TargetId: dbUser.Id})
+	return &user.RecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+// VerifyTwoFactor 提交挑战令牌与验证码（或恢复码），通过后签发正式Token
+func (l *UserLogic) VerifyTwoFactor(req *user.VerifyTwoFactorRequest) (*user.LoginResponse, error) {
+	userId, err := user.LoginChallengeUser(req.ChallengeToken)
+	if err != nil {
+		return nil, err
+	}
+	dbUser, err := user.NewUserEntity(l.Ctx).LoadById(userId)
+	if err != nil {
+		return nil, user.ErrLoginChallengeInvalid
+	}
+	if dbUser.Status != user.StatusActive {
+		user.FinishLoginChallenge(req.ChallengeToken)
+		return nil, errs.Forbidden("账号已被禁用")
+	}
+
+	// 验证码失败与密码失败共用账号的锁定与退避
+	ip := l.Ctx.ClientIP()
+	if err = user.CheckLoginAllowed(dbUser.Username, ip); err != nil {
+		return nil, err
+	}
+	if dbUser.TotpEnabled && !verifySecondFactor(dbUser, req.Code) {
+		l.Audit(&auditLog.Entry{Action: auditLog.ActionTwoFactorFailed, TargetType: auditLog.TargetUser, TargetId: dbUser.Id, ActorId: dbUser.Id, ActorName: dbUser.Username})
+		return nil, user.RecordLoginChallengeFailure(req.ChallengeToken, dbUser.Username, ip)
+	}
+	user.FinishLoginChallenge(req.ChallengeToken)
+	user.ClearLoginFailures(dbUser.Username)
+
+	// 使用的恢复码随登录信息一并保存
+	return l.issueLogin(dbUser, ip)
+}
+
+// currUser 加载当前登录用户
+func (l *UserLogic) currUser() (*user.UserEntity, error) {
+	userId, err := l.MustCurrUserId()
+	if err != nil {
+		return nil, err
+	}
+	return user.NewUserEntity(l.Ctx).LoadById(userId)
+}
+
+// verifySecondFactor 校验6位验证码，其余格式按恢复码处理（恢复码使用后需保存实体）
+func verifySecondFactor(dbUser *user.UserEntity, code string) bool {
+	if len(code) == 6 {
+		return dbUser.VerifyTotp(code)
+	}
+	return dbUser.UseRecoveryCode(code)
+}
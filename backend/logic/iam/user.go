@@ -2,9 +2,22 @@ package iam
 
 import (
 	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"thinkingModels/component/db"
+	"thinkingModels/component/errs"
+	"thinkingModels/component/logger"
+	"thinkingModels/component/mail"
+	"thinkingModels/config"
+	"thinkingModels/domain/iam/auditLog"
+	"thinkingModels/domain/iam/permission"
+	"thinkingModels/domain/iam/role"
 	"thinkingModels/domain/iam/user"
+	"thinkingModels/domain/iam/userSession"
 	"thinkingModels/logic"
 )
 
@@ -18,8 +31,21 @@ func NewUserLogic(ctx *gin.Context) *UserLogic {
 	return &UserLogic{BaseLogic: logic.BaseLogic{Ctx: ctx}}
 }
 
-// Create 创建用户
+// Create 创建用户（管理员创建的账号直接启用）
 func (l *UserLogic) Create(req *user.RegisterRequest) (*user.UserInfo, error) {
+	res, err := l.createUser(req, func(entity *user.UserEntity) {
+		entity.Status = user.StatusActive
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 返回用户信息DTO（脱敏处理）
+	return convertToUserInfo(res), nil
+}
+
+// createUser 保存新用户，fill 用于设置状态、邀请人等请求之外的字段
+func (l *UserLogic) createUser(req *user.RegisterRequest, fill func(entity *user.UserEntity)) (*user.UserEntity, error) {
 	// 实例化模型
 	userEntity := user.NewUserEntity(l.Ctx)
 
@@ -28,10 +54,8 @@ func (l *UserLogic) Create(req *user.RegisterRequest) (*user.UserInfo, error) {
 	if err != nil {
 		return nil, err
 	}
-
-	// 设置用户状态为正常（默认启用）- 通过类型断言访问具体类型
 	if entity, ok := userEntity.(*user.UserEntity); ok {
-		entity.Status = 1
+		fill(entity)
 	}
 
 	// 数据校验
@@ -52,36 +76,64 @@ func (l *UserLogic) Create(req *user.RegisterRequest) (*user.UserInfo, error) {
 		return nil, err
 	}
 
-	// 返回用户信息DTO（直接构造，脱敏处理）
-	return &user.UserInfo{
-		ID:            res.Id,
-		Username:      res.Username,
-		Nickname:      res.Nickname,
-		Email:         res.Email,
-		Phone:         res.Phone,
-		Avatar:        res.Avatar,
-		Status:        res.Status,
-		LastLoginTime: res.LastLoginTime.String(),
-		CreatedAt:     res.CreatedAt.String(),
-	}, nil
+	// 未登录即为自助注册，操作人为新用户本人
+	entry := &auditLog.Entry{Action: auditLog.ActionUserCreate, TargetType: auditLog.TargetUser, TargetId: res.Id, After: res}
+	if l.CurrUserId() == 0 {
+		entry.Action, entry.ActorId, entry.ActorName = auditLog.ActionUserRegister, res.Id, res.Username
+	}
+	l.Audit(entry)
+	return res, nil
 }
 
 // Update 更新用户信息
+// 只能修改本人信息，修改他人需 ACCOUNT_EDIT 权限；更换邮箱后需重新验证
 func (l *UserLogic) Update(req *user.UpdateUserRequest) (*user.UserInfo, error) {
+	currUserId, err := l.MustCurrUserId()
+	if err != nil {
+		return nil, err
+	}
+	if req.ID != currUserId && !l.HasPermission("ACCOUNT_EDIT") {
+		return nil, errs.Forbidden("无权修改他人信息")
+	}
+
 	// 实例化模型
 	userEntity := user.NewUserEntity(l.Ctx)
 
 	// 先加载旧数据
-	_, err := userEntity.LoadById(req.ID)
+	old, err := userEntity.LoadById(req.ID)
 	if err != nil {
 		return nil, err
 	}
+	if old.Id == 0 {
+		return nil, errs.NotFound("用户不存在")
+	}
+	before := auditLog.Snapshot(old)
+
+	// 邮箱变更：开启验证时不能清空，且不能与其他账号重复
+	emailChanged := !strings.EqualFold(req.Email, old.Email)
+	if emailChanged {
+		if req.Email == "" && user.EmailVerifyRequired() {
+			return nil, user.ErrEmailRequired
+		}
+		if req.Email != "" {
+			count, err := userEntity.Count(userEntity.MakeConditon(user.SearchUser{Email: req.Email}))
+			if err != nil {
+				return nil, err
+			}
+			if count > 0 {
+				return nil, user.ErrEmailExists
+			}
+		}
+	}
 
 	// 数据赋值
 	_, err = userEntity.SetData(req)
 	if err != nil {
 		return nil, err
 	}
+	if entity, ok := userEntity.(*user.UserEntity); ok && emailChanged {
+		entity.EmailVerified = false
+	}
 
 	// 数据校验
 	err = userEntity.Validate()
@@ -94,19 +146,17 @@ func (l *UserLogic) Update(req *user.UpdateUserRequest) (*user.UserInfo, error)
 	if err != nil {
 		return nil, err
 	}
+	l.Audit(&auditLog.Entry{Action: auditLog.ActionUserUpdate, TargetType: auditLog.TargetUser, TargetId: res.Id, Before: before, After: res})
 
-	// 返回用户信息DTO（直接构造，脱敏处理）
-	return &user.UserInfo{
-		ID:            res.Id,
-		Username:      res.Username,
-		Nickname:      res.Nickname,
-		Email:         res.Email,
-		Phone:         res.Phone,
-		Avatar:        res.Avatar,
-		Status:        res.Status,
-		LastLoginTime: res.LastLoginTime.String(),
-		CreatedAt:     res.CreatedAt.String(),
-	}, nil
+	// 向新邮箱投递验证邮件（失败时可通过重新发送补发）
+	if emailChanged && res.Email != "" {
+		if err = l.sendVerification(res); err != nil {
+			logger.Ctx(l.Ctx).Error("验证邮件发送失败", "user_id", res.Id, "error", err)
+		}
+	}
+
+	// 返回用户信息DTO（脱敏处理）
+	return convertToUserInfo(res), nil
 }
 
 // Get 查询用户详情
@@ -119,19 +169,12 @@ func (l *UserLogic) Get(id uint64) (*user.UserInfo, error) {
 	if err != nil {
 		return nil, err
 	}
+	if res.Id == 0 {
+		return nil, errs.NotFound("用户不存在")
+	}
 
-	// 返回用户信息DTO（直接构造，脱敏处理）
-	return &user.UserInfo{
-		ID:            res.Id,
-		Username:      res.Username,
-		Nickname:      res.Nickname,
-		Email:         res.Email,
-		Phone:         res.Phone,
-		Avatar:        res.Avatar,
-		Status:        res.Status,
-		LastLoginTime: res.LastLoginTime.String(),
-		CreatedAt:     res.CreatedAt.String(),
-	}, nil
+	// 返回用户信息DTO（脱敏处理）
+	return convertToUserInfo(res), nil
 }
 
 // List 查询用户列表
@@ -154,20 +197,10 @@ func (l *UserLogic) List(req *user.SearchUser) (*user.ListUserResponse, error) {
 		return nil, err
 	}
 
-	// 转换为DTO列表（直接构造，脱敏处理）
+	// 转换为DTO列表（脱敏处理）
 	userInfoList := make([]*user.UserInfo, 0, len(list))
 	for _, item := range list {
-		userInfoList = append(userInfoList, &user.UserInfo{
-			ID:            item.Id,
-			Username:      item.Username,
-			Nickname:      item.Nickname,
-			Email:         item.Email,
-			Phone:         item.Phone,
-			Avatar:        item.Avatar,
-			Status:        item.Status,
-			LastLoginTime: item.LastLoginTime.String(),
-			CreatedAt:     item.CreatedAt.String(),
-		})
+		userInfoList = append(userInfoList, convertToUserInfo(item))
 	}
 
 	// 返回数据
@@ -185,34 +218,90 @@ func (l *UserLogic) Del(ids []uint64) (any, error) {
 	// 实例化模型
 	userEntity := user.NewUserEntity(l.Ctx)
 
+	// 删除前留存快照
+	list, err := userEntity.ListByIds(ids)
+	if err != nil {
+		return nil, err
+	}
+
 	// 删除数据
-	err := userEntity.Del(ids...)
-	return nil, err
+	err = userEntity.Del(ids...)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range list {
+		l.Audit(&auditLog.Entry{Action: auditLog.ActionUserDelete, TargetType: auditLog.TargetUser, TargetId: item.Id, Before: item})
+	}
+	return nil, nil
 }
 
 // Login 用户登录
 func (l *UserLogic) Login(req *user.LoginRequest) (*user.LoginResponse, error) {
-	// 1. 根据用户名查询用户
+	// 0. 防暴力破解：锁定或退避中直接拒绝
+	ip := l.Ctx.ClientIP()
+	err := user.CheckLoginAllowed(req.Username, ip)
+	if err != nil {
+		return nil, err
+	}
+
+	// 同一IP失败次数过多时需要人机验证
+	if user.LoginCaptchaRequired(ip) {
+		if err = user.VerifyCaptcha(req.CaptchaId, req.CaptchaAnswer); err != nil {
+			return nil, &user.LoginFailedError{Msg: err.Error(), RemainingAttempts: -1, CaptchaRequired: true}
+		}
+	}
+
+	// 1. 根据用户名查询用户（用户不存在同样计入失败，避免枚举用户名）
 	userEntity := user.NewUserEntity(l.Ctx)
 	cond := userEntity.MakeConditon(user.SearchUser{Username: req.Username})
 	dbUser, err := userEntity.LoadData(cond)
 	if err != nil {
-		return nil, errors.New("用户名或密码错误")
+		l.auditLoginFailed(0, req.Username, "用户不存在")
+		return nil, user.RecordLoginFailure(req.Username, ip)
 	}
 
-	// 2. 检查用户状态
-	if dbUser.Status == 0 {
-		return nil, errors.New("账号已被禁用")
+	// 2. 验证密码（实体方法）
+	if !dbUser.VerifyPassword(req.Password) {
+		l.auditLoginFailed(dbUser.Id, req.Username, "密码错误")
+		return nil, user.RecordLoginFailure(req.Username, ip)
 	}
 
-	// 3. 验证密码（实体方法）
-	if !dbUser.VerifyPassword(req.Password) {
-		return nil, errors.New("用户名或密码错误")
+	// 3. 检查用户状态（密码正确后才提示，避免泄露哪些账号已被禁用）
+	if dbUser.Status == user.StatusDisabled {
+		l.auditLoginFailed(dbUser.Id, req.Username, "账号已被禁用")
+		return nil, errs.Forbidden("账号已被禁用")
+	}
+
+	// 密码正确但邮箱尚未验证
+	if dbUser.Status == user.StatusPending {
+		l.auditLoginFailed(dbUser.Id, req.Username, "邮箱未验证")
+		return nil, user.ErrEmailNotVerified
+	}
+
+	// 启用两步验证时失败记录保留到验证码通过，避免重新登录绕过验证码的失败次数限制
+	if !dbUser.TotpEnabled {
+		user.ClearLoginFailures(req.Username)
+	}
+	return l.completeLogin(dbUser, ip)
+}
+
+// completeLogin 第一因素验证通过：启用两步验证的用户返回挑战令牌，否则直接签发Token
+func (l *UserLogic) completeLogin(dbUser *user.UserEntity, ip string) (*user.LoginResponse, error) {
+	if !dbUser.TotpEnabled {
+		return l.issueLogin(dbUser, ip)
+	}
+	challenge, err := user.IssueLoginChallenge(dbUser.Id)
+	if err != nil {
+		return nil, err
 	}
+	return &user.LoginResponse{TwoFactorRequired: true, ChallengeToken: challenge}, nil
+}
 
+// issueLogin 登录成功：更新登录信息并签发Token（密码登录与第三方登录共用）
+func (l *UserLogic) issueLogin(dbUser *user.UserEntity, ip string) (*user.LoginResponse, error) {
 	// 4. 更新登录信息（实体方法）
-	dbUser.UpdateLoginInfo(l.Ctx.ClientIP())
-	_, err = dbUser.Update()
+	dbUser.UpdateLoginInfo(ip)
+	_, err := dbUser.Update()
 	if err != nil {
 		return nil, err
 	}
@@ -220,29 +309,343 @@ func (l *UserLogic) Login(req *user.LoginRequest) (*user.LoginResponse, error) {
 	// 5. 生成JWT Token（实体方法 - 充血模型）
 	tokenPair, err := dbUser.GenerateToken()
 	if err != nil {
-		return nil, errors.New("Token生成失败")
+		return nil, errs.New(errs.CodeInternal, "Token生成失败").Wrap(err)
 	}
 
+	// 6. 记录刷新令牌家族
+	err = user.SaveRefreshFamily(dbUser.Id, tokenPair)
+	if err != nil {
+		return nil, err
+	}
+
+	// 7. 记录登录会话（设备、IP）
+	err = l.createSession(dbUser.Id, tokenPair, ip)
+	if err != nil {
+		return nil, err
+	}
+	l.Audit(&auditLog.Entry{Action: auditLog.ActionLogin, TargetType: auditLog.TargetUser, TargetId: dbUser.Id, ActorId: dbUser.Id, ActorName: dbUser.Username})
+
 	return &user.LoginResponse{
 		AccessToken:  tokenPair.AccessToken,
 		RefreshToken: tokenPair.RefreshToken,
 		ExpiresIn:    tokenPair.ExpiresIn,
-		UserInfo: user.UserInfo{ // 直接构造，脱敏处理
-			ID:            dbUser.Id,
-			Username:      dbUser.Username,
-			Nickname:      dbUser.Nickname,
-			Email:         dbUser.Email,
-			Phone:         dbUser.Phone,
-			Avatar:        dbUser.Avatar,
-			Status:        dbUser.Status,
-			LastLoginTime: dbUser.LastLoginTime.String(),
-			CreatedAt:     dbUser.CreatedAt.String(),
-		},
+		UserInfo:     *convertToUserInfo(dbUser),
+	}, nil
+}
+
+// auditLoginFailed 记录登录失败（用户不存在时操作人ID为0，保留尝试的用户名）
+func (l *UserLogic) auditLoginFailed(userId uint64, username, reason string) {
+	l.Audit(&auditLog.Entry{Action: auditLog.ActionLoginFailed, TargetType: auditLog.TargetUser, TargetId: userId, ActorId: userId, ActorName: username, Remark: reason})
+}
+
+// Codes 获取角色集合拥有的权限码
+func (l *UserLogic) Codes(roleIds string) ([]string, error) {
+	codes, _, err := permission.NewPermissionEntity(l.Ctx).ResolveCodes(user.ParseRoleIds(roleIds))
+	return codes, err
+}
+
+// AssignRoles 为用户分配角色（全量覆盖），新角色在用户下次刷新Token后生效
+func (l *UserLogic) AssignRoles(req *user.AssignUserRoles) (*user.UserInfo, error) {
+	userEntity := user.NewUserEntity(l.Ctx)
+	old, err := userEntity.LoadById(req.UserId)
+	if err != nil || old.Id == 0 {
+		return nil, errs.NotFound("用户不存在")
+	}
+
+	// 校验角色均存在；分配或撤销超级管理员角色需当前用户为超级管理员
+	roleIds := append(append([]uint64{}, req.RoleIds...), old.RoleIdList()...)
+	if len(roleIds) > 0 {
+		roles, err := role.NewRoleEntity(l.Ctx).ListByIds(roleIds)
+		if err != nil {
+			return nil, err
+		}
+		existing := make(map[uint64]bool, len(roles))
+		for _, item := range roles {
+			existing[item.Id] = true
+		}
+		for _, roleId := range req.RoleIds {
+			if !existing[roleId] {
+				return nil, errs.NotFound(fmt.Sprintf("角色不存在: %d", roleId))
+			}
+		}
+		if err = role.CheckSuperGrant(l.IsSuperAdmin(), roles...); err != nil {
+			return nil, err
+		}
+	}
+	before := auditLog.Snapshot(old)
+	userEntity.SetRoleIds(req.RoleIds)
+
+	res, err := userEntity.Update()
+	if err != nil {
+		return nil, err
+	}
+	l.Audit(&auditLog.Entry{Action: auditLog.ActionUserAssignRoles, TargetType: auditLog.TargetUser, TargetId: res.Id, Before: before, After: res})
+
+	return convertToUserInfo(res), nil
+}
+
+// Refresh 刷新Token（Refresh Token 轮换 + 重用检测）
+func (l *UserLogic) Refresh(req *user.RefreshRequest) (*user.RefreshResponse, error) {
+	// 1. 解析Refresh Token
+	claims, err := user.ParseRefreshToken(req.RefreshToken)
+	if err != nil {
+		return nil, user.ErrRefreshTokenRevoked
+	}
+
+	// 2. 家族加锁，避免并发刷新
+	unlock, err := user.LockRefreshFamily(claims.FamilyID)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	// 3. 校验是否为家族内当前有效的令牌（重用则作废整个家族）
+	err = user.CheckRefreshFamily(claims)
+	if err != nil {
+		if errors.Is(err, user.ErrRefreshTokenReused) {
+			_ = userSession.NewUserSessionEntity(l.Ctx).Offline(claims.FamilyID)
+		}
+		return nil, err
+	}
+
+	// 4. 重新加载用户，确保账号仍可用
+	userEntity := user.NewUserEntity(l.Ctx)
+	dbUser, err := userEntity.LoadById(claims.UserID)
+	if err != nil {
+		return nil, user.ErrRefreshTokenRevoked
+	}
+	if dbUser.Status != user.StatusActive {
+		_ = user.RevokeRefreshFamily(claims.UserID, claims.FamilyID)
+		return nil, errs.Forbidden("账号已被禁用")
+	}
+
+	// 5. 在同一家族内轮换签发新Token
+	tokenPair, err := dbUser.RotateToken(claims.FamilyID)
+	if err != nil {
+		return nil, errs.New(errs.CodeInternal, "Token生成失败").Wrap(err)
+	}
+	err = user.SaveRefreshFamily(dbUser.Id, tokenPair)
+	if err != nil {
+		return nil, err
+	}
+
+	// 6. 会话随 Refresh Token 续期
+	expiresAt := time.Now().Add(time.Duration(tokenPair.RefreshExpiresIn) * time.Second)
+	_ = userSession.NewUserSessionEntity(l.Ctx).Extend(claims.FamilyID, expiresAt)
+
+	return &user.RefreshResponse{
+		AccessToken:  tokenPair.AccessToken,
+		RefreshToken: tokenPair.RefreshToken,
+		ExpiresIn:    tokenPair.ExpiresIn,
 	}, nil
 }
 
 // Logout 用户登出
-func (l *UserLogic) Logout(token string) error {
-	// TODO: 实现登出逻辑，将Token加入黑名单
+// 吊销当前Access Token，并作废其所属的刷新令牌家族
+func (l *UserLogic) Logout(accessToken string, req *user.LogoutRequest) error {
+	if accessToken != "" {
+		claims, err := user.ParseToken(accessToken)
+		if err == nil {
+			l.Audit(&auditLog.Entry{Action: auditLog.ActionLogout, TargetType: auditLog.TargetUser, TargetId: claims.UserID, ActorId: claims.UserID, ActorName: claims.Username})
+			err = user.RevokeAccessToken(claims)
+			if err != nil {
+				return err
+			}
+			if claims.FamilyID != "" {
+				err = user.RevokeRefreshFamily(claims.UserID, claims.FamilyID)
+				if err != nil {
+					return err
+				}
+				_ = userSession.NewUserSessionEntity(l.Ctx).Offline(claims.FamilyID)
+			}
+		}
+	}
+
+	// Access Token 已过期时，通过 Refresh Token 作废会话
+	if req.RefreshToken != "" {
+		claims, err := user.ParseRefreshToken(req.RefreshToken)
+		if err == nil {
+			_ = userSession.NewUserSessionEntity(l.Ctx).Offline(claims.FamilyID)
+			return user.RevokeRefreshFamily(claims.UserID, claims.FamilyID)
+		}
+	}
+	return nil
+}
+
+// LogoutAll 退出全部设备：吊销当前用户此刻之前签发的全部Token
+func (l *UserLogic) LogoutAll(userId uint64) error {
+	err := l.revokeAllSessions(userId)
+	if err != nil {
+		return err
+	}
+	l.Audit(&auditLog.Entry{Action: auditLog.ActionLogoutAll, TargetType: auditLog.TargetUser, TargetId: userId})
 	return nil
 }
+
+// revokeAllSessions 吊销用户全部Token，并将全部登录会话标记为下线
+func (l *UserLogic) revokeAllSessions(userId uint64) error {
+	err := user.RevokeUserTokensBefore(userId, time.Now())
+	if err != nil {
+		return err
+	}
+	return userSession.NewUserSessionEntity(l.Ctx).OfflineByUser(userId)
+}
+
+// revokeOtherSessions 下线当前会话以外的全部登录会话，当前会话保持登录
+// 无当前会话（非登录Token）时与 revokeAllSessions 相同
+func (l *UserLogic) revokeOtherSessions(userId uint64) error {
+	currFamilyId := l.Ctx.GetString("currFamilyId")
+	if currFamilyId == "" {
+		return l.revokeAllSessions(userId)
+	}
+	if _, err := user.RevokeOtherSessions(userId, currFamilyId); err != nil {
+		return err
+	}
+	return userSession.NewUserSessionEntity(l.Ctx).OfflineByUser(userId, currFamilyId)
+}
+
+// createSession 登录成功后记录会话，与刷新令牌家族一一对应
+func (l *UserLogic) createSession(userId uint64, pair *user.TokenPair, ip string) error {
+	userAgent := l.Ctx.Request.UserAgent()
+	now := time.Now()
+
+	sessionEntity := userSession.NewUserSessionEntity(l.Ctx)
+	if session, ok := sessionEntity.(*userSession.UserSessionEntity); ok {
+		session.UserId = userId
+		session.FamilyId = pair.FamilyId
+		session.Device = userSession.ParseDevice(userAgent)
+		session.UserAgent = userAgent
+		session.Ip = ip
+		session.LastSeenAt = db.LocalTime(now)
+		session.LastSeenIp = ip
+		session.ExpiresAt = db.LocalTime(now.Add(time.Duration(pair.RefreshExpiresIn) * time.Second))
+		session.Status = 1
+	}
+	err := sessionEntity.Repair()
+	if err != nil {
+		return err
+	}
+	err = sessionEntity.Validate()
+	if err != nil {
+		return err
+	}
+	_, err = sessionEntity.Create()
+	return err
+}
+
+// ChangePassword 修改密码（需校验原密码），并下线当前会话以外的全部会话
+func (l *UserLogic) ChangePassword(userId uint64, req *user.UpdatePasswordRequest) error {
+	userEntity := user.NewUserEntity(l.Ctx)
+	dbUser, err := userEntity.LoadById(userId)
+	if err != nil {
+		return err
+	}
+
+	if !dbUser.VerifyPassword(req.OldPassword) {
+		return errs.Validation("原密码错误")
+	}
+	if req.OldPassword == req.NewPassword {
+		return errs.Validation("新密码不能与原密码相同")
+	}
+
+	dbUser.Password = req.NewPassword
+	err = dbUser.HashPassword()
+	if err != nil {
+		return err
+	}
+	_, err = dbUser.Update()
+	if err != nil {
+		return err
+	}
+	l.Audit(&auditLog.Entry{Action: auditLog.ActionPasswordChange, TargetType: auditLog.TargetUser, TargetId: userId})
+
+	// 其他设备上的会话随密码修改失效
+	return l.revokeOtherSessions(userId)
+}
+
+// ForgotPassword 忘记密码：向注册邮箱投递一次性重置链接
+// 邮箱未注册时同样返回成功，避免泄露账号是否存在
+func (l *UserLogic) ForgotPassword(req *user.ForgotPasswordRequest) error {
+	userEntity := user.NewUserEntity(l.Ctx)
+	cond := userEntity.MakeConditon(user.SearchUser{Email: req.Email})
+	dbUser, err := userEntity.LoadData(cond)
+	if err != nil || dbUser.Id == 0 || dbUser.Status == user.StatusDisabled {
+		return nil
+	}
+
+	token, expire, err := user.IssuePasswordReset(dbUser.Id)
+	if err != nil {
+		return err
+	}
+
+	link := fmt.Sprintf(config.Config.Mail.ResetPasswordUrl, url.QueryEscape(token))
+	body := fmt.Sprintf("%s，您好：\n\n我们收到了重置您账号密码的请求，请在 %d 分钟内打开以下链接设置新密码：\n\n%s\n\n如果这不是您本人的操作，请忽略本邮件。",
+		dbUser.Username, int(expire.Minutes()), link)
+	err = mail.NewMailer().Send(&mail.Message{
+		To:      []string{dbUser.Email},
+		Subject: "重置密码",
+		Body:    body,
+	})
+	if err != nil {
+		return errs.New(errs.CodeInternal, "邮件发送失败，请稍后重试").Wrap(err)
+	}
+	return nil
+}
+
+// ResetPassword 使用重置令牌设置新密码，并吊销该用户全部登录会话
+func (l *UserLogic) ResetPassword(req *user.ResetPasswordRequest) error {
+	userId, err := user.ConsumePasswordReset(req.Token)
+	if err != nil {
+		return err
+	}
+
+	userEntity := user.NewUserEntity(l.Ctx)
+	dbUser, err := userEntity.LoadById(userId)
+	if err != nil {
+		return user.ErrPasswordResetInvalid
+	}
+
+	dbUser.Password = req.NewPassword
+	err = dbUser.HashPassword()
+	if err != nil {
+		return err
+	}
+	_, err = dbUser.Update()
+	if err != nil {
+		return err
+	}
+	l.Audit(&auditLog.Entry{Action: auditLog.ActionPasswordReset, TargetType: auditLog.TargetUser, TargetId: userId, ActorId: userId, ActorName: dbUser.Username})
+
+	return l.revokeAllSessions(userId)
+}
+
+// UnlockLogin 解除登录锁定（管理员操作）
+func (l *UserLogic) UnlockLogin(req *user.UnlockLoginRequest) error {
+	if req.Username == "" && req.Ip == "" {
+		return errs.Validation("用户名和IP不能同时为空")
+	}
+	err := user.UnlockLogin(req.Username, req.Ip)
+	if err != nil {
+		return err
+	}
+	l.Audit(&auditLog.Entry{Action: auditLog.ActionUserUnlock, TargetType: auditLog.TargetUser, TargetId: req.Username, Remark: "ip=" + req.Ip})
+	return nil
+}
+
+// convertToUserInfo 转换为用户信息DTO（脱敏，不含密码等敏感字段）
+func convertToUserInfo(m *user.UserEntity) *user.UserInfo {
+	return &user.UserInfo{
+		ID:            m.Id,
+		Username:      m.Username,
+		Nickname:      m.Nickname,
+		Email:         m.Email,
+		Phone:         m.Phone,
+		Avatar:        m.Avatar,
+		Bio:           m.Bio,
+		Status:        m.Status,
+		EmailVerified: m.EmailVerified,
+		InvitedBy:     m.InvitedBy,
+		LastLoginTime: m.LastLoginTime.String(),
+		CreatedAt:     m.CreatedAt.String(),
+	}
+}
@@ -2,6 +2,7 @@ package master
 
 import (
 	"github.com/gin-gonic/gin"
+	"thinkingModels/domain/iam/auditLog"
 	"thinkingModels/domain/master/category"
 	"thinkingModels/logic"
 )
@@ -30,7 +31,12 @@ func (l *CategoryLogic) All() (*category.AllReap, error) {
 // Create 创建分类
 func (l *CategoryLogic) Create(req *category.CreateCategory) (*category.CategoryEntity, error) {
 	entity := category.NewCategoryEntity(l.Ctx)
-	return entity.CreateCategory(req)
+	res, err := entity.CreateCategory(req)
+	if err != nil {
+		return nil, err
+	}
+	l.Audit(&auditLog.Entry{Action: auditLog.ActionCategoryCreate, TargetType: auditLog.TargetCategory, TargetId: res.Id, After: res})
+	return res, nil
 }
 
 // Update 更新分类
@@ -39,10 +45,11 @@ func (l *CategoryLogic) Update(req *category.UpdateCategory) (*category.Category
 	entity := category.NewCategoryEntity(l.Ctx)
 
 	// 加载旧数据
-	_, err := entity.LoadById(req.Id)
+	old, err := entity.LoadById(req.Id)
 	if err != nil {
 		return nil, err
 	}
+	before := auditLog.Snapshot(old)
 
 	// 设置新数据
 	_, err = entity.SetData(req)
@@ -61,6 +68,7 @@ func (l *CategoryLogic) Update(req *category.UpdateCategory) (*category.Category
 	if err != nil {
 		return nil, err
 	}
+	l.Audit(&auditLog.Entry{Action: auditLog.ActionCategoryUpdate, TargetType: auditLog.TargetCategory, TargetId: res.Id, Before: before, After: res})
 
 	return res, nil
 }
@@ -105,8 +113,18 @@ func (l *CategoryLogic) List(req *category.SearchCategory) (*category.ListReap,
 // Del 删除分类
 func (l *CategoryLogic) Del(req *category.DelCategory) (any, error) {
 	entity := category.NewCategoryEntity(l.Ctx)
-	err := entity.Del(req.Ids...)
-	return nil, err
+	list, err := entity.ListByIds(req.Ids)
+	if err != nil {
+		return nil, err
+	}
+	err = entity.Del(req.Ids...)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range list {
+		l.Audit(&auditLog.Entry{Action: auditLog.ActionCategoryDelete, TargetType: auditLog.TargetCategory, TargetId: item.Id, Before: item})
+	}
+	return nil, nil
 }
 
 // IncreaseHeat 增加分类热度
@@ -3,6 +3,7 @@ package master
 import (
 	"github.com/gin-gonic/gin"
 
+	"thinkingModels/domain/iam/auditLog"
 	"thinkingModels/domain/master/superDictionary"
 	"thinkingModels/logic"
 )
@@ -40,6 +41,7 @@ func (l *SuperDictionaryLogic) Create(req *superDictionary.CreateSuperDictionary
 	if err != nil {
 		return nil, err
 	}
+	l.Audit(&auditLog.Entry{Action: auditLog.ActionDictCreate, TargetType: auditLog.TargetDictionary, TargetId: res.Id, After: res})
 
 	return res, nil
 }
@@ -51,10 +53,11 @@ func (l *SuperDictionaryLogic) Update(req *superDictionary.UpdateSuperDictionary
 	superDictionaryEntity := superDictionary.NewSuperDictionaryEntity(l.Ctx)
 
 	// 先加载旧数据
-	_, err := superDictionaryEntity.LoadById(req.Id)
+	old, err := superDictionaryEntity.LoadById(req.Id)
 	if err != nil {
 		return nil, err
 	}
+	before := auditLog.Snapshot(old)
 
 	// 数据赋值
 	_, err = superDictionaryEntity.SetData(req)
@@ -70,7 +73,11 @@ func (l *SuperDictionaryLogic) Update(req *superDictionary.UpdateSuperDictionary
 
 	// 更新数据
 	res, err := superDictionaryEntity.Update()
-	return res, err
+	if err != nil {
+		return nil, err
+	}
+	l.Audit(&auditLog.Entry{Action: auditLog.ActionDictUpdate, TargetType: auditLog.TargetDictionary, TargetId: res.Id, Before: before, After: res})
+	return res, nil
 }
 
 // 查询
@@ -123,9 +130,21 @@ func (l *SuperDictionaryLogic) Del(req *superDictionary.DelSuperDictionary) (any
 	// 实例化模型
 	superDictionaryEntity := superDictionary.NewSuperDictionaryEntity(l.Ctx)
 
+	// 删除前留存快照
+	list, err := superDictionaryEntity.ListByIds(req.Ids)
+	if err != nil {
+		return nil, err
+	}
+
 	// 删除数据
-	err := superDictionaryEntity.Del(req.Ids...)
-	return nil, err
+	err = superDictionaryEntity.Del(req.Ids...)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range list {
+		l.Audit(&auditLog.Entry{Action: auditLog.ActionDictDelete, TargetType: auditLog.TargetDictionary, TargetId: item.Id, Before: item})
+	}
+	return nil, nil
 }
 
 // 树形结构
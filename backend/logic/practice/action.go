@@ -1,6 +1,7 @@
 package thinking
 
 import (
+	"thinkingModels/component/metrics"
 	"thinkingModels/domain/practice/action"
 	"thinkingModels/logic"
 
@@ -19,13 +20,29 @@ func NewActionLogic(ctx *gin.Context) *ActionLogic {
 
 // Create 创建行动项
 func (l *ActionLogic) Create(req *action.CreateAction) (*action.ActionInfo, error) {
+	userId, err := l.MustCurrUserId()
+	if err != nil {
+		return nil, err
+	}
+	if req.TopicId > 0 {
+		if err := checkTopicOwner(&l.BaseLogic, req.TopicId); err != nil {
+			return nil, err
+		}
+	}
+	if req.AnalysisId > 0 {
+		if err := checkAnalysisOwner(&l.BaseLogic, req.AnalysisId); err != nil {
+			return nil, err
+		}
+	}
+
 	entity := action.NewActionEntity(l.Ctx)
-	_, err := entity.SetData(req)
+	_, err = entity.SetData(req)
 	if err != nil {
 		return nil, err
 	}
 
 	if concreteEntity, ok := entity.(*action.ActionEntity); ok {
+		concreteEntity.UserId = userId
 		concreteEntity.Status = 0
 		concreteEntity.Progress = 0
 	}
@@ -45,10 +62,13 @@ func (l *ActionLogic) Create(req *action.CreateAction) (*action.ActionInfo, erro
 // Update 更新行动项
 func (l *ActionLogic) Update(req *action.UpdateAction) (*action.ActionInfo, error) {
 	entity := action.NewActionEntity(l.Ctx)
-	_, err := entity.LoadById(req.Id)
+	old, err := entity.LoadById(req.Id)
 	if err != nil {
 		return nil, err
 	}
+	if err := l.CheckOwner(old.UserId); err != nil {
+		return nil, err
+	}
 
 	_, err = entity.SetData(req)
 	if err != nil {
@@ -105,19 +125,43 @@ func (l *ActionLogic) List(req *action.SearchAction) (*logic.ListReap, error) {
 	}, nil
 }
 
-// ListMy 查询我的行动项
+// ListMy 查询我的行动项（强制按当前用户过滤）
 func (l *ActionLogic) ListMy(req *action.SearchAction) (*logic.ListReap, error) {
+	userId, err := l.MustCurrUserId()
+	if err != nil {
+		return nil, err
+	}
+	req.UserId = userId
 	return l.List(req)
 }
 
 // Del 删除行动项
 func (l *ActionLogic) Del(req *action.DelAction) error {
 	entity := action.NewActionEntity(l.Ctx)
+	list, err := entity.ListByIds(req.Ids)
+	if err != nil {
+		return err
+	}
+	ownerIds := make([]uint64, 0, len(list))
+	for _, item := range list {
+		ownerIds = append(ownerIds, item.UserId)
+	}
+	if err := l.CheckOwner(ownerIds...); err != nil {
+		return err
+	}
 	return entity.Del(req.Ids...)
 }
 
 // CreateFromAnalysis 从分析创建行动项
 func (l *ActionLogic) CreateFromAnalysis(req *action.CreateActionsFromAnalysis) ([]*action.ActionInfo, error) {
+	userId, err := l.MustCurrUserId()
+	if err != nil {
+		return nil, err
+	}
+	if err := checkAnalysisOwner(&l.BaseLogic, req.AnalysisId); err != nil {
+		return nil, err
+	}
+
 	result := make([]*action.ActionInfo, 0, len(req.Actions))
 
 	for _, actionReq := range req.Actions {
@@ -128,6 +172,7 @@ func (l *ActionLogic) CreateFromAnalysis(req *action.CreateActionsFromAnalysis)
 		}
 
 		if concreteEntity, ok := entity.(*action.ActionEntity); ok {
+			concreteEntity.UserId = userId
 			concreteEntity.TopicId = req.TopicId
 			concreteEntity.AnalysisId = req.AnalysisId
 			concreteEntity.Status = 0
@@ -184,42 +229,62 @@ func (l *ActionLogic) ListByAnalysis(analysisId uint64) ([]*action.ActionInfo, e
 // UpdateProgress 更新进度
 func (l *ActionLogic) UpdateProgress(req *action.UpdateProgress) error {
 	entity := action.NewActionEntity(l.Ctx)
-	_, err := entity.LoadById(req.Id)
+	old, err := entity.LoadById(req.Id)
 	if err != nil {
 		return err
 	}
+	if err := l.CheckOwner(old.UserId); err != nil {
+		return err
+	}
+	wasCompleted := old.Status == 2 // 已完成
 
 	if err := entity.UpdateProgress(req.Progress, req.Note); err != nil {
 		return err
 	}
 
-	_, err = entity.Update()
-	return err
+	res, err := entity.Update()
+	if err != nil {
+		return err
+	}
+	// 进度达到100%时自动完成，与 Complete 一样计入完成数
+	if !wasCompleted && res.Status == 2 {
+		metrics.ActionsCompleted.Inc()
+	}
+	return nil
 }
 
 // Complete 完成行动项
 func (l *ActionLogic) Complete(id uint64) error {
 	entity := action.NewActionEntity(l.Ctx)
-	_, err := entity.LoadById(id)
+	old, err := entity.LoadById(id)
 	if err != nil {
 		return err
 	}
+	if err := l.CheckOwner(old.UserId); err != nil {
+		return err
+	}
 
 	if err := entity.MarkComplete(); err != nil {
 		return err
 	}
 
-	_, err = entity.Update()
-	return err
+	if _, err = entity.Update(); err != nil {
+		return err
+	}
+	metrics.ActionsCompleted.Inc()
+	return nil
 }
 
 // Cancel 取消行动项
 func (l *ActionLogic) Cancel(id uint64) error {
 	entity := action.NewActionEntity(l.Ctx)
-	_, err := entity.LoadById(id)
+	old, err := entity.LoadById(id)
 	if err != nil {
 		return err
 	}
+	if err := l.CheckOwner(old.UserId); err != nil {
+		return err
+	}
 
 	if err := entity.Cancel(); err != nil {
 		return err
@@ -1,6 +1,7 @@
 package thinking
 
 import (
+	"thinkingModels/component/metrics"
 	"thinkingModels/domain/practice/analysis"
 	"thinkingModels/logic"
 
@@ -19,13 +20,22 @@ func NewAnalysisLogic(ctx *gin.Context) *AnalysisLogic {
 
 // Create 创建分析
 func (l *AnalysisLogic) Create(req *analysis.CreateAnalysis) (*analysis.AnalysisInfo, error) {
+	userId, err := l.MustCurrUserId()
+	if err != nil {
+		return nil, err
+	}
+	if err := checkTopicOwner(&l.BaseLogic, req.TopicId); err != nil {
+		return nil, err
+	}
+
 	entity := analysis.NewAnalysisEntity(l.Ctx)
-	_, err := entity.SetData(req)
+	_, err = entity.SetData(req)
 	if err != nil {
 		return nil, err
 	}
 
 	if concreteEntity, ok := entity.(*analysis.AnalysisEntity); ok {
+		concreteEntity.UserId = userId
 		concreteEntity.Version = 1
 		concreteEntity.IsCurrent = true
 		concreteEntity.Status = 0
@@ -39,6 +49,7 @@ func (l *AnalysisLogic) Create(req *analysis.CreateAnalysis) (*analysis.Analysis
 	if err != nil {
 		return nil, err
 	}
+	metrics.AnalysesCreated.WithLabelValues("practice").Inc()
 
 	return convertToAnalysisInfo(res), nil
 }
@@ -46,10 +57,13 @@ func (l *AnalysisLogic) Create(req *analysis.CreateAnalysis) (*analysis.Analysis
 // Update 更新分析
 func (l *AnalysisLogic) Update(req *analysis.UpdateAnalysis) (*analysis.AnalysisInfo, error) {
 	entity := analysis.NewAnalysisEntity(l.Ctx)
-	_, err := entity.LoadById(req.Id)
+	old, err := entity.LoadById(req.Id)
 	if err != nil {
 		return nil, err
 	}
+	if err := l.CheckOwner(old.UserId); err != nil {
+		return nil, err
+	}
 
 	_, err = entity.SetData(req)
 	if err != nil {
@@ -106,32 +120,63 @@ func (l *AnalysisLogic) List(req *analysis.SearchAnalysis) (*logic.ListReap, err
 	}, nil
 }
 
-// ListMy 查询我的分析
+// ListMy 查询我的分析（强制按当前用户过滤）
 func (l *AnalysisLogic) ListMy(req *analysis.SearchAnalysis) (*logic.ListReap, error) {
+	userId, err := l.MustCurrUserId()
+	if err != nil {
+		return nil, err
+	}
+	req.UserId = userId
 	return l.List(req)
 }
 
 // Del 删除分析
 func (l *AnalysisLogic) Del(req *analysis.DelAnalysis) error {
 	entity := analysis.NewAnalysisEntity(l.Ctx)
+	list, err := entity.ListByIds(req.Ids)
+	if err != nil {
+		return err
+	}
+	ownerIds := make([]uint64, 0, len(list))
+	for _, item := range list {
+		ownerIds = append(ownerIds, item.UserId)
+	}
+	if err := l.CheckOwner(ownerIds...); err != nil {
+		return err
+	}
 	return entity.Del(req.Ids...)
 }
 
 // SaveWithAi 保存并AI分析
 func (l *AnalysisLogic) SaveWithAi(req *analysis.SaveWithAi) (*analysis.AnalysisInfo, error) {
+	userId, err := l.MustCurrUserId()
+	if err != nil {
+		return nil, err
+	}
+
 	entity := analysis.NewAnalysisEntity(l.Ctx)
 
 	if req.Id > 0 {
-		_, err := entity.LoadById(req.Id)
+		old, err := entity.LoadById(req.Id)
 		if err != nil {
 			return nil, err
 		}
+		if err := l.CheckOwner(old.UserId); err != nil {
+			return nil, err
+		}
+	} else if err := checkTopicOwner(&l.BaseLogic, req.TopicId); err != nil {
+		return nil, err
 	}
 
-	_, err := entity.SetData(req)
+	_, err = entity.SetData(req)
 	if err != nil {
 		return nil, err
 	}
+	if req.Id == 0 {
+		if concreteEntity, ok := entity.(*analysis.AnalysisEntity); ok {
+			concreteEntity.UserId = userId
+		}
+	}
 
 	var res any
 	if req.Id > 0 {
@@ -143,6 +188,10 @@ func (l *AnalysisLogic) SaveWithAi(req *analysis.SaveWithAi) (*analysis.Analysis
 	if err != nil {
 		return nil, err
 	}
+	if req.Id == 0 {
+		metrics.AnalysesCreated.WithLabelValues("practice").Inc()
+	}
+	metrics.AddAiTokens(req.Usage)
 
 	return convertToAnalysisInfo(res), nil
 }
@@ -232,10 +281,13 @@ func (l *AnalysisLogic) GetHistory(topicId, modelId uint64) ([]*analysis.Analysi
 // SetCurrent 设为当前版本
 func (l *AnalysisLogic) SetCurrent(req *analysis.SetCurrentAnalysis) error {
 	entity := analysis.NewAnalysisEntity(l.Ctx)
-	_, err := entity.LoadById(req.Id)
+	old, err := entity.LoadById(req.Id)
 	if err != nil {
 		return err
 	}
+	if err := l.CheckOwner(old.UserId); err != nil {
+		return err
+	}
 
 	if err := entity.SetAsCurrent(); err != nil {
 		return err
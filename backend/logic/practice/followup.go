@@ -19,13 +19,22 @@ func NewFollowUpLogic(ctx *gin.Context) *FollowUpLogic {
 
 // Create 创建跟进记录
 func (l *FollowUpLogic) Create(req *followup.CreateFollowUp) (*followup.FollowUpInfo, error) {
+	userId, err := l.MustCurrUserId()
+	if err != nil {
+		return nil, err
+	}
+	if err := checkActionOwner(&l.BaseLogic, req.ActionId); err != nil {
+		return nil, err
+	}
+
 	entity := followup.NewFollowUpEntity(l.Ctx)
-	_, err := entity.SetData(req)
+	_, err = entity.SetData(req)
 	if err != nil {
 		return nil, err
 	}
 
 	if concreteEntity, ok := entity.(*followup.FollowUpEntity); ok {
+		concreteEntity.UserId = userId
 		concreteEntity.SetProgressChange(req.ProgressBefore, req.ProgressAfter)
 	}
 
@@ -44,10 +53,13 @@ func (l *FollowUpLogic) Create(req *followup.CreateFollowUp) (*followup.FollowUp
 // Update 更新跟进记录
 func (l *FollowUpLogic) Update(req *followup.UpdateFollowUp) (*followup.FollowUpInfo, error) {
 	entity := followup.NewFollowUpEntity(l.Ctx)
-	_, err := entity.LoadById(req.Id)
+	old, err := entity.LoadById(req.Id)
 	if err != nil {
 		return nil, err
 	}
+	if err := l.CheckOwner(old.UserId); err != nil {
+		return nil, err
+	}
 
 	_, err = entity.SetData(req)
 	if err != nil {
@@ -79,6 +91,17 @@ func (l *FollowUpLogic) Get(id uint64) (*followup.FollowUpInfo, error) {
 // Del 删除跟进记录
 func (l *FollowUpLogic) Del(req *followup.DelFollowUp) error {
 	entity := followup.NewFollowUpEntity(l.Ctx)
+	list, err := entity.ListByIds(req.Ids)
+	if err != nil {
+		return err
+	}
+	ownerIds := make([]uint64, 0, len(list))
+	for _, item := range list {
+		ownerIds = append(ownerIds, item.UserId)
+	}
+	if err := l.CheckOwner(ownerIds...); err != nil {
+		return err
+	}
 	return entity.Del(req.Ids...)
 }
 
@@ -1,12 +1,17 @@
 package thinking
 
 import (
-	"errors"
+	"slices"
+	"strconv"
 
+	"thinkingModels/domain/iam/auditLog"
+	"thinkingModels/domain/iam/user"
 	"thinkingModels/domain/practice/model"
 	"thinkingModels/logic"
 
 	"github.com/gin-gonic/gin"
+	"thinkingModels/component/errs"
+	"thinkingModels/component/metrics"
 )
 
 // ModelLogic 思维模型业务逻辑
@@ -21,13 +26,22 @@ func NewModelLogic(ctx *gin.Context) *ModelLogic {
 
 // Create 创建思维模型
 func (l *ModelLogic) Create(req *model.CreateModel) (*model.ModelInfo, error) {
+	userId, err := l.MustCurrUserId()
+	if err != nil {
+		return nil, err
+	}
+
 	entity := model.NewModelEntity(l.Ctx)
-	_, err := entity.SetData(req)
+	_, err = entity.SetData(req)
 	if err != nil {
 		return nil, err
 	}
 
 	if concreteEntity, ok := entity.(*model.ModelEntity); ok {
+		concreteEntity.AuthorId = userId
+		if concreteEntity.AuthorName == "" {
+			concreteEntity.AuthorName = l.Ctx.GetString("currUserName")
+		}
 		concreteEntity.Status = 0
 		concreteEntity.Version = "1.0.0"
 		if concreteEntity.Difficulty == 0 {
@@ -53,10 +67,13 @@ func (l *ModelLogic) Create(req *model.CreateModel) (*model.ModelInfo, error) {
 // Update 更新思维模型
 func (l *ModelLogic) Update(req *model.UpdateModel) (*model.ModelInfo, error) {
 	entity := model.NewModelEntity(l.Ctx)
-	_, err := entity.LoadById(req.Id)
+	old, err := entity.LoadById(req.Id)
 	if err != nil {
 		return nil, err
 	}
+	if err := l.CheckOwner(old.AuthorId); err != nil {
+		return nil, err
+	}
 
 	_, err = entity.SetData(req)
 	if err != nil {
@@ -82,7 +99,9 @@ func (l *ModelLogic) Get(id uint64) (*model.ModelDetail, error) {
 	if err != nil {
 		return nil, err
 	}
-	return convertToModelDetail(res), nil
+	detail := convertToModelDetail(res)
+	l.fillAuthors(&detail.ModelInfo)
+	return detail, nil
 }
 
 // GetByCode 按编码查询
@@ -94,9 +113,11 @@ func (l *ModelLogic) GetByCode(code string) (*model.ModelDetail, error) {
 		return nil, err
 	}
 	if len(list) == 0 {
-		return nil, errors.New("模型不存在")
+		return nil, errs.NotFound("模型不存在")
 	}
-	return convertToModelDetail(list[0]), nil
+	detail := convertToModelDetail(list[0])
+	l.fillAuthors(&detail.ModelInfo)
+	return detail, nil
 }
 
 // List 查询思维模型列表
@@ -118,6 +139,7 @@ func (l *ModelLogic) List(req *model.SearchModel) (*model.ListModelResponse, err
 	for _, item := range list {
 		modelInfoList = append(modelInfoList, convertToModelInfo(item))
 	}
+	l.fillAuthors(modelInfoList...)
 
 	return &model.ListModelResponse{
 		Page:     req.Page,
@@ -127,24 +149,50 @@ func (l *ModelLogic) List(req *model.SearchModel) (*model.ListModelResponse, err
 	}, nil
 }
 
-// ListMy 查询我的思维模型
+// ListMy 查询我的思维模型（强制按当前作者过滤）
 func (l *ModelLogic) ListMy(req *model.SearchModel) (*model.ListModelResponse, error) {
+	userId, err := l.MustCurrUserId()
+	if err != nil {
+		return nil, err
+	}
+	req.AuthorId = userId
 	return l.List(req)
 }
 
 // Del 删除思维模型
 func (l *ModelLogic) Del(ids []uint64) error {
 	entity := model.NewModelEntity(l.Ctx)
-	return entity.Del(ids...)
+	list, err := entity.ListByIds(ids)
+	if err != nil {
+		return err
+	}
+	ownerIds := make([]uint64, 0, len(list))
+	for _, item := range list {
+		ownerIds = append(ownerIds, item.AuthorId)
+	}
+	if err := l.CheckOwner(ownerIds...); err != nil {
+		return err
+	}
+	if err := entity.Del(ids...); err != nil {
+		return err
+	}
+	for _, item := range list {
+		l.Audit(&auditLog.Entry{Action: auditLog.ActionModelDelete, TargetType: auditLog.TargetModel, TargetId: item.Id, Before: item})
+	}
+	return nil
 }
 
 // Publish 发布思维模型
 func (l *ModelLogic) Publish(req *model.PublishModel) (*model.ModelInfo, error) {
 	entity := model.NewModelEntity(l.Ctx)
-	_, err := entity.LoadById(req.Id)
+	old, err := entity.LoadById(req.Id)
 	if err != nil {
 		return nil, err
 	}
+	if err := l.CheckOwner(old.AuthorId); err != nil {
+		return nil, err
+	}
+	before := auditLog.Snapshot(old)
 
 	if err := entity.Publish(); err != nil {
 		return nil, err
@@ -154,6 +202,8 @@ func (l *ModelLogic) Publish(req *model.PublishModel) (*model.ModelInfo, error)
 	if err != nil {
 		return nil, err
 	}
+	l.Audit(&auditLog.Entry{Action: auditLog.ActionModelPublish, TargetType: auditLog.TargetModel, TargetId: res.Id, Before: before, After: res})
+	metrics.ModelsPublished.Inc()
 
 	return convertToModelInfo(res), nil
 }
@@ -161,10 +211,14 @@ func (l *ModelLogic) Publish(req *model.PublishModel) (*model.ModelInfo, error)
 // Unpublish 下架思维模型
 func (l *ModelLogic) Unpublish(id uint64) (*model.ModelInfo, error) {
 	entity := model.NewModelEntity(l.Ctx)
-	_, err := entity.LoadById(id)
+	old, err := entity.LoadById(id)
 	if err != nil {
 		return nil, err
 	}
+	if err := l.CheckOwner(old.AuthorId); err != nil {
+		return nil, err
+	}
+	before := auditLog.Snapshot(old)
 
 	if err := entity.Unpublish(); err != nil {
 		return nil, err
@@ -174,14 +228,45 @@ func (l *ModelLogic) Unpublish(id uint64) (*model.ModelInfo, error) {
 	if err != nil {
 		return nil, err
 	}
+	l.Audit(&auditLog.Entry{Action: auditLog.ActionModelUnpublish, TargetType: auditLog.TargetModel, TargetId: res.Id, Before: before, After: res})
+
+	return convertToModelInfo(res), nil
+}
+
+// Share 设置思维模型跨企业共享
+func (l *ModelLogic) Share(req *model.ShareModel) (*model.ModelInfo, error) {
+	entity := model.NewModelEntity(l.Ctx)
+	old, err := entity.LoadById(req.Id)
+	if err != nil {
+		return nil, err
+	}
+	if err := l.CheckOwner(old.AuthorId); err != nil {
+		return nil, err
+	}
+	before := auditLog.Snapshot(old)
+
+	if err := entity.Share(req.IsShared); err != nil {
+		return nil, err
+	}
+
+	res, err := entity.Update()
+	if err != nil {
+		return nil, err
+	}
+	l.Audit(&auditLog.Entry{Action: auditLog.ActionModelShare, TargetType: auditLog.TargetModel, TargetId: res.Id, Before: before, After: res})
 
 	return convertToModelInfo(res), nil
 }
 
 // Fork 派生思维模型
 func (l *ModelLogic) Fork(req *model.ForkModel) (*model.ModelInfo, error) {
+	userId, err := l.MustCurrUserId()
+	if err != nil {
+		return nil, err
+	}
+
 	entity := model.NewModelEntity(l.Ctx)
-	_, err := entity.LoadById(req.SourceModelId)
+	_, err = entity.LoadById(req.SourceModelId)
 	if err != nil {
 		return nil, err
 	}
@@ -190,6 +275,8 @@ func (l *ModelLogic) Fork(req *model.ForkModel) (*model.ModelInfo, error) {
 	if concreteEntity, ok := forkedEntity.(*model.ModelEntity); ok {
 		concreteEntity.Name = req.Name
 		concreteEntity.Description = req.Description
+		concreteEntity.AuthorId = userId
+		concreteEntity.AuthorName = l.Ctx.GetString("currUserName")
 	}
 
 	res, err := forkedEntity.Create()
@@ -200,6 +287,38 @@ func (l *ModelLogic) Fork(req *model.ForkModel) (*model.ModelInfo, error) {
 	return convertToModelInfo(res), nil
 }
 
+// fillAuthors 批量查询作者（一次查询）填充头像，模型未记录作者名称时使用作者昵称
+// 作者不存在（如已注销）时保留模型上记录的作者信息
+func (l *ModelLogic) fillAuthors(infos ...*model.ModelInfo) {
+	ids := make([]uint64, 0, len(infos))
+	for _, info := range infos {
+		id, _ := strconv.ParseUint(info.Author.Id, 10, 64)
+		if id > 0 && !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return
+	}
+	authors, err := user.NewUserEntity(l.Ctx).ListByIds(ids)
+	if err != nil {
+		return
+	}
+
+	byId := make(map[string]*user.UserEntity, len(authors))
+	for _, author := range authors {
+		byId[strconv.FormatUint(author.Id, 10)] = author
+	}
+	for _, info := range infos {
+		if author, ok := byId[info.Author.Id]; ok {
+			if info.Author.Name == "" {
+				info.Author.Name = author.DisplayName()
+			}
+			info.Author.Avatar = author.Avatar
+		}
+	}
+}
+
 func convertToModelInfo(entity any) *model.ModelInfo {
 	e, ok := entity.(*model.ModelEntity)
 	if !ok {
@@ -211,13 +330,22 @@ func convertToModelInfo(entity any) *model.ModelInfo {
 		Name:          e.Name,
 		Description:   e.Description,
 		Overview:      e.Overview,
+		CoverImage:    e.CoverImage,
 		Icon:          e.Icon,
 		CategoryId:    e.CategoryId,
+		Price:         e.Price,
+		IsFree:        e.Price == 0,
 		Difficulty:    e.Difficulty,
 		EstimatedTime: e.EstimatedTime,
 		Status:        e.Status,
 		Version:       e.Version,
 		IsOfficial:    e.IsOfficial,
+		IsShared:      e.IsShared,
+		SourceModelId: e.SourceModelId,
+		Author: model.ModelAuthor{
+			Id:   strconv.FormatUint(e.AuthorId, 10),
+			Name: e.AuthorName,
+		},
 		Stats: model.ModelStats{
 			UsageCount:   int(e.UsageCount),
 			AdoptCount:   int(e.AdoptCount),
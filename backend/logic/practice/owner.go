@@ -0,0 +1,35 @@
+package thinking
+
+import (
+	"thinkingModels/domain/practice/action"
+	"thinkingModels/domain/practice/analysis"
+	"thinkingModels/domain/practice/topic"
+	"thinkingModels/logic"
+)
+
+// checkTopicOwner 校验课题归属当前用户
+func checkTopicOwner(l *logic.BaseLogic, topicId uint64) error {
+	res, err := topic.NewTopicEntity(l.Ctx).LoadById(topicId)
+	if err != nil {
+		return err
+	}
+	return l.CheckOwner(res.UserId)
+}
+
+// checkAnalysisOwner 校验分析归属当前用户
+func checkAnalysisOwner(l *logic.BaseLogic, analysisId uint64) error {
+	res, err := analysis.NewAnalysisEntity(l.Ctx).LoadById(analysisId)
+	if err != nil {
+		return err
+	}
+	return l.CheckOwner(res.UserId)
+}
+
+// checkActionOwner 校验行动项归属当前用户
+func checkActionOwner(l *logic.BaseLogic, actionId uint64) error {
+	res, err := action.NewActionEntity(l.Ctx).LoadById(actionId)
+	if err != nil {
+		return err
+	}
+	return l.CheckOwner(res.UserId)
+}
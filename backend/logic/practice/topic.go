@@ -19,13 +19,19 @@ func NewTopicLogic(ctx *gin.Context) *TopicLogic {
 
 // Create 创建课题
 func (l *TopicLogic) Create(req *topic.CreateTopic) (*topic.TopicInfo, error) {
+	userId, err := l.MustCurrUserId()
+	if err != nil {
+		return nil, err
+	}
+
 	entity := topic.NewTopicEntity(l.Ctx)
-	_, err := entity.SetData(req)
+	_, err = entity.SetData(req)
 	if err != nil {
 		return nil, err
 	}
 
 	if concreteEntity, ok := entity.(*topic.TopicEntity); ok {
+		concreteEntity.UserId = userId
 		concreteEntity.Status = 0
 	}
 
@@ -44,10 +50,13 @@ func (l *TopicLogic) Create(req *topic.CreateTopic) (*topic.TopicInfo, error) {
 // Update 更新课题
 func (l *TopicLogic) Update(req *topic.UpdateTopic) (*topic.TopicInfo, error) {
 	entity := topic.NewTopicEntity(l.Ctx)
-	_, err := entity.LoadById(req.Id)
+	old, err := entity.LoadById(req.Id)
 	if err != nil {
 		return nil, err
 	}
+	if err := l.CheckOwner(old.UserId); err != nil {
+		return nil, err
+	}
 
 	_, err = entity.SetData(req)
 	if err != nil {
@@ -104,24 +113,43 @@ func (l *TopicLogic) List(req *topic.SearchTopic) (*logic.ListReap, error) {
 	}, nil
 }
 
-// ListMy 查询我的课题
+// ListMy 查询我的课题（强制按当前用户过滤）
 func (l *TopicLogic) ListMy(req *topic.SearchTopic) (*logic.ListReap, error) {
+	userId, err := l.MustCurrUserId()
+	if err != nil {
+		return nil, err
+	}
+	req.UserId = userId
 	return l.List(req)
 }
 
 // Del 删除课题
 func (l *TopicLogic) Del(req *topic.DelTopic) error {
 	entity := topic.NewTopicEntity(l.Ctx)
+	list, err := entity.ListByIds(req.Ids)
+	if err != nil {
+		return err
+	}
+	ownerIds := make([]uint64, 0, len(list))
+	for _, item := range list {
+		ownerIds = append(ownerIds, item.UserId)
+	}
+	if err := l.CheckOwner(ownerIds...); err != nil {
+		return err
+	}
 	return entity.Del(req.Ids...)
 }
 
 // SelectModel 选择模型
 func (l *TopicLogic) SelectModel(req *topic.SelectModelForTopic) error {
 	entity := topic.NewTopicEntity(l.Ctx)
-	_, err := entity.LoadById(req.TopicId)
+	old, err := entity.LoadById(req.TopicId)
 	if err != nil {
 		return err
 	}
+	if err := l.CheckOwner(old.UserId); err != nil {
+		return err
+	}
 	if err := entity.SelectModel(req.ModelId, req.ModelName); err != nil {
 		return err
 	}
@@ -132,10 +160,13 @@ func (l *TopicLogic) SelectModel(req *topic.SelectModelForTopic) error {
 // RemoveModel 移除模型
 func (l *TopicLogic) RemoveModel(topicId uint64) error {
 	entity := topic.NewTopicEntity(l.Ctx)
-	_, err := entity.LoadById(topicId)
+	old, err := entity.LoadById(topicId)
 	if err != nil {
 		return err
 	}
+	if err := l.CheckOwner(old.UserId); err != nil {
+		return err
+	}
 	if err := entity.RemoveModel(); err != nil {
 		return err
 	}
@@ -146,10 +177,13 @@ func (l *TopicLogic) RemoveModel(topicId uint64) error {
 // UpdateStatus 更新状态
 func (l *TopicLogic) UpdateStatus(req *topic.UpdateTopicStatus) error {
 	entity := topic.NewTopicEntity(l.Ctx)
-	_, err := entity.LoadById(req.Id)
+	old, err := entity.LoadById(req.Id)
 	if err != nil {
 		return err
 	}
+	if err := l.CheckOwner(old.UserId); err != nil {
+		return err
+	}
 
 	if concreteEntity, ok := entity.(*topic.TopicEntity); ok {
 		concreteEntity.Status = req.Status
@@ -162,10 +196,13 @@ func (l *TopicLogic) UpdateStatus(req *topic.UpdateTopicStatus) error {
 // Complete 完成课题
 func (l *TopicLogic) Complete(id uint64) error {
 	entity := topic.NewTopicEntity(l.Ctx)
-	_, err := entity.LoadById(id)
+	old, err := entity.LoadById(id)
 	if err != nil {
 		return err
 	}
+	if err := l.CheckOwner(old.UserId); err != nil {
+		return err
+	}
 
 	if err := entity.MarkComplete(); err != nil {
 		return err
@@ -178,10 +215,13 @@ func (l *TopicLogic) Complete(id uint64) error {
 // Archive 归档课题
 func (l *TopicLogic) Archive(id uint64) error {
 	entity := topic.NewTopicEntity(l.Ctx)
-	_, err := entity.LoadById(id)
+	old, err := entity.LoadById(id)
 	if err != nil {
 		return err
 	}
+	if err := l.CheckOwner(old.UserId); err != nil {
+		return err
+	}
 
 	if err := entity.Archive(); err != nil {
 		return err
@@ -194,10 +234,13 @@ func (l *TopicLogic) Archive(id uint64) error {
 // Reopen 重新打开课题
 func (l *TopicLogic) Reopen(id uint64) error {
 	entity := topic.NewTopicEntity(l.Ctx)
-	_, err := entity.LoadById(id)
+	old, err := entity.LoadById(id)
 	if err != nil {
 		return err
 	}
+	if err := l.CheckOwner(old.UserId); err != nil {
+		return err
+	}
 
 	if err := entity.Reopen(); err != nil {
 		return err
@@ -2,10 +2,11 @@ package subject
 
 import (
 	"encoding/json"
-	"errors"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"thinkingModels/component/errs"
+	"thinkingModels/component/metrics"
 	"thinkingModels/domain/subject/analysis"
 	"thinkingModels/logic"
 )
@@ -25,11 +26,9 @@ func (l *AnalysisLogic) Create(req *analysis.CreateAnalysis) (*analysis.CreateAn
 	// 实例化模型
 	entity := analysis.NewAnalysisEntity(l.Ctx)
 
-	// 验证课题是否存在
-	topicLogic := NewTopicLogic(l.Ctx)
-	_, err := topicLogic.GetSimple(req.TopicId)
-	if err != nil {
-		return nil, errors.New("课题不存在")
+	// 验证课题存在且归属当前用户
+	if err := checkTopicOwner(&l.BaseLogic, req.TopicId); err != nil {
+		return nil, err
 	}
 
 	// 计算版本号
@@ -72,6 +71,7 @@ func (l *AnalysisLogic) Create(req *analysis.CreateAnalysis) (*analysis.CreateAn
 	if err != nil {
 		return nil, err
 	}
+	metrics.AnalysesCreated.WithLabelValues("subject").Inc()
 
 	// 返回响应
 	info := convertToAnalysisInfo(res)
@@ -86,10 +86,13 @@ func (l *AnalysisLogic) Update(req *analysis.UpdateAnalysis) (*analysis.Analysis
 	entity := analysis.NewAnalysisEntity(l.Ctx)
 
 	// 加载旧数据
-	_, err := entity.LoadById(req.Id)
+	old, err := entity.LoadById(req.Id)
 	if err != nil {
 		return nil, err
 	}
+	if err := l.CheckOwner(old.UserId); err != nil {
+		return nil, err
+	}
 
 	// 更新数据
 	if concreteEntity, ok := entity.(*analysis.AnalysisEntity); ok {
@@ -120,10 +123,13 @@ func (l *AnalysisLogic) SaveWithAi(req *analysis.SaveAnalysisWithAi) (*analysis.
 	entity := analysis.NewAnalysisEntity(l.Ctx)
 
 	// 加载旧数据
-	_, err := entity.LoadById(req.Id)
+	old, err := entity.LoadById(req.Id)
 	if err != nil {
 		return nil, err
 	}
+	if err := l.CheckOwner(old.UserId); err != nil {
+		return nil, err
+	}
 
 	// 更新数据
 	if concreteEntity, ok := entity.(*analysis.AnalysisEntity); ok {
@@ -141,6 +147,7 @@ func (l *AnalysisLogic) SaveWithAi(req *analysis.SaveAnalysisWithAi) (*analysis.
 	if err != nil {
 		return nil, err
 	}
+	metrics.AddAiTokens(req.Usage)
 
 	return convertToAnalysisInfo(res), nil
 }
@@ -152,12 +159,18 @@ func (l *AnalysisLogic) Get(id uint64) (*analysis.AnalysisDetail, error) {
 	if err != nil {
 		return nil, err
 	}
+	if err := l.CheckOwner(res.UserId); err != nil {
+		return nil, err
+	}
 
 	return convertToAnalysisDetail(res), nil
 }
 
 // GetByTopic 查询课题的所有分析记录
 func (l *AnalysisLogic) GetByTopic(topicId uint64) ([]*analysis.AnalysisInfo, error) {
+	if err := checkTopicOwner(&l.BaseLogic, topicId); err != nil {
+		return nil, err
+	}
 	entity := analysis.NewAnalysisEntity(l.Ctx)
 	search := &analysis.SearchAnalysis{TopicId: topicId, PageSize: 1000}
 	cond := entity.MakeConditon(*search)
@@ -177,6 +190,9 @@ func (l *AnalysisLogic) GetByTopic(topicId uint64) ([]*analysis.AnalysisInfo, er
 
 // GetCurrentByTopic 获取课题当前使用的分析记录
 func (l *AnalysisLogic) GetCurrentByTopic(topicId uint64) (*analysis.AnalysisInfo, error) {
+	if err := checkTopicOwner(&l.BaseLogic, topicId); err != nil {
+		return nil, err
+	}
 	entity := analysis.NewAnalysisEntity(l.Ctx)
 	search := &analysis.SearchAnalysis{
 		TopicId:   topicId,
@@ -191,7 +207,7 @@ func (l *AnalysisLogic) GetCurrentByTopic(topicId uint64) (*analysis.AnalysisInf
 	}
 
 	if len(list) == 0 {
-		return nil, errors.New("未找到当前分析记录")
+		return nil, errs.NotFound("未找到当前分析记录")
 	}
 
 	return convertToAnalysisInfo(list[0]), nil
@@ -199,6 +215,9 @@ func (l *AnalysisLogic) GetCurrentByTopic(topicId uint64) (*analysis.AnalysisInf
 
 // GetLatestByTopic 获取课题最新的分析记录（按版本号）
 func (l *AnalysisLogic) GetLatestByTopic(topicId uint64) (*analysis.AnalysisInfo, error) {
+	if err := checkTopicOwner(&l.BaseLogic, topicId); err != nil {
+		return nil, err
+	}
 	entity := analysis.NewAnalysisEntity(l.Ctx)
 	search := &analysis.SearchAnalysis{
 		TopicId:  topicId,
@@ -212,7 +231,7 @@ func (l *AnalysisLogic) GetLatestByTopic(topicId uint64) (*analysis.AnalysisInfo
 	}
 
 	if len(list) == 0 {
-		return nil, errors.New("未找到分析记录")
+		return nil, errs.NotFound("未找到分析记录")
 	}
 
 	// 找出最新版本
@@ -228,8 +247,14 @@ func (l *AnalysisLogic) GetLatestByTopic(topicId uint64) (*analysis.AnalysisInfo
 	return convertToAnalysisInfo(latest), nil
 }
 
-// List 查询分析记录列表
+// List 查询分析记录列表（仅当前用户的记录）
 func (l *AnalysisLogic) List(req *analysis.SearchAnalysis) (*analysis.ListAnalysisResponse, error) {
+	userId, err := l.MustCurrUserId()
+	if err != nil {
+		return nil, err
+	}
+	req.UserId = userId
+
 	entity := analysis.NewAnalysisEntity(l.Ctx)
 
 	cond := entity.MakeConditon(*req)
@@ -258,6 +283,9 @@ func (l *AnalysisLogic) List(req *analysis.SearchAnalysis) (*analysis.ListAnalys
 
 // GetHistory 获取分析记录历史（同一课题同一模型的所有版本）
 func (l *AnalysisLogic) GetHistory(topicId, modelId uint64) (*analysis.AnalysisHistory, error) {
+	if err := checkTopicOwner(&l.BaseLogic, topicId); err != nil {
+		return nil, err
+	}
 	entity := analysis.NewAnalysisEntity(l.Ctx)
 	search := &analysis.SearchAnalysis{
 		TopicId:  topicId,
@@ -297,10 +325,13 @@ func (l *AnalysisLogic) SetCurrent(req *analysis.SetCurrentVersion) (*analysis.A
 	if err != nil {
 		return nil, err
 	}
+	if err := l.CheckOwner(target.UserId); err != nil {
+		return nil, err
+	}
 
 	// 验证topicId匹配
 	if target.TopicId != req.TopicId {
-		return nil, errors.New("课题ID不匹配")
+		return nil, errs.Validation("课题ID不匹配")
 	}
 
 	// 先将同一课题同一模型的其他记录设置为非当前
@@ -323,6 +354,11 @@ func (l *AnalysisLogic) SetCurrent(req *analysis.SetCurrentVersion) (*analysis.A
 
 // Del 删除分析记录
 func (l *AnalysisLogic) Del(ids []uint64) (any, error) {
+	for _, id := range ids {
+		if err := checkAnalysisOwner(&l.BaseLogic, id); err != nil {
+			return nil, err
+		}
+	}
 	entity := analysis.NewAnalysisEntity(l.Ctx)
 	err := entity.Del(ids...)
 	return nil, err
@@ -0,0 +1,25 @@
+package subject
+
+import (
+	"thinkingModels/domain/subject/analysis"
+	"thinkingModels/domain/subject/topic"
+	"thinkingModels/logic"
+)
+
+// checkTopicOwner 校验课题归属当前用户
+func checkTopicOwner(l *logic.BaseLogic, topicId uint64) error {
+	res, err := topic.NewTopicEntity(l.Ctx).LoadById(topicId)
+	if err != nil {
+		return err
+	}
+	return l.CheckOwner(res.UserId)
+}
+
+// checkAnalysisOwner 校验分析记录归属当前用户
+func checkAnalysisOwner(l *logic.BaseLogic, analysisId uint64) error {
+	res, err := analysis.NewAnalysisEntity(l.Ctx).LoadById(analysisId)
+	if err != nil {
+		return err
+	}
+	return l.CheckOwner(res.UserId)
+}
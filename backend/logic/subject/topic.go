@@ -82,6 +82,9 @@ func (l *TopicLogic) Update(req *topic.UpdateTopic) (*topic.TopicInfo, error) {
 	if err != nil {
 		return nil, err
 	}
+	if err := l.CheckOwner(oldData.UserId); err != nil {
+		return nil, err
+	}
 
 	// 设置数据
 	if concreteEntity, ok := entity.(*topic.TopicEntity); ok {
@@ -130,6 +133,9 @@ func (l *TopicLogic) Get(id uint64) (*topic.TopicDetail, error) {
 	if err != nil {
 		return nil, err
 	}
+	if err := l.CheckOwner(topicData.UserId); err != nil {
+		return nil, err
+	}
 
 	// 获取课题的分析记录列表
 	analysisLogic := NewAnalysisLogic(l.Ctx)
@@ -166,11 +172,20 @@ func (l *TopicLogic) GetSimple(id uint64) (*topic.TopicInfo, error) {
 	if err != nil {
 		return nil, err
 	}
+	if err := l.CheckOwner(topicData.UserId); err != nil {
+		return nil, err
+	}
 	return convertToTopicInfo(topicData), nil
 }
 
-// List 查询课题列表
+// List 查询课题列表（仅当前用户的课题）
 func (l *TopicLogic) List(req *topic.SearchTopic) (*topic.ListTopicResponse, error) {
+	userId, err := l.MustCurrUserId()
+	if err != nil {
+		return nil, err
+	}
+	req.UserId = userId
+
 	entity := topic.NewTopicEntity(l.Ctx)
 
 	cond := entity.MakeConditon(*req)
@@ -200,6 +215,11 @@ func (l *TopicLogic) List(req *topic.SearchTopic) (*topic.ListTopicResponse, err
 
 // Del 删除课题
 func (l *TopicLogic) Del(ids []uint64) (any, error) {
+	for _, id := range ids {
+		if err := checkTopicOwner(&l.BaseLogic, id); err != nil {
+			return nil, err
+		}
+	}
 	entity := topic.NewTopicEntity(l.Ctx)
 	err := entity.Del(ids...)
 	return nil, err
@@ -209,10 +229,13 @@ func (l *TopicLogic) Del(ids []uint64) (any, error) {
 func (l *TopicLogic) UpdateStatus(req *topic.UpdateTopicStatus) (*topic.TopicInfo, error) {
 	entity := topic.NewTopicEntity(l.Ctx)
 
-	_, err := entity.LoadById(req.Id)
+	old, err := entity.LoadById(req.Id)
 	if err != nil {
 		return nil, err
 	}
+	if err := l.CheckOwner(old.UserId); err != nil {
+		return nil, err
+	}
 
 	if concreteEntity, ok := entity.(*topic.TopicEntity); ok {
 		concreteEntity.Status = req.Status
@@ -238,10 +261,13 @@ func (l *TopicLogic) UpdateStatus(req *topic.UpdateTopicStatus) (*topic.TopicInf
 func (l *TopicLogic) SelectModel(req *topic.UpdateTopicModel) (*topic.TopicInfo, error) {
 	// 更新课题的模型
 	entity := topic.NewTopicEntity(l.Ctx)
-	_, err := entity.LoadById(req.Id)
+	old, err := entity.LoadById(req.Id)
 	if err != nil {
 		return nil, err
 	}
+	if err := l.CheckOwner(old.UserId); err != nil {
+		return nil, err
+	}
 
 	if concreteEntity, ok := entity.(*topic.TopicEntity); ok {
 		concreteEntity.ModelId = req.ModelId
@@ -258,10 +284,13 @@ func (l *TopicLogic) SelectModel(req *topic.UpdateTopicModel) (*topic.TopicInfo,
 // RemoveModel 移除课题的思维模型
 func (l *TopicLogic) RemoveModel(id uint64) (*topic.TopicInfo, error) {
 	entity := topic.NewTopicEntity(l.Ctx)
-	_, err := entity.LoadById(id)
+	old, err := entity.LoadById(id)
 	if err != nil {
 		return nil, err
 	}
+	if err := l.CheckOwner(old.UserId); err != nil {
+		return nil, err
+	}
 
 	if concreteEntity, ok := entity.(*topic.TopicEntity); ok {
 		concreteEntity.ModelId = 0
@@ -278,10 +307,13 @@ func (l *TopicLogic) RemoveModel(id uint64) (*topic.TopicInfo, error) {
 // Complete 完成课题
 func (l *TopicLogic) Complete(req *topic.CompleteTopic) (*topic.TopicInfo, error) {
 	entity := topic.NewTopicEntity(l.Ctx)
-	_, err := entity.LoadById(req.Id)
+	old, err := entity.LoadById(req.Id)
 	if err != nil {
 		return nil, err
 	}
+	if err := l.CheckOwner(old.UserId); err != nil {
+		return nil, err
+	}
 
 	if concreteEntity, ok := entity.(*topic.TopicEntity); ok {
 		concreteEntity.Status = 1 // 已完成
@@ -299,10 +331,13 @@ func (l *TopicLogic) Complete(req *topic.CompleteTopic) (*topic.TopicInfo, error
 // Archive 归档课题
 func (l *TopicLogic) Archive(req *topic.ArchiveTopic) (*topic.TopicInfo, error) {
 	entity := topic.NewTopicEntity(l.Ctx)
-	_, err := entity.LoadById(req.Id)
+	old, err := entity.LoadById(req.Id)
 	if err != nil {
 		return nil, err
 	}
+	if err := l.CheckOwner(old.UserId); err != nil {
+		return nil, err
+	}
 
 	if concreteEntity, ok := entity.(*topic.TopicEntity); ok {
 		concreteEntity.Status = 2 // 已归档
@@ -319,10 +354,13 @@ func (l *TopicLogic) Archive(req *topic.ArchiveTopic) (*topic.TopicInfo, error)
 // Reopen 重新打开课题
 func (l *TopicLogic) Reopen(id uint64) (*topic.TopicInfo, error) {
 	entity := topic.NewTopicEntity(l.Ctx)
-	_, err := entity.LoadById(id)
+	old, err := entity.LoadById(id)
 	if err != nil {
 		return nil, err
 	}
+	if err := l.CheckOwner(old.UserId); err != nil {
+		return nil, err
+	}
 
 	if concreteEntity, ok := entity.(*topic.TopicEntity); ok {
 		concreteEntity.Status = 0                    // 进行中
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"os"
+
 	_ "thinkingModels/docs"
 	"thinkingModels/engine"
 )
@@ -27,6 +29,11 @@ import (
 // apifox token = afxp_731980BYiStMxas7r5wJnWXl5thuEaPchjfg
 // 项目ID = 7838366
 func main() {
+	// 数据库迁移：thinkingModels migrate up|down|status|create|force
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		os.Exit(engine.Migrate(os.Args[2:]))
+	}
+
 	// 启动服务
 	engine.Run()
 }
@@ -0,0 +1,129 @@
+package middleware
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"thinkingModels/api"
+	"thinkingModels/component/errs"
+	"thinkingModels/component/logger"
+	"thinkingModels/domain/iam/accessToken"
+	"thinkingModels/domain/iam/user"
+	"thinkingModels/domain/iam/userSession"
+)
+
+// Auth JWT鉴权中间件
+// 解析 Authorization: Bearer <token>，校验通过后将用户信息预埋到上下文
+// 同时接受个人访问令牌（tmpat_ 前缀），并按令牌权限范围限制可访问的接口
+func Auth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tokenString := BearerToken(c)
+		if tokenString == "" {
+			unauthorized(c, "未登录或token无效")
+			return
+		}
+
+		if accessToken.IsPersonalToken(tokenString) {
+			personalTokenAuth(c, tokenString)
+			return
+		}
+
+		claims, err := user.ParseToken(tokenString)
+		if err != nil {
+			unauthorized(c, "登录已过期或token无效")
+			return
+		}
+
+		// 已登出或已被强制下线；Redis 异常时无法确认吊销状态，拒绝请求
+		// 返回 500 而非 401，避免客户端误判为登录失效而触发刷新或登出
+		revoked, err := user.IsTokenRevoked(claims)
+		if err != nil {
+			logger.Ctx(c).Error("校验Token吊销状态失败", "userId", claims.UserID, "error", err)
+			abort(c, errs.New(errs.CodeInternal, "登录状态校验失败，请稍后重试").Wrap(err))
+			return
+		}
+		if revoked {
+			unauthorized(c, "登录已失效，请重新登录")
+			return
+		}
+
+		// 预埋到上下文
+		c.Set("currUserId", strconv.FormatUint(claims.UserID, 10))
+		c.Set("currUserName", claims.Username)
+		c.Set("currRoleIds", claims.RoleIds)
+		c.Set("currEnterpriseId", strconv.FormatUint(claims.EnterpriseID, 10))
+		c.Set("currClaims", claims)
+		c.Set("currFamilyId", claims.FamilyID)
+
+		// 更新会话最后活跃时间（每个会话每分钟最多写一次）
+		if userSession.ShouldTouch(claims.FamilyID) {
+			_ = userSession.NewUserSessionEntity(c).Touch(claims.FamilyID, c.ClientIP())
+		}
+
+		// 继续执行后续的中间件或者处理器函数
+		c.Next()
+	}
+}
+
+// personalTokenAuth 个人访问令牌鉴权
+// 令牌须未吊销、未过期且拥有当前接口所需的权限范围，用户信息按令牌所属用户实时加载
+func personalTokenAuth(c *gin.Context, tokenString string) {
+	token, err := loadAccessToken(c, tokenString)
+	if err != nil || !token.IsActive() {
+		unauthorized(c, "访问令牌无效或已过期")
+		return
+	}
+
+	scope, ok := accessToken.RequiredScope(c.Request.Method, c.FullPath())
+	if !ok {
+		forbidden(c, "个人访问令牌不能访问该接口")
+		return
+	}
+	if !token.HasScope(scope) {
+		forbidden(c, "访问令牌缺少权限范围: "+scope)
+		return
+	}
+
+	dbUser, err := user.NewUserEntity(c).LoadById(token.UserId)
+	if err != nil || dbUser.Id == 0 || dbUser.Status != user.StatusActive {
+		unauthorized(c, "访问令牌所属账号不可用")
+		return
+	}
+	_ = token.Touch(c.ClientIP())
+
+	// 预埋到上下文
+	c.Set("currUserId", strconv.FormatUint(dbUser.Id, 10))
+	c.Set("currUserName", dbUser.Username)
+	c.Set("currRoleIds", dbUser.RoleIds)
+	c.Set("currEnterpriseId", strconv.FormatUint(dbUser.EnterpriseID, 10))
+	c.Set("currAccessTokenId", token.Id)
+
+	c.Next()
+}
+
+// loadAccessToken 按令牌原文加载个人访问令牌，测试中可替换以脱离数据库
+var loadAccessToken = func(c *gin.Context, tokenString string) (*accessToken.AccessTokenEntity, error) {
+	return accessToken.NewAccessTokenEntity(c).LoadByToken(tokenString)
+}
+
+// BearerToken 从请求头提取Token
+// 格式: Bearer <token>
+func BearerToken(c *gin.Context) string {
+	authHeader := strings.TrimSpace(c.GetHeader("Authorization"))
+	if len(authHeader) > 7 && strings.EqualFold(authHeader[:7], "Bearer ") {
+		return strings.TrimSpace(authHeader[7:])
+	}
+	return ""
+}
+
+// unauthorized 终止请求并返回401
+func unauthorized(c *gin.Context, msg string) {
+	abort(c, errs.Unauthorized(msg))
+}
+
+// abort 终止请求，按错误码返回状态码与提示
+func abort(c *gin.Context, err error) {
+	c.Abort()
+	(&api.Base{Ctx: c}).Error(err)
+}
@@ -0,0 +1,143 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"thinkingModels/component/jwtkey"
+	"thinkingModels/component/redis"
+	"thinkingModels/domain/iam/accessToken"
+	"thinkingModels/domain/iam/user"
+)
+
+// serveAuth 经 Auth 中间件请求路由，返回状态码
+func serveAuth(method, path, token string) int {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Handle(method, path, Auth(), func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	req := httptest.NewRequest(method, path, nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w.Code
+}
+
+// stubAccessToken 替换个人访问令牌的加载，测试结束后恢复
+func stubAccessToken(t *testing.T, token *accessToken.AccessTokenEntity) {
+	origin := loadAccessToken
+	loadAccessToken = func(*gin.Context, string) (*accessToken.AccessTokenEntity, error) {
+		return token, nil
+	}
+	t.Cleanup(func() { loadAccessToken = origin })
+}
+
+// TestBearerToken 解析 Authorization 请求头
+func TestBearerToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cases := map[string]string{
+		"Bearer abc":     "abc",
+		"bearer  abc ":   "abc",
+		"Basic abc":      "",
+		"Bearer ":        "",
+		"abc":            "",
+		"Bearer tmpat_x": "tmpat_x",
+	}
+	for header, want := range cases {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+		c.Request.Header.Set("Authorization", header)
+		assert.Equal(t, want, BearerToken(c), header)
+	}
+}
+
+// TestAuth_MissingToken 未携带Token返回401
+func TestAuth_MissingToken(t *testing.T) {
+	assert.Equal(t, http.StatusUnauthorized, serveAuth(http.MethodGet, "/thinking/model/list", ""))
+}
+
+// TestAuth_WrongIssuer 签发者不匹配的Token返回401
+func TestAuth_WrongIssuer(t *testing.T) {
+	keys, err := jwtkey.Default()
+	if err != nil {
+		t.Fatalf("jwtkey.Default failed: %v", err)
+	}
+	now := time.Now()
+	token, err := keys.Sign(user.UserClaims{
+		UserID:    1,
+		Username:  "test_issuer",
+		TokenType: "access",
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			Issuer:    "other",
+			ID:        "test_issuer",
+		},
+	})
+	if err != nil {
+		t.Fatalf("Sign failed: %v", err)
+	}
+	assert.Equal(t, http.StatusUnauthorized, serveAuth(http.MethodGet, "/thinking/model/list", token))
+}
+
+// TestAuth_RevokedToken 已登出的Token返回401（需要 redis）
+func TestAuth_RevokedToken(t *testing.T) {
+	if err := redis.Ping(context.Background()); err != nil {
+		t.Skipf("redis unavailable: %v", err)
+	}
+	userEntity := &user.UserEntity{Username: "test_auth_revoked"}
+	userEntity.Id = uint64(time.Now().UnixNano())
+	pair, err := userEntity.GenerateToken()
+	if err != nil {
+		t.Fatalf("GenerateToken failed: %v", err)
+	}
+	claims, err := user.ParseToken(pair.AccessToken)
+	if err != nil {
+		t.Fatalf("ParseToken failed: %v", err)
+	}
+	if err := user.RevokeAccessToken(claims); err != nil {
+		t.Fatalf("RevokeAccessToken failed: %v", err)
+	}
+	assert.Equal(t, http.StatusUnauthorized, serveAuth(http.MethodGet, "/thinking/model/list", pair.AccessToken))
+}
+
+// TestAuth_RevocationUnknown 无法确认吊销状态时拒绝请求（redis 不可用时执行）
+func TestAuth_RevocationUnknown(t *testing.T) {
+	if err := redis.Ping(context.Background()); err == nil {
+		t.Skip("redis available")
+	}
+	userEntity := &user.UserEntity{Username: "test_auth_unknown"}
+	userEntity.Id = uint64(time.Now().UnixNano())
+	pair, err := userEntity.GenerateToken()
+	if err != nil {
+		t.Fatalf("GenerateToken failed: %v", err)
+	}
+	assert.Equal(t, http.StatusInternalServerError, serveAuth(http.MethodGet, "/thinking/model/list", pair.AccessToken))
+}
+
+// TestAuth_PersonalTokenScope 个人访问令牌缺少接口所需权限范围时返回403
+func TestAuth_PersonalTokenScope(t *testing.T) {
+	stubAccessToken(t, &accessToken.AccessTokenEntity{UserId: 1, Scopes: "model:read", Status: 1})
+
+	// 只读范围不能调用写接口
+	assert.Equal(t, http.StatusForbidden, serveAuth(http.MethodPost, "/thinking/model", "tmpat_test"))
+	// 未授予的资源
+	assert.Equal(t, http.StatusForbidden, serveAuth(http.MethodGet, "/thinking/action/list", "tmpat_test"))
+	// 不允许个人访问令牌访问的接口
+	assert.Equal(t, http.StatusForbidden, serveAuth(http.MethodGet, "/user/session/list", "tmpat_test"))
+}
+
+// TestAuth_PersonalTokenInactive 已吊销的个人访问令牌返回401
+func TestAuth_PersonalTokenInactive(t *testing.T) {
+	stubAccessToken(t, &accessToken.AccessTokenEntity{UserId: 1, Scopes: "model:read", Status: 0})
+	assert.Equal(t, http.StatusUnauthorized, serveAuth(http.MethodGet, "/thinking/model/list", "tmpat_test"))
+}
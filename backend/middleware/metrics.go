@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"thinkingModels/component/errs"
+	"thinkingModels/component/metrics"
+	"thinkingModels/config"
+)
+
+// unmatchedRoute 未匹配到路由的请求（404）统一记为一个路由，避免任意路径产生新的序列
+const unmatchedRoute = "unmatched"
+
+// Metrics 按路由模板统计请求数与耗时
+func Metrics() gin.HandlerFunc {
+	if !config.Config.Metrics.Enabled {
+		return passThrough
+	}
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+		method := c.Request.Method
+		metrics.HttpRequests.WithLabelValues(method, route, strconv.Itoa(c.Writer.Status())).Inc()
+		metrics.HttpDuration.WithLabelValues(method, route).Observe(time.Since(start).Seconds())
+	}
+}
+
+// MetricsAuth 指标接口访问控制：携带正确的 Bearer Token，或来源IP在 allowCidrs 内
+func MetricsAuth() gin.HandlerFunc {
+	conf := config.Config.Metrics
+	token := []byte(conf.Token)
+	nets := make([]*net.IPNet, 0, len(conf.AllowCidrs))
+	for _, cidr := range conf.AllowCidrs {
+		if _, ipNet, err := net.ParseCIDR(cidr); err == nil {
+			nets = append(nets, ipNet)
+		}
+	}
+
+	return func(c *gin.Context) {
+		if len(token) > 0 {
+			given, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+			if ok && subtle.ConstantTimeCompare([]byte(given), token) == 1 {
+				c.Next()
+				return
+			}
+		}
+		if ip := net.ParseIP(c.ClientIP()); ip != nil {
+			for _, ipNet := range nets {
+				if ipNet.Contains(ip) {
+					c.Next()
+					return
+				}
+			}
+		}
+		abort(c, errs.Forbidden("无权访问监控指标"))
+	}
+}
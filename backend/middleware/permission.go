@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"thinkingModels/component/errs"
+	"thinkingModels/domain/iam/permission"
+)
+
+// RequirePermission 权限校验中间件，需挂载在 Auth 之后
+// 当前用户需拥有全部指定权限码，超级管理员角色直接通过
+func RequirePermission(codes ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		granted, isSuper, err := permission.CurrPermissions(c)
+		if err != nil {
+			forbidden(c, "权限校验失败")
+			return
+		}
+
+		if !isSuper {
+			for _, code := range codes {
+				if !granted[code] {
+					forbidden(c, "无权限访问: "+code)
+					return
+				}
+			}
+		}
+
+		c.Next()
+	}
+}
+
+// forbidden 终止请求并返回403
+func forbidden(c *gin.Context, msg string) {
+	abort(c, errs.Forbidden(msg))
+}
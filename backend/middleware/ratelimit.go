@@ -0,0 +1,96 @@
+package middleware
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"thinkingModels/component/errs"
+	"thinkingModels/component/ratelimit"
+	"thinkingModels/config"
+)
+
+// rateRemainingKey 本次请求已返回的最小剩余次数，多个限流同时生效时响应头取最严格的一个
+const rateRemainingKey = "rateLimitRemaining"
+
+var ErrTooManyRequests = errs.RateLimited("请求过于频繁，请稍后再试")
+
+// rateRule 一条限流规则
+type rateRule struct {
+	key    string
+	limit  int
+	window time.Duration
+}
+
+// RateLimit 全局限流：全站与单个IP，挂载在全部业务路由之前（探针不受限）
+func RateLimit() gin.HandlerFunc {
+	conf := config.Config.RateLimit
+	window := time.Duration(conf.Window) * time.Second
+	if !conf.Enabled || (conf.Global <= 0 && conf.PerIp <= 0) {
+		return passThrough
+	}
+	return func(c *gin.Context) {
+		rateLimit(c,
+			rateRule{"ratelimit:global", conf.Global, window},
+			rateRule{"ratelimit:ip:" + c.ClientIP(), conf.PerIp, window},
+		)
+	}
+}
+
+// UserRateLimit 单个用户限流，需挂载在 Auth 之后
+func UserRateLimit() gin.HandlerFunc {
+	conf := config.Config.RateLimit
+	window := time.Duration(conf.Window) * time.Second
+	if !conf.Enabled || conf.PerUser <= 0 {
+		return passThrough
+	}
+	return func(c *gin.Context) {
+		if userId := c.GetString("currUserId"); userId != "" {
+			rateLimit(c, rateRule{"ratelimit:user:" + userId, conf.PerUser, window})
+		}
+	}
+}
+
+// RouteRateLimit 单独的路由策略（登录、注册、AI调用等），在全局限流之外额外生效
+// 不受全局限流开关影响，仅在策略上限为0时关闭；已登录按用户计数，未登录按IP计数；同名策略的路由共用计数
+func RouteRateLimit(name string, policy config.RatePolicy) gin.HandlerFunc {
+	if policy.Limit <= 0 {
+		return passThrough
+	}
+	window := time.Duration(policy.Window) * time.Second
+	return func(c *gin.Context) {
+		key := "ratelimit:" + name + ":ip:" + c.ClientIP()
+		if userId := c.GetString("currUserId"); userId != "" {
+			key = "ratelimit:" + name + ":user:" + userId
+		}
+		rateLimit(c, rateRule{key, policy.Limit, window})
+	}
+}
+
+func passThrough(c *gin.Context) {
+	c.Next()
+}
+
+// rateLimit 依次检查规则，任一超限即返回429
+// 响应头 RateLimit-Limit / RateLimit-Remaining / RateLimit-Reset / RateLimit-Policy 取剩余次数最少的规则，超限时另加 Retry-After
+func rateLimit(c *gin.Context, rules ...rateRule) {
+	for _, rule := range rules {
+		if rule.limit <= 0 {
+			continue
+		}
+		res := ratelimit.Allow(c.Request.Context(), rule.key, rule.limit, rule.window)
+		if remaining, ok := c.Get(rateRemainingKey); !ok || res.Remaining < remaining.(int) || !res.Allowed {
+			c.Set(rateRemainingKey, res.Remaining)
+			c.Header("RateLimit-Limit", strconv.Itoa(res.Limit))
+			c.Header("RateLimit-Remaining", strconv.Itoa(res.Remaining))
+			c.Header("RateLimit-Reset", strconv.Itoa(res.Reset))
+			c.Header("RateLimit-Policy", strconv.Itoa(res.Limit)+";w="+strconv.Itoa(int(rule.window.Seconds())))
+		}
+		if !res.Allowed {
+			c.Header("Retry-After", strconv.Itoa(res.RetryAfter))
+			abort(c, ErrTooManyRequests)
+			return
+		}
+	}
+	c.Next()
+}
@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"thinkingModels/config"
+)
+
+// TestRouteRateLimit_IgnoresGlobalSwitch 登录、注册等单独策略在全局限流关闭时仍然生效
+func TestRouteRateLimit_IgnoresGlobalSwitch(t *testing.T) {
+	origin := config.Config.RateLimit.Enabled
+	config.Config.RateLimit.Enabled = false
+	t.Cleanup(func() { config.Config.RateLimit.Enabled = origin })
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	name := fmt.Sprintf("test_route_%d", time.Now().UnixNano())
+	r.POST("/login", RouteRateLimit(name, config.RatePolicy{Window: 60, Limit: 2}), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	codes := make([]int, 0, 3)
+	for range 3 {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/login", nil))
+		codes = append(codes, w.Code)
+	}
+	assert.Equal(t, []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests}, codes)
+}
+
+// TestRouteRateLimit_Disabled 策略上限为0时不限流
+func TestRouteRateLimit_Disabled(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.POST("/login", RouteRateLimit("test_disabled", config.RatePolicy{Window: 60}), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	for range 3 {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/login", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+	}
+}
@@ -0,0 +1,88 @@
+package middleware
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"runtime/debug"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"thinkingModels/component/errs"
+	"thinkingModels/component/logger"
+)
+
+// validTraceId 客户端传入的 trace id 只接受常见字符，避免日志注入
+var validTraceId = regexp.MustCompile(`^[A-Za-z0-9._-]{8,64}$`)
+
+// quietPaths 探针请求频繁，只在 debug 级别记录
+var quietPaths = map[string]bool{"/livez": true, "/readyz": true, "/health": true}
+
+// Trace 生成或沿用请求的 trace id：写入请求上下文与响应头 X-Trace-Id
+// 客户端（或网关）携带 X-Trace-Id / X-Request-Id 时沿用，否则生成新的
+func Trace() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		traceId := c.GetHeader(logger.TraceHeader)
+		if traceId == "" {
+			traceId = c.GetHeader("X-Request-Id")
+		}
+		if !validTraceId.MatchString(traceId) {
+			traceId = logger.NewTraceId()
+		}
+
+		c.Request = c.Request.WithContext(logger.WithTraceId(c.Request.Context(), traceId))
+		c.Header(logger.TraceHeader, traceId)
+		c.Next()
+	}
+}
+
+// AccessLog 访问日志：路由模板、状态码、耗时、客户端IP、当前用户
+// 5xx 记为 error，4xx 记为 warn
+func AccessLog() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		status := c.Writer.Status()
+		level := slog.LevelInfo
+		switch {
+		case status >= http.StatusInternalServerError:
+			level = slog.LevelError
+		case status >= http.StatusBadRequest:
+			level = slog.LevelWarn
+		case quietPaths[c.Request.URL.Path]:
+			level = slog.LevelDebug
+		}
+
+		attrs := []slog.Attr{
+			slog.String("method", c.Request.Method),
+			slog.String("path", c.Request.URL.Path),
+			slog.String("route", c.FullPath()),
+			slog.Int("status", status),
+			slog.Duration("latency", time.Since(start)),
+			slog.String("ip", c.ClientIP()),
+			slog.Int("size", c.Writer.Size()),
+		}
+		if userId := c.GetString("currUserId"); userId != "" {
+			attrs = append(attrs, slog.String("user_id", userId))
+		}
+		if len(c.Errors) > 0 {
+			attrs = append(attrs, slog.String("errors", c.Errors.String()))
+		}
+		logger.Ctx(c).LogAttrs(c, level, "http request", attrs...)
+	}
+}
+
+// Recovery 捕获 panic：记录堆栈（带 trace id）并返回 500
+func Recovery() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			if err := recover(); err != nil {
+				logger.Ctx(c).Error("panic recovered", "error", err, "path", c.Request.URL.Path, "stack", string(debug.Stack()))
+				abort(c, errs.Internal(fmt.Errorf("panic: %v", err)))
+			}
+		}()
+		c.Next()
+	}
+}
@@ -0,0 +1,20 @@
+DROP TABLE IF EXISTS `action_followups`;
+DROP TABLE IF EXISTS `actions`;
+DROP TABLE IF EXISTS `topic_analyses`;
+DROP TABLE IF EXISTS `topics`;
+DROP TABLE IF EXISTS `model_tags`;
+DROP TABLE IF EXISTS `model_categories`;
+DROP TABLE IF EXISTS `thinking_models`;
+DROP TABLE IF EXISTS `category`;
+DROP TABLE IF EXISTS `super_dictionary`;
+DROP TABLE IF EXISTS `user_follows`;
+DROP TABLE IF EXISTS `invite_codes`;
+DROP TABLE IF EXISTS `account_deletions`;
+DROP TABLE IF EXISTS `audit_logs`;
+DROP TABLE IF EXISTS `user_sessions`;
+DROP TABLE IF EXISTS `personal_access_tokens`;
+DROP TABLE IF EXISTS `user_identities`;
+DROP TABLE IF EXISTS `role_permissions`;
+DROP TABLE IF EXISTS `permissions`;
+DROP TABLE IF EXISTS `roles`;
+DROP TABLE IF EXISTS `users`;
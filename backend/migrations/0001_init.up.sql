@@ -0,0 +1,572 @@
+-- 初始表结构：doc/技术方案.md 第四章中后端已使用的表（4.1 ~ 4.3）、IAM 初始数据与 4.7 多租户字段
+-- 已按文档手工建表的数据库无需执行本迁移，核对表结构一致后执行 `migrate force 1` 标记为已迁移
+
+-- ========================================
+-- IAM 领域
+-- ========================================
+CREATE TABLE `users` (
+    `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+    -- ==================== 业务字段 ====================
+    `username` VARCHAR(50) NOT NULL COMMENT '登录用户名',
+    `password` VARCHAR(255) NOT NULL COMMENT '加密密码',
+    `nickname` VARCHAR(100) DEFAULT '' COMMENT '用户昵称',
+    `email` VARCHAR(100) DEFAULT '' COMMENT '邮箱',
+    `phone` VARCHAR(20) DEFAULT '' COMMENT '手机号',
+    `avatar` VARCHAR(500) DEFAULT '' COMMENT '头像URL',
+    `bio` VARCHAR(500) DEFAULT '' COMMENT '个人简介',
+    `status` TINYINT NOT NULL DEFAULT 1 COMMENT '状态:0=禁用,1=正常,2=待验证邮箱',
+    `email_verified` TINYINT NOT NULL DEFAULT 0 COMMENT '邮箱是否已验证:0=否,1=是',
+    `last_login_time` DATETIME DEFAULT NULL COMMENT '最后登录时间',
+    `last_login_ip` VARCHAR(50) DEFAULT '' COMMENT '最后登录IP',
+    `enterprise_id` BIGINT UNSIGNED DEFAULT 0 COMMENT '企业ID',
+    `role_ids` VARCHAR(200) DEFAULT '' COMMENT '角色ID列表',
+    `is_expert` TINYINT DEFAULT 0 COMMENT '是否专家:0=否,1=是',
+    `expert_title` VARCHAR(100) DEFAULT '' COMMENT '专家头衔',
+    `expert_company` VARCHAR(100) DEFAULT '' COMMENT '所属公司',
+    `expert_domains` VARCHAR(500) DEFAULT '' COMMENT '专业领域',
+    `consult_price` DECIMAL(10,2) DEFAULT 0 COMMENT '咨询价格',
+    `totp_secret` VARCHAR(64) DEFAULT '' COMMENT '两步验证密钥(Base32)',
+    `totp_enabled` TINYINT NOT NULL DEFAULT 0 COMMENT '是否启用两步验证:0=否,1=是',
+    `recovery_codes` VARCHAR(1000) DEFAULT '' COMMENT '恢复码摘要(逗号分隔,一次性)',
+    `invited_by` BIGINT UNSIGNED DEFAULT 0 COMMENT '邀请人用户ID',
+    `invite_code_id` BIGINT UNSIGNED DEFAULT 0 COMMENT '注册使用的邀请码ID',
+    -- ==================== 标准审计字段 ====================
+    `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP NOT NULL COMMENT '创建时间',
+    `updated_at` DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP NULL COMMENT '修改时间',
+    `deleted_at` DATETIME NULL COMMENT '软删除时间',
+    `create_by` BIGINT UNSIGNED DEFAULT 0 NOT NULL COMMENT '创建人ID',
+    `create_by_name` VARCHAR(20) DEFAULT '系统' NOT NULL COMMENT '创建人姓名',
+    `update_by` BIGINT UNSIGNED DEFAULT 0 NOT NULL COMMENT '修改人ID',
+    `update_by_name` VARCHAR(20) DEFAULT '系统' NOT NULL COMMENT '修改人姓名',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_username` (`username`),
+    KEY `idx_phone` (`phone`),
+    KEY `idx_email` (`email`),
+    KEY `idx_status` (`status`),
+    KEY `idx_invited_by` (`invited_by`),
+    KEY `idx_created_at` (`created_at`),
+    KEY `idx_deleted_at` (`deleted_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='用户表';
+
+CREATE TABLE `roles` (
+    `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+    -- ==================== 业务字段 ====================
+    `code` VARCHAR(50) NOT NULL COMMENT '角色编码',
+    `name` VARCHAR(50) NOT NULL COMMENT '角色名称',
+    `description` VARCHAR(255) DEFAULT '' COMMENT '角色描述',
+    `is_super` TINYINT NOT NULL DEFAULT 0 COMMENT '是否超级管理员:0=否,1=是(拥有全部权限)',
+    `status` TINYINT NOT NULL DEFAULT 1 COMMENT '状态:0=禁用,1=正常',
+    `sort` INT DEFAULT 0 COMMENT '排序',
+    -- ==================== 标准审计字段 ====================
+    `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP NOT NULL COMMENT '创建时间',
+    `updated_at` DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP NULL COMMENT '修改时间',
+    `deleted_at` DATETIME NULL COMMENT '软删除时间',
+    `create_by` BIGINT UNSIGNED DEFAULT 0 NOT NULL COMMENT '创建人ID',
+    `create_by_name` VARCHAR(20) DEFAULT '系统' NOT NULL COMMENT '创建人姓名',
+    `update_by` BIGINT UNSIGNED DEFAULT 0 NOT NULL COMMENT '修改人ID',
+    `update_by_name` VARCHAR(20) DEFAULT '系统' NOT NULL COMMENT '修改人姓名',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_code` (`code`),
+    KEY `idx_deleted_at` (`deleted_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='角色表';
+
+CREATE TABLE `permissions` (
+    `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+    -- ==================== 业务字段 ====================
+    `code` VARCHAR(100) NOT NULL COMMENT '权限码,如MODEL_PUBLISH',
+    `name` VARCHAR(50) NOT NULL COMMENT '权限名称',
+    `module` VARCHAR(50) DEFAULT '' COMMENT '所属模块',
+    `description` VARCHAR(255) DEFAULT '' COMMENT '权限描述',
+    `sort` INT DEFAULT 0 COMMENT '排序',
+    -- ==================== 标准审计字段 ====================
+    `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP NOT NULL COMMENT '创建时间',
+    `updated_at` DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP NULL COMMENT '修改时间',
+    `deleted_at` DATETIME NULL COMMENT '软删除时间',
+    `create_by` BIGINT UNSIGNED DEFAULT 0 NOT NULL COMMENT '创建人ID',
+    `create_by_name` VARCHAR(20) DEFAULT '系统' NOT NULL COMMENT '创建人姓名',
+    `update_by` BIGINT UNSIGNED DEFAULT 0 NOT NULL COMMENT '修改人ID',
+    `update_by_name` VARCHAR(20) DEFAULT '系统' NOT NULL COMMENT '修改人姓名',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_code` (`code`),
+    KEY `idx_module` (`module`),
+    KEY `idx_deleted_at` (`deleted_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='权限表';
+
+CREATE TABLE `role_permissions` (
+    `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+    -- ==================== 业务字段 ====================
+    `role_id` BIGINT UNSIGNED NOT NULL COMMENT '角色ID',
+    `permission_id` BIGINT UNSIGNED NOT NULL COMMENT '权限ID',
+    -- ==================== 标准审计字段 ====================
+    `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP NOT NULL COMMENT '创建时间',
+    `updated_at` DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP NULL COMMENT '修改时间',
+    `deleted_at` DATETIME NULL COMMENT '软删除时间',
+    `create_by` BIGINT UNSIGNED DEFAULT 0 NOT NULL COMMENT '创建人ID',
+    `create_by_name` VARCHAR(20) DEFAULT '系统' NOT NULL COMMENT '创建人姓名',
+    `update_by` BIGINT UNSIGNED DEFAULT 0 NOT NULL COMMENT '修改人ID',
+    `update_by_name` VARCHAR(20) DEFAULT '系统' NOT NULL COMMENT '修改人姓名',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_role_permission` (`role_id`, `permission_id`),
+    KEY `idx_permission_id` (`permission_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='角色权限关联表';
+
+CREATE TABLE `user_identities` (
+    `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+    -- ==================== 业务字段 ====================
+    `user_id` BIGINT UNSIGNED NOT NULL COMMENT '本地用户ID',
+    `provider` VARCHAR(50) NOT NULL COMMENT '身份提供方',
+    `subject` VARCHAR(255) NOT NULL COMMENT '提供方用户标识(sub)',
+    `email` VARCHAR(100) DEFAULT '' COMMENT '绑定时提供方返回的邮箱',
+    -- ==================== 标准审计字段 ====================
+    `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP NOT NULL COMMENT '创建时间',
+    `updated_at` DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP NULL COMMENT '修改时间',
+    `deleted_at` DATETIME NULL COMMENT '软删除时间',
+    `create_by` BIGINT UNSIGNED DEFAULT 0 NOT NULL COMMENT '创建人ID',
+    `create_by_name` VARCHAR(20) DEFAULT '系统' NOT NULL COMMENT '创建人姓名',
+    `update_by` BIGINT UNSIGNED DEFAULT 0 NOT NULL COMMENT '修改人ID',
+    `update_by_name` VARCHAR(20) DEFAULT '系统' NOT NULL COMMENT '修改人姓名',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_provider_subject` (`provider`, `subject`),
+    KEY `idx_user_id` (`user_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='第三方登录身份表';
+
+CREATE TABLE `personal_access_tokens` (
+    `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+    -- ==================== 业务字段 ====================
+    `user_id` BIGINT UNSIGNED NOT NULL COMMENT '所属用户ID',
+    `name` VARCHAR(50) NOT NULL COMMENT '令牌名称',
+    `token_prefix` VARCHAR(20) NOT NULL COMMENT '令牌前缀，用于识别',
+    `token_hash` CHAR(64) NOT NULL COMMENT '令牌SHA256摘要',
+    `scopes` VARCHAR(500) NOT NULL COMMENT '权限范围，逗号分隔',
+    `expires_at` DATETIME DEFAULT NULL COMMENT '过期时间，为空表示永不过期',
+    `last_used_at` DATETIME DEFAULT NULL COMMENT '最后使用时间',
+    `last_used_ip` VARCHAR(50) DEFAULT '' COMMENT '最后使用IP',
+    `status` TINYINT NOT NULL DEFAULT 1 COMMENT '状态:0=已吊销,1=正常',
+    -- ==================== 标准审计字段 ====================
+    `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP NOT NULL COMMENT '创建时间',
+    `updated_at` DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP NULL COMMENT '修改时间',
+    `deleted_at` DATETIME NULL COMMENT '软删除时间',
+    `create_by` BIGINT UNSIGNED DEFAULT 0 NOT NULL COMMENT '创建人ID',
+    `create_by_name` VARCHAR(20) DEFAULT '系统' NOT NULL COMMENT '创建人姓名',
+    `update_by` BIGINT UNSIGNED DEFAULT 0 NOT NULL COMMENT '修改人ID',
+    `update_by_name` VARCHAR(20) DEFAULT '系统' NOT NULL COMMENT '修改人姓名',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_token_hash` (`token_hash`),
+    KEY `idx_user_id` (`user_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='个人访问令牌表';
+
+CREATE TABLE `user_sessions` (
+    `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+    -- ==================== 业务字段 ====================
+    `user_id` BIGINT UNSIGNED NOT NULL COMMENT '用户ID',
+    `family_id` VARCHAR(64) NOT NULL COMMENT '刷新令牌家族ID',
+    `device` VARCHAR(100) DEFAULT '' COMMENT '设备描述',
+    `user_agent` VARCHAR(500) DEFAULT '' COMMENT 'User-Agent',
+    `ip` VARCHAR(50) DEFAULT '' COMMENT '登录IP',
+    `last_seen_at` DATETIME DEFAULT NULL COMMENT '最后活跃时间',
+    `last_seen_ip` VARCHAR(50) DEFAULT '' COMMENT '最后活跃IP',
+    `expires_at` DATETIME NOT NULL COMMENT '会话过期时间',
+    `status` TINYINT NOT NULL DEFAULT 1 COMMENT '状态:0=已下线,1=在线',
+    -- ==================== 标准审计字段 ====================
+    `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP NOT NULL COMMENT '创建时间',
+    `updated_at` DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP NULL COMMENT '修改时间',
+    `deleted_at` DATETIME NULL COMMENT '软删除时间',
+    `create_by` BIGINT UNSIGNED DEFAULT 0 NOT NULL COMMENT '创建人ID',
+    `create_by_name` VARCHAR(20) DEFAULT '系统' NOT NULL COMMENT '创建人姓名',
+    `update_by` BIGINT UNSIGNED DEFAULT 0 NOT NULL COMMENT '修改人ID',
+    `update_by_name` VARCHAR(20) DEFAULT '系统' NOT NULL COMMENT '修改人姓名',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_family_id` (`family_id`),
+    KEY `idx_user_status` (`user_id`, `status`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='登录会话表';
+
+CREATE TABLE `audit_logs` (
+    `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+    -- ==================== 业务字段 ====================
+    `actor_id` BIGINT UNSIGNED DEFAULT 0 COMMENT '操作人ID，0表示匿名',
+    `actor_name` VARCHAR(50) DEFAULT '' COMMENT '操作人名称',
+    `action` VARCHAR(50) NOT NULL COMMENT '动作，如 auth.login',
+    `target_type` VARCHAR(50) DEFAULT '' COMMENT '目标类型，如 user',
+    `target_id` VARCHAR(64) DEFAULT '' COMMENT '目标ID',
+    `ip` VARCHAR(50) DEFAULT '' COMMENT '客户端IP',
+    `user_agent` VARCHAR(500) DEFAULT '' COMMENT 'User-Agent',
+    `trace_id` VARCHAR(64) DEFAULT '' COMMENT '链路追踪ID',
+    `diff` TEXT COMMENT '变更前后差异(JSON)',
+    `remark` VARCHAR(500) DEFAULT '' COMMENT '备注，如失败原因',
+    -- ==================== 标准审计字段 ====================
+    `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP NOT NULL COMMENT '创建时间',
+    `updated_at` DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP NULL COMMENT '修改时间',
+    `deleted_at` DATETIME NULL COMMENT '软删除时间',
+    `create_by` BIGINT UNSIGNED DEFAULT 0 NOT NULL COMMENT '创建人ID',
+    `create_by_name` VARCHAR(20) DEFAULT '系统' NOT NULL COMMENT '创建人姓名',
+    `update_by` BIGINT UNSIGNED DEFAULT 0 NOT NULL COMMENT '修改人ID',
+    `update_by_name` VARCHAR(20) DEFAULT '系统' NOT NULL COMMENT '修改人姓名',
+    PRIMARY KEY (`id`),
+    KEY `idx_actor` (`actor_id`, `created_at`),
+    KEY `idx_action` (`action`, `created_at`),
+    KEY `idx_target` (`target_type`, `target_id`),
+    KEY `idx_created_at` (`created_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='审计日志表';
+
+CREATE TABLE `account_deletions` (
+    `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+    -- ==================== 业务字段 ====================
+    `job_id` VARCHAR(64) NOT NULL COMMENT '任务ID(随机串，用于匿名查询进度)',
+    `user_id` BIGINT UNSIGNED NOT NULL COMMENT '注销的用户ID',
+    `status` TINYINT NOT NULL DEFAULT 0 COMMENT '状态:0=排队中,1=处理中,2=已完成,3=失败',
+    `attempts` INT NOT NULL DEFAULT 0 COMMENT '已执行次数',
+    `stats` VARCHAR(1000) DEFAULT '' COMMENT '各表处理行数(JSON)',
+    `error` VARCHAR(500) DEFAULT '' COMMENT '最近一次失败原因',
+    `finished_at` DATETIME DEFAULT NULL COMMENT '完成时间',
+    -- ==================== 标准审计字段 ====================
+    `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP NOT NULL COMMENT '创建时间',
+    `updated_at` DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP NULL COMMENT '修改时间',
+    `deleted_at` DATETIME NULL COMMENT '软删除时间',
+    `create_by` BIGINT UNSIGNED DEFAULT 0 NOT NULL COMMENT '创建人ID',
+    `create_by_name` VARCHAR(20) DEFAULT '系统' NOT NULL COMMENT '创建人姓名',
+    `update_by` BIGINT UNSIGNED DEFAULT 0 NOT NULL COMMENT '修改人ID',
+    `update_by_name` VARCHAR(20) DEFAULT '系统' NOT NULL COMMENT '修改人姓名',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_job_id` (`job_id`),
+    KEY `idx_user_id` (`user_id`),
+    KEY `idx_status` (`status`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='注销账号任务表';
+
+CREATE TABLE `invite_codes` (
+    `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+    -- ==================== 业务字段 ====================
+    `code` VARCHAR(32) NOT NULL COMMENT '邀请码(大写)',
+    `owner_id` BIGINT UNSIGNED NOT NULL COMMENT '邀请人用户ID',
+    `max_uses` INT NOT NULL DEFAULT 0 COMMENT '最大使用次数，0表示不限',
+    `used_count` INT NOT NULL DEFAULT 0 COMMENT '已使用次数',
+    `expires_at` DATETIME DEFAULT NULL COMMENT '过期时间，为空表示永不过期',
+    `status` TINYINT NOT NULL DEFAULT 1 COMMENT '状态:0=停用,1=启用',
+    `remark` VARCHAR(255) DEFAULT '' COMMENT '备注',
+    -- ==================== 标准审计字段 ====================
+    `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP NOT NULL COMMENT '创建时间',
+    `updated_at` DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP NULL COMMENT '修改时间',
+    `deleted_at` DATETIME NULL COMMENT '软删除时间',
+    `create_by` BIGINT UNSIGNED DEFAULT 0 NOT NULL COMMENT '创建人ID',
+    `create_by_name` VARCHAR(20) DEFAULT '系统' NOT NULL COMMENT '创建人姓名',
+    `update_by` BIGINT UNSIGNED DEFAULT 0 NOT NULL COMMENT '修改人ID',
+    `update_by_name` VARCHAR(20) DEFAULT '系统' NOT NULL COMMENT '修改人姓名',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_code` (`code`),
+    KEY `idx_owner_id` (`owner_id`),
+    KEY `idx_deleted_at` (`deleted_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='邀请码表';
+
+CREATE TABLE `user_follows` (
+    `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+    -- ==================== 业务字段 ====================
+    `follower_id` BIGINT UNSIGNED NOT NULL COMMENT '关注者用户ID',
+    `followee_id` BIGINT UNSIGNED NOT NULL COMMENT '被关注者用户ID',
+    -- ==================== 标准审计字段 ====================
+    `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP NOT NULL COMMENT '创建时间',
+    `updated_at` DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP NULL COMMENT '修改时间',
+    `deleted_at` DATETIME NULL COMMENT '软删除时间',
+    `create_by` BIGINT UNSIGNED DEFAULT 0 NOT NULL COMMENT '创建人ID',
+    `create_by_name` VARCHAR(20) DEFAULT '系统' NOT NULL COMMENT '创建人姓名',
+    `update_by` BIGINT UNSIGNED DEFAULT 0 NOT NULL COMMENT '修改人ID',
+    `update_by_name` VARCHAR(20) DEFAULT '系统' NOT NULL COMMENT '修改人姓名',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_follower_followee` (`follower_id`, `followee_id`),
+    KEY `idx_followee_id` (`followee_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='用户关注表';
+
+-- 初始化角色（users.role_ids 中的 1 为超级管理员）
+INSERT INTO `roles` (`id`, `code`, `name`, `description`, `is_super`, `sort`) VALUES
+(1, 'admin', '超级管理员', '拥有全部权限', 1, 1),
+(2, 'creator', '创作者', '可发布思维模型', 0, 2),
+(3, 'user', '普通用户', '默认角色', 0, 3);
+
+-- 初始化权限码
+INSERT INTO `permissions` (`code`, `name`, `module`, `sort`) VALUES
+('ACCOUNT', '账号管理', 'iam', 1), ('ACCOUNT_ADD', '新增账号', 'iam', 2), ('ACCOUNT_EDIT', '编辑账号', 'iam', 3),
+('ACCOUNT_DELETE', '删除账号', 'iam', 4), ('ACCOUNT_VIEW', '查看账号', 'iam', 5),
+('ROLE', '角色管理', 'iam', 11), ('ROLE_ADD', '新增角色', 'iam', 12), ('ROLE_EDIT', '编辑角色', 'iam', 13),
+('ROLE_DELETE', '删除角色', 'iam', 14), ('ROLE_VIEW', '查看角色', 'iam', 15),
+('DICT', '字典管理', 'master', 21), ('DICT_ADD', '新增字典', 'master', 22), ('DICT_EDIT', '编辑字典', 'master', 23),
+('DICT_DELETE', '删除字典', 'master', 24), ('DICT_VIEW', '查看字典', 'master', 25),
+('MODEL_PUBLISH', '发布思维模型', 'practice', 31),
+('DATA_ADMIN', '管理他人数据', 'system', 90),
+('MODEL_SHARE', '共享思维模型', 'practice', 32),
+('ADMIN', '管理后台', 'system', 91), ('SYSTEM', '系统管理', 'system', 92), ('SYSTEM_SETTING', '系统设置', 'system', 93),
+('AUDIT_VIEW', '查看审计日志', 'system', 94),
+('INVITE_CODE', '邀请码管理', 'system', 95);
+
+-- 创作者可发布模型
+INSERT INTO `role_permissions` (`role_id`, `permission_id`)
+SELECT 2, `id` FROM `permissions` WHERE `code` = 'MODEL_PUBLISH';
+
+-- ========================================
+-- MASTER 领域
+-- ========================================
+CREATE TABLE `super_dictionary` (
+    `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+    -- ==================== 业务字段 ====================
+    `parent_id` BIGINT DEFAULT 0 COMMENT '父级ID',
+    `dict_value` VARCHAR(100) NOT NULL COMMENT '字典值',
+    `dict_name` VARCHAR(100) NOT NULL COMMENT '字典名称',
+    `level` INT DEFAULT 1 COMMENT '层级',
+    `level_name` VARCHAR(50) DEFAULT '' COMMENT '层级名称',
+    `description` VARCHAR(500) DEFAULT '' COMMENT '字典描述',
+    `eval` VARCHAR(200) DEFAULT '' COMMENT '计算表达式',
+    `ext_schema` TEXT COMMENT '扩展Schema',
+    `ext_json` TEXT COMMENT '扩展JSON',
+    `sort` INT DEFAULT 0 COMMENT '排序',
+    `status` TINYINT DEFAULT 1 COMMENT '状态:0=禁用,1=正常',
+    -- ==================== 标准审计字段 ====================
+    `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP NOT NULL COMMENT '创建时间',
+    `updated_at` DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP NULL COMMENT '修改时间',
+    `deleted_at` DATETIME NULL COMMENT '软删除时间',
+    `create_by` BIGINT UNSIGNED DEFAULT 0 NOT NULL COMMENT '创建人ID',
+    `create_by_name` VARCHAR(20) DEFAULT '系统' NOT NULL COMMENT '创建人姓名',
+    `update_by` BIGINT UNSIGNED DEFAULT 0 NOT NULL COMMENT '修改人ID',
+    `update_by_name` VARCHAR(20) DEFAULT '系统' NOT NULL COMMENT '修改人姓名',
+    PRIMARY KEY (`id`),
+    KEY `idx_parent_id` (`parent_id`),
+    KEY `idx_dict_value` (`dict_value`),
+    KEY `idx_created_at` (`created_at`),
+    KEY `idx_deleted_at` (`deleted_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='超级字典表';
+
+CREATE TABLE `category` (
+    `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+    -- ==================== 业务字段 ====================
+    `name` VARCHAR(50) NOT NULL COMMENT '分类名称',
+    `icon` VARCHAR(500) DEFAULT '' COMMENT '分类图标URL',
+    `description` VARCHAR(500) DEFAULT '' COMMENT '分类描述',
+    `heat` INT DEFAULT 0 COMMENT '热度值，用于排序',
+    -- ==================== 标准审计字段 ====================
+    `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP NOT NULL COMMENT '创建时间',
+    `updated_at` DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP NULL COMMENT '修改时间',
+    `deleted_at` DATETIME NULL COMMENT '软删除时间',
+    `create_by` BIGINT UNSIGNED DEFAULT 0 NOT NULL COMMENT '创建人ID',
+    `create_by_name` VARCHAR(20) DEFAULT '系统' NOT NULL COMMENT '创建人姓名',
+    `update_by` BIGINT UNSIGNED DEFAULT 0 NOT NULL COMMENT '修改人ID',
+    `update_by_name` VARCHAR(20) DEFAULT '系统' NOT NULL COMMENT '修改人姓名',
+    PRIMARY KEY (`id`),
+    KEY `idx_heat` (`heat`),
+    KEY `idx_deleted_at` (`deleted_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='分类表';
+
+-- ========================================
+-- THINKING 领域
+-- ========================================
+CREATE TABLE `thinking_models` (
+    `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+    -- ==================== 业务字段 ====================
+    `name` VARCHAR(100) NOT NULL COMMENT '模型名称',
+    `code` VARCHAR(50) NOT NULL COMMENT '模型编码',
+    `description` VARCHAR(1000) DEFAULT '' COMMENT '模型描述',
+    `cover_image` VARCHAR(500) DEFAULT '' COMMENT '封面图片URL',
+    `icon` VARCHAR(200) DEFAULT '' COMMENT '模型图标',
+    `category_id` BIGINT UNSIGNED NOT NULL COMMENT '所属分类ID',
+    `price` DECIMAL(10,2) DEFAULT 0 COMMENT '价格，0表示免费',
+    `content` LONGTEXT COMMENT '模型内容JSON',
+    `overview` TEXT COMMENT '模型概述',
+    `difficulty` TINYINT DEFAULT 1 COMMENT '难度:1=入门,2=进阶,3=高级',
+    `estimated_time` INT DEFAULT 30 COMMENT '预计完成时间(分钟)',
+    `usage_count` INT DEFAULT 0 COMMENT '使用次数',
+    `adopt_count` INT DEFAULT 0 COMMENT '采纳次数',
+    `like_count` INT DEFAULT 0 COMMENT '点赞次数',
+    `comment_count` INT DEFAULT 0 COMMENT '评论次数',
+    `status` TINYINT DEFAULT 0 COMMENT '状态:0=草稿,1=审核中,2=已发布,3=已下架,4=审核拒绝',
+    `publish_time` DATETIME DEFAULT NULL COMMENT '发布时间',
+    `version` VARCHAR(20) DEFAULT '1.0.0' COMMENT '版本号',
+    `author_id` BIGINT UNSIGNED NOT NULL COMMENT '作者ID',
+    `author_name` VARCHAR(100) DEFAULT '' COMMENT '作者名称',
+    `is_official` TINYINT DEFAULT 0 COMMENT '是否官方:0=否,1=是',
+    `source_model_id` BIGINT UNSIGNED DEFAULT 0 COMMENT '引用源模型ID',
+    -- ==================== 标准审计字段 ====================
+    `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP NOT NULL COMMENT '创建时间',
+    `updated_at` DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP NULL COMMENT '修改时间',
+    `deleted_at` DATETIME NULL COMMENT '软删除时间',
+    `create_by` BIGINT UNSIGNED DEFAULT 0 NOT NULL COMMENT '创建人ID',
+    `create_by_name` VARCHAR(20) DEFAULT '系统' NOT NULL COMMENT '创建人姓名',
+    `update_by` BIGINT UNSIGNED DEFAULT 0 NOT NULL COMMENT '修改人ID',
+    `update_by_name` VARCHAR(20) DEFAULT '系统' NOT NULL COMMENT '修改人姓名',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_code` (`code`),
+    KEY `idx_category_id` (`category_id`),
+    KEY `idx_author_id` (`author_id`),
+    KEY `idx_status` (`status`),
+    KEY `idx_created_at` (`created_at`),
+    KEY `idx_deleted_at` (`deleted_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='思维模型表';
+
+CREATE TABLE `model_categories` (
+    `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+    -- ==================== 业务字段 ====================
+    `parent_id` BIGINT UNSIGNED DEFAULT 0 COMMENT '父分类ID',
+    `name` VARCHAR(50) NOT NULL COMMENT '分类名称',
+    `code` VARCHAR(50) NOT NULL COMMENT '分类编码',
+    `icon` VARCHAR(100) DEFAULT '' COMMENT '分类图标',
+    `description` VARCHAR(200) DEFAULT '' COMMENT '分类描述',
+    `sort` INT DEFAULT 0 COMMENT '排序',
+    `level` INT DEFAULT 1 COMMENT '层级',
+    `path` VARCHAR(500) DEFAULT '' COMMENT '路径',
+    `status` TINYINT DEFAULT 1 COMMENT '状态:0=禁用,1=正常',
+    `model_count` INT DEFAULT 0 COMMENT '模型数量',
+    -- ==================== 标准审计字段 ====================
+    `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP NOT NULL COMMENT '创建时间',
+    `updated_at` DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP NULL COMMENT '修改时间',
+    `deleted_at` DATETIME NULL COMMENT '软删除时间',
+    `create_by` BIGINT UNSIGNED DEFAULT 0 NOT NULL COMMENT '创建人ID',
+    `create_by_name` VARCHAR(20) DEFAULT '系统' NOT NULL COMMENT '创建人姓名',
+    `update_by` BIGINT UNSIGNED DEFAULT 0 NOT NULL COMMENT '修改人ID',
+    `update_by_name` VARCHAR(20) DEFAULT '系统' NOT NULL COMMENT '修改人姓名',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_code` (`code`),
+    KEY `idx_parent_id` (`parent_id`),
+    KEY `idx_created_at` (`created_at`),
+    KEY `idx_deleted_at` (`deleted_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='模型分类表';
+
+CREATE TABLE `model_tags` (
+    `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+    -- ==================== 业务字段 ====================
+    `model_id` BIGINT UNSIGNED NOT NULL COMMENT '模型ID',
+    `tag_name` VARCHAR(50) NOT NULL COMMENT '标签名称',
+    -- ==================== 标准审计字段 ====================
+    `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP NOT NULL COMMENT '创建时间',
+    `deleted_at` DATETIME NULL COMMENT '软删除时间',
+    PRIMARY KEY (`id`),
+    KEY `idx_model_id` (`model_id`),
+    KEY `idx_tag_name` (`tag_name`),
+    KEY `idx_created_at` (`created_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='模型标签表';
+
+CREATE TABLE `topics` (
+    `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+    -- ==================== 业务字段 ====================
+    `title` VARCHAR(200) NOT NULL COMMENT '课题标题',
+    `description` TEXT COMMENT '课题描述',
+    `background` TEXT COMMENT '背景说明',
+    `goal` TEXT COMMENT '目标设定',
+    `constraints` TEXT COMMENT '约束条件',
+    `status` TINYINT DEFAULT 0 COMMENT '状态:0=草稿,1=进行中,2=已完成,3=已归档',
+    `user_id` BIGINT UNSIGNED NOT NULL COMMENT '所属用户ID',
+    `model_id` BIGINT UNSIGNED DEFAULT 0 COMMENT '当前使用的思维模型ID',
+    `model_name` VARCHAR(100) DEFAULT '' COMMENT '模型名称快照',
+    `priority` TINYINT DEFAULT 2 COMMENT '优先级:1=低,2=中,3=高',
+    `tags` VARCHAR(500) DEFAULT '' COMMENT '标签，逗号分隔',
+    `analysis_count` INT DEFAULT 0 COMMENT '分析次数',
+    `action_count` INT DEFAULT 0 COMMENT '导出行动数',
+    `deadline` DATETIME DEFAULT NULL COMMENT '截止日期',
+    `complete_time` DATETIME DEFAULT NULL COMMENT '完成时间',
+    -- ==================== 标准审计字段 ====================
+    `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP NOT NULL COMMENT '创建时间',
+    `updated_at` DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP NULL COMMENT '修改时间',
+    `deleted_at` DATETIME NULL COMMENT '软删除时间',
+    `create_by` BIGINT UNSIGNED DEFAULT 0 NOT NULL COMMENT '创建人ID',
+    `create_by_name` VARCHAR(20) DEFAULT '系统' NOT NULL COMMENT '创建人姓名',
+    `update_by` BIGINT UNSIGNED DEFAULT 0 NOT NULL COMMENT '修改人ID',
+    `update_by_name` VARCHAR(20) DEFAULT '系统' NOT NULL COMMENT '修改人姓名',
+    PRIMARY KEY (`id`),
+    KEY `idx_user_id` (`user_id`),
+    KEY `idx_model_id` (`model_id`),
+    KEY `idx_status` (`status`),
+    KEY `idx_created_at` (`created_at`),
+    KEY `idx_deleted_at` (`deleted_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='课题表';
+
+CREATE TABLE `topic_analyses` (
+    `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+    -- ==================== 业务字段 ====================
+    `topic_id` BIGINT UNSIGNED NOT NULL COMMENT '所属课题ID',
+    `model_id` BIGINT UNSIGNED NOT NULL COMMENT '使用的思维模型ID',
+    `model_name` VARCHAR(100) DEFAULT '' COMMENT '模型名称快照',
+    `content` LONGTEXT COMMENT '用户填写的分析内容JSON',
+    `ai_analysis` LONGTEXT COMMENT 'AI分析结果',
+    `ai_suggestions` TEXT COMMENT 'AI建议',
+    `version` INT DEFAULT 1 COMMENT '版本号',
+    `is_current` TINYINT DEFAULT 1 COMMENT '是否当前版本:0=否,1=是',
+    `user_id` BIGINT UNSIGNED NOT NULL COMMENT '用户ID',
+    `status` TINYINT DEFAULT 0 COMMENT '状态:0=分析中,1=已完成,2=失败',
+    -- ==================== 标准审计字段 ====================
+    `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP NOT NULL COMMENT '创建时间',
+    `updated_at` DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP NULL COMMENT '修改时间',
+    `deleted_at` DATETIME NULL COMMENT '软删除时间',
+    `create_by` BIGINT UNSIGNED DEFAULT 0 NOT NULL COMMENT '创建人ID',
+    `create_by_name` VARCHAR(20) DEFAULT '系统' NOT NULL COMMENT '创建人姓名',
+    `update_by` BIGINT UNSIGNED DEFAULT 0 NOT NULL COMMENT '修改人ID',
+    `update_by_name` VARCHAR(20) DEFAULT '系统' NOT NULL COMMENT '修改人姓名',
+    PRIMARY KEY (`id`),
+    KEY `idx_topic_id` (`topic_id`),
+    KEY `idx_model_id` (`model_id`),
+    KEY `idx_user_id` (`user_id`),
+    KEY `idx_created_at` (`created_at`),
+    KEY `idx_deleted_at` (`deleted_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='课题分析记录表';
+
+CREATE TABLE `actions` (
+    `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+    -- ==================== 业务字段 ====================
+    `title` VARCHAR(200) NOT NULL COMMENT '行动标题',
+    `description` TEXT COMMENT '行动描述',
+    `user_id` BIGINT UNSIGNED NOT NULL COMMENT '所属用户ID',
+    `topic_id` BIGINT UNSIGNED DEFAULT 0 COMMENT '关联课题ID',
+    `topic_title` VARCHAR(200) DEFAULT '' COMMENT '课题标题快照',
+    `analysis_id` BIGINT UNSIGNED DEFAULT 0 COMMENT '来源分析ID',
+    `priority` TINYINT DEFAULT 2 COMMENT '优先级:1=低,2=中,3=高',
+    `status` TINYINT DEFAULT 0 COMMENT '状态:0=待执行,1=进行中,2=已完成,3=已取消',
+    `progress` INT DEFAULT 0 COMMENT '完成进度(0-100)',
+    `deadline` DATETIME DEFAULT NULL COMMENT '截止日期',
+    `complete_time` DATETIME DEFAULT NULL COMMENT '完成时间',
+    `guide_principle` TEXT COMMENT '指导原则(来自思维模型)',
+    `followup_count` INT DEFAULT 0 COMMENT '跟进记录数',
+    -- ==================== 标准审计字段 ====================
+    `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP NOT NULL COMMENT '创建时间',
+    `updated_at` DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP NULL COMMENT '修改时间',
+    `deleted_at` DATETIME NULL COMMENT '软删除时间',
+    `create_by` BIGINT UNSIGNED DEFAULT 0 NOT NULL COMMENT '创建人ID',
+    `create_by_name` VARCHAR(20) DEFAULT '系统' NOT NULL COMMENT '创建人姓名',
+    `update_by` BIGINT UNSIGNED DEFAULT 0 NOT NULL COMMENT '修改人ID',
+    `update_by_name` VARCHAR(20) DEFAULT '系统' NOT NULL COMMENT '修改人姓名',
+    PRIMARY KEY (`id`),
+    KEY `idx_user_id` (`user_id`),
+    KEY `idx_topic_id` (`topic_id`),
+    KEY `idx_status` (`status`),
+    KEY `idx_priority` (`priority`),
+    KEY `idx_deadline` (`deadline`),
+    KEY `idx_created_at` (`created_at`),
+    KEY `idx_deleted_at` (`deleted_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='行动表';
+
+CREATE TABLE `action_followups` (
+    `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT '主键ID',
+    -- ==================== 业务字段 ====================
+    `action_id` BIGINT UNSIGNED NOT NULL COMMENT '所属行动ID',
+    `user_id` BIGINT UNSIGNED NOT NULL COMMENT '用户ID',
+    `content` TEXT NOT NULL COMMENT '跟进内容',
+    `progress_before` INT DEFAULT 0 COMMENT '跟进前进度',
+    `progress_after` INT DEFAULT 0 COMMENT '跟进后进度',
+    -- ==================== 标准审计字段 ====================
+    `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP NOT NULL COMMENT '创建时间',
+    `updated_at` DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP NULL COMMENT '修改时间',
+    `deleted_at` DATETIME NULL COMMENT '软删除时间',
+    `create_by` BIGINT UNSIGNED DEFAULT 0 NOT NULL COMMENT '创建人ID',
+    `create_by_name` VARCHAR(20) DEFAULT '系统' NOT NULL COMMENT '创建人姓名',
+    `update_by` BIGINT UNSIGNED DEFAULT 0 NOT NULL COMMENT '修改人ID',
+    `update_by_name` VARCHAR(20) DEFAULT '系统' NOT NULL COMMENT '修改人姓名',
+    PRIMARY KEY (`id`),
+    KEY `idx_action_id` (`action_id`),
+    KEY `idx_user_id` (`user_id`),
+    KEY `idx_created_at` (`created_at`),
+    KEY `idx_deleted_at` (`deleted_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='行动跟进记录表';
+
+-- ========================================
+-- 多租户
+-- ========================================
+ALTER TABLE `topics` ADD COLUMN `enterprise_id` BIGINT UNSIGNED NOT NULL DEFAULT 0 COMMENT '企业ID', ADD KEY `idx_enterprise_id` (`enterprise_id`);
+ALTER TABLE `topic_analyses` ADD COLUMN `enterprise_id` BIGINT UNSIGNED NOT NULL DEFAULT 0 COMMENT '企业ID', ADD KEY `idx_enterprise_id` (`enterprise_id`);
+ALTER TABLE `actions` ADD COLUMN `enterprise_id` BIGINT UNSIGNED NOT NULL DEFAULT 0 COMMENT '企业ID', ADD KEY `idx_enterprise_id` (`enterprise_id`);
+ALTER TABLE `action_followups` ADD COLUMN `enterprise_id` BIGINT UNSIGNED NOT NULL DEFAULT 0 COMMENT '企业ID', ADD KEY `idx_enterprise_id` (`enterprise_id`);
+ALTER TABLE `model_tags` ADD COLUMN `enterprise_id` BIGINT UNSIGNED NOT NULL DEFAULT 0 COMMENT '企业ID', ADD KEY `idx_enterprise_id` (`enterprise_id`);
+ALTER TABLE `thinking_models` ADD COLUMN `enterprise_id` BIGINT UNSIGNED NOT NULL DEFAULT 0 COMMENT '企业ID', ADD COLUMN `is_shared` TINYINT NOT NULL DEFAULT 0 COMMENT '是否跨企业共享:0=否,1=是', ADD KEY `idx_enterprise_shared` (`enterprise_id`, `is_shared`);
+ALTER TABLE `model_categories` ADD COLUMN `enterprise_id` BIGINT UNSIGNED NOT NULL DEFAULT 0 COMMENT '企业ID', ADD COLUMN `is_shared` TINYINT NOT NULL DEFAULT 0 COMMENT '是否跨企业共享:0=否,1=是', ADD KEY `idx_enterprise_shared` (`enterprise_id`, `is_shared`);
+ALTER TABLE `category` ADD COLUMN `enterprise_id` BIGINT UNSIGNED NOT NULL DEFAULT 0 COMMENT '企业ID', ADD COLUMN `is_shared` TINYINT NOT NULL DEFAULT 0 COMMENT '是否跨企业共享:0=否,1=是', ADD KEY `idx_enterprise_shared` (`enterprise_id`, `is_shared`);
+ALTER TABLE `super_dictionary` ADD COLUMN `enterprise_id` BIGINT UNSIGNED NOT NULL DEFAULT 0 COMMENT '企业ID', ADD COLUMN `is_shared` TINYINT NOT NULL DEFAULT 0 COMMENT '是否跨企业共享:0=否,1=是', ADD KEY `idx_enterprise_shared` (`enterprise_id`, `is_shared`);
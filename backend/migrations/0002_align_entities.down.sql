@@ -0,0 +1,25 @@
+ALTER TABLE `model_tags`
+    DROP KEY `idx_deleted_at`,
+    DROP COLUMN `update_by_name`,
+    DROP COLUMN `update_by`,
+    DROP COLUMN `create_by_name`,
+    DROP COLUMN `create_by`,
+    DROP COLUMN `updated_at`;
+
+ALTER TABLE `topic_analyses`
+    DROP COLUMN `conclusion`,
+    DROP COLUMN `user_result`,
+    DROP COLUMN `ai_result`,
+    DROP COLUMN `input_content`,
+    DROP COLUMN `topic_title`;
+
+ALTER TABLE `actions`
+    DROP COLUMN `actual_result`,
+    DROP COLUMN `expected_result`,
+    CHANGE COLUMN `follow_up_count` `followup_count` INT DEFAULT 0 COMMENT '跟进记录数',
+    CHANGE COLUMN `completed_at` `complete_time` DATETIME DEFAULT NULL COMMENT '完成时间';
+
+ALTER TABLE `thinking_models`
+    DROP COLUMN `ai_prompt`,
+    DROP COLUMN `examples`,
+    DROP COLUMN `usage_guide`;
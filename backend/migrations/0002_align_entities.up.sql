@@ -0,0 +1,31 @@
+-- 补齐实体已使用但文档表结构缺少的字段
+
+-- 思维模型：使用指南、案例与AI提示词
+ALTER TABLE `thinking_models`
+    ADD COLUMN `usage_guide` TEXT COMMENT '使用指南' AFTER `overview`,
+    ADD COLUMN `examples` LONGTEXT COMMENT '案例(JSON)' AFTER `usage_guide`,
+    ADD COLUMN `ai_prompt` TEXT COMMENT 'AI提示词模板' AFTER `examples`;
+
+-- 行动：字段名与实体保持一致，补充预期/实际结果
+ALTER TABLE `actions`
+    CHANGE COLUMN `complete_time` `completed_at` DATETIME DEFAULT NULL COMMENT '完成时间',
+    CHANGE COLUMN `followup_count` `follow_up_count` INT DEFAULT 0 COMMENT '跟进记录数',
+    ADD COLUMN `expected_result` TEXT COMMENT '预期结果' AFTER `progress`,
+    ADD COLUMN `actual_result` TEXT COMMENT '实际结果' AFTER `expected_result`;
+
+-- 分析记录：practice 模块的分析字段（与 subject 模块共用 topic_analyses）
+ALTER TABLE `topic_analyses`
+    ADD COLUMN `topic_title` VARCHAR(200) DEFAULT '' COMMENT '课题标题快照' AFTER `topic_id`,
+    ADD COLUMN `input_content` LONGTEXT COMMENT '用户输入内容(JSON)' AFTER `ai_suggestions`,
+    ADD COLUMN `ai_result` LONGTEXT COMMENT 'AI分析结果(JSON)' AFTER `input_content`,
+    ADD COLUMN `user_result` LONGTEXT COMMENT '用户编辑结果(JSON)' AFTER `ai_result`,
+    ADD COLUMN `conclusion` TEXT COMMENT '分析结论' AFTER `user_result`;
+
+-- 模型标签：补齐标准审计字段
+ALTER TABLE `model_tags`
+    ADD COLUMN `updated_at` DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP NULL COMMENT '修改时间' AFTER `created_at`,
+    ADD COLUMN `create_by` BIGINT UNSIGNED DEFAULT 0 NOT NULL COMMENT '创建人ID' AFTER `deleted_at`,
+    ADD COLUMN `create_by_name` VARCHAR(20) DEFAULT '系统' NOT NULL COMMENT '创建人姓名' AFTER `create_by`,
+    ADD COLUMN `update_by` BIGINT UNSIGNED DEFAULT 0 NOT NULL COMMENT '修改人ID' AFTER `create_by_name`,
+    ADD COLUMN `update_by_name` VARCHAR(20) DEFAULT '系统' NOT NULL COMMENT '修改人姓名' AFTER `update_by`,
+    ADD KEY `idx_deleted_at` (`deleted_at`);
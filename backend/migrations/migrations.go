@@ -0,0 +1,10 @@
+// Package migrations 数据库迁移脚本，编译时嵌入二进制
+//
+// 文件名格式为 {版本号}_{名称}.up.sql / .down.sql，版本号递增，由 `migrate create` 生成；
+// 已发布的迁移不要修改，表结构变更一律新增迁移
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS
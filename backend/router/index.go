@@ -2,7 +2,6 @@ package router
 
 import (
 	"github.com/gin-gonic/gin"
-	"thinkingModels/middleware"
 
 	_ "thinkingModels/docs"
 	ginSwagger "github.com/swaggo/gin-swagger"
@@ -12,11 +11,9 @@ import (
 var Routers = make([]func(router *gin.Engine), 0)
 
 // 实例化所有路由
+// 全局中间件（跨域、限流）在 engine 中注册
 func InitRouter(router *gin.Engine) {
 
-	// 全局中间件 | 允许跨域
-	router.Use(middleware.Cors()).Use(middleware.UserInfo())
-
 	// Swagger API 文档路由
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
@@ -5,6 +5,8 @@ import (
 	"thinkingModels/api/master"
 	"thinkingModels/api/subject"
 	"thinkingModels/api/practice"
+	"thinkingModels/config"
+	"thinkingModels/middleware"
 
 	"github.com/gin-gonic/gin"
 )
@@ -12,16 +14,17 @@ import (
 // 鉴权路由
 func AuthorizedRouters() {
 	authorizedRouters := func(router *gin.Engine) {
-		api := router.Group("")
+		api := router.Group("", middleware.Auth(), middleware.UserRateLimit())
+		aiLimit := middleware.RouteRateLimit("ai", config.Config.RateLimit.Ai)
 
 		// 超级字典
 		superDictionaryApi := master.NewSuperDictionary()
 		superDictionaryGroup := api.Group("/master/superDictionary")
-		superDictionaryGroup.POST("", superDictionaryApi.Create)
-		superDictionaryGroup.PUT("", superDictionaryApi.Update)
+		superDictionaryGroup.POST("", middleware.RequirePermission("DICT_ADD"), superDictionaryApi.Create)
+		superDictionaryGroup.PUT("", middleware.RequirePermission("DICT_EDIT"), superDictionaryApi.Update)
 		superDictionaryGroup.POST("/:id", superDictionaryApi.Get)
 		superDictionaryGroup.POST("/list", superDictionaryApi.List)
-		superDictionaryGroup.DELETE("", superDictionaryApi.Del)
+		superDictionaryGroup.DELETE("", middleware.RequirePermission("DICT_DELETE"), superDictionaryApi.Del)
 		superDictionaryGroup.GET("/tree", superDictionaryApi.Tree)
 		superDictionaryGroup.POST("/children", superDictionaryApi.Children)
 
@@ -29,30 +32,99 @@ func AuthorizedRouters() {
 		userApi := iam.NewUser()
 		userGroup := api.Group("/user")
 		userGroup.GET("/info", userApi.Info) // 获取当前登录用户信息
-		userGroup.POST("", userApi.Create)
+		userGroup.POST("", middleware.RequirePermission("ACCOUNT_ADD"), userApi.Create)
 		userGroup.PUT("", userApi.Update)
-		userGroup.POST("/:id", userApi.Get)
-		userGroup.POST("/list", userApi.List)
-		userGroup.DELETE("", userApi.Del)
+		userGroup.POST("/:id", middleware.RequirePermission("ACCOUNT_VIEW"), userApi.Get)
+		userGroup.POST("/list", middleware.RequirePermission("ACCOUNT_VIEW"), userApi.List)
+		userGroup.DELETE("", middleware.RequirePermission("ACCOUNT_DELETE"), userApi.Del)
+		userGroup.POST("/roles", middleware.RequirePermission("ACCOUNT_EDIT"), userApi.AssignRoles) // 分配角色
+		userGroup.POST("/unlock", middleware.RequirePermission("ACCOUNT_EDIT"), userApi.UnlockLogin) // 解除登录锁定
+		userGroup.POST("/password", userApi.ChangePassword)                                         // 修改密码
+
+		// 两步验证
+		totpGroup := api.Group("/user/2fa")
+		totpGroup.GET("", userApi.TotpStatus)
+		totpGroup.POST("/setup", userApi.SetupTotp)
+		totpGroup.POST("/enable", userApi.EnableTotp)
+		totpGroup.POST("/disable", userApi.DisableTotp)
+		totpGroup.POST("/recovery-codes", userApi.RegenerateRecoveryCodes) // 重新生成恢复码
+
+		// 个人访问令牌（令牌管理接口不接受个人访问令牌本身）
+		accessTokenApi := iam.NewAccessToken()
+		accessTokenGroup := api.Group("/user/tokens")
+		accessTokenGroup.GET("/scopes", accessTokenApi.Scopes)
+		accessTokenGroup.GET("", accessTokenApi.ListMy)
+		accessTokenGroup.POST("", accessTokenApi.Create)
+		accessTokenGroup.DELETE("", accessTokenApi.Revoke)
+
+		// 登录会话
+		sessionApi := iam.NewSession()
+		sessionGroup := api.Group("/user/sessions")
+		sessionGroup.GET("", sessionApi.ListMy)
+		sessionGroup.DELETE("", sessionApi.Revoke)
+		sessionGroup.POST("/revoke-others", sessionApi.RevokeOthers) // 下线其他会话
+
+		// 个人数据导出与注销账号
+		accountApi := iam.NewAccount()
+		userGroup.GET("/export", accountApi.Export)
+		userGroup.DELETE("/account", accountApi.DeleteAccount)
+
+		// 用户主页与关注
+		userGroup.GET("/profile/:id", userApi.Profile)
+		userGroup.POST("/follow", userApi.Follow)
+		userGroup.DELETE("/follow", userApi.Unfollow)
+
+		// 角色管理
+		roleApi := iam.NewRole()
+		roleGroup := api.Group("/iam/role", middleware.RequirePermission("ROLE"))
+		roleGroup.POST("/list", middleware.RequirePermission("ROLE_VIEW"), roleApi.List)
+		roleGroup.POST("/permissions", middleware.RequirePermission("ROLE_EDIT"), roleApi.AssignPermissions) // 分配权限
+		roleGroup.POST("", middleware.RequirePermission("ROLE_ADD"), roleApi.Create)
+		roleGroup.PUT("", middleware.RequirePermission("ROLE_EDIT"), roleApi.Update)
+		roleGroup.GET("/:id", middleware.RequirePermission("ROLE_VIEW"), roleApi.Get)
+		roleGroup.DELETE("", middleware.RequirePermission("ROLE_DELETE"), roleApi.Del)
+
+		// 审计日志（只读）
+		auditLogApi := iam.NewAuditLog()
+		auditLogGroup := api.Group("/iam/audit-log", middleware.RequirePermission("AUDIT_VIEW"))
+		auditLogGroup.POST("/list", auditLogApi.List)
+		auditLogGroup.GET("/:id", auditLogApi.Get)
 
-		// 认证相关（非鉴权）
+		// 邀请码管理
+		inviteCodeApi := iam.NewInviteCode()
+		inviteCodeGroup := api.Group("/iam/invite-code", middleware.RequirePermission("INVITE_CODE"))
+		inviteCodeGroup.POST("/list", inviteCodeApi.List)
+		inviteCodeGroup.POST("", inviteCodeApi.Create)
+		inviteCodeGroup.PUT("", inviteCodeApi.Update)
+		inviteCodeGroup.GET("/:id", inviteCodeApi.Get)
+		inviteCodeGroup.DELETE("", inviteCodeApi.Del)
+
+		// 权限管理
+		permissionApi := iam.NewPermission()
+		permissionGroup := api.Group("/iam/permission", middleware.RequirePermission("ROLE"))
+		permissionGroup.GET("/all", middleware.RequirePermission("ROLE_VIEW"), permissionApi.All)
+		permissionGroup.POST("/list", middleware.RequirePermission("ROLE_VIEW"), permissionApi.List)
+		permissionGroup.POST("", middleware.RequirePermission("SYSTEM_SETTING"), permissionApi.Create)
+		permissionGroup.PUT("", middleware.RequirePermission("SYSTEM_SETTING"), permissionApi.Update)
+		permissionGroup.GET("/:id", middleware.RequirePermission("ROLE_VIEW"), permissionApi.Get)
+		permissionGroup.DELETE("", middleware.RequirePermission("SYSTEM_SETTING"), permissionApi.Del)
+
+		// 认证相关
 		authGroup := api.Group("/auth")
-		authGroup.POST("/login", userApi.Login)
-		authGroup.POST("/logout", userApi.Logout)
-		authGroup.POST("/refresh", userApi.Refresh)
-		authGroup.GET("/codes", userApi.Codes) // 获取用户权限码
+		authGroup.GET("/codes", userApi.Codes)           // 获取用户权限码
+		authGroup.POST("/logout-all", userApi.LogoutAll) // 退出全部设备
 
 		// ==================== 主数据模块 (Master) ====================
 		// 模型分类管理
 		masterCategoryApi := master.NewCategory()
 		masterCategoryGroup := api.Group("/master/category")
-		masterCategoryGroup.GET("/all", masterCategoryApi.All)     // 全量列表（按热度降序）
-		masterCategoryGroup.GET("/list", masterCategoryApi.List)   // 分页列表
-		masterCategoryGroup.POST("", masterCategoryApi.Create)     // 新建分类
-		masterCategoryGroup.PUT("", masterCategoryApi.Update)    // 更新分类
-		masterCategoryGroup.GET("/:id", masterCategoryApi.Get)     // 查询详情
-		masterCategoryGroup.DELETE("", masterCategoryApi.Del)     // 删除分类
-		masterCategoryGroup.POST("/increaseHeat", masterCategoryApi.IncreaseHeat) // 增加热度
+		masterCategoryGroup.GET("/all", masterCategoryApi.All)                                             // 全量列表（按热度降序）
+		masterCategoryGroup.GET("/list", masterCategoryApi.List)                                           // 分页列表
+		masterCategoryGroup.POST("", middleware.RequirePermission("DICT_ADD"), masterCategoryApi.Create)   // 新建分类
+		masterCategoryGroup.PUT("", middleware.RequirePermission("DICT_EDIT"), masterCategoryApi.Update)   // 更新分类
+		masterCategoryGroup.GET("/:id", masterCategoryApi.Get)                                             // 查询详情
+		masterCategoryGroup.DELETE("", middleware.RequirePermission("DICT_DELETE"), masterCategoryApi.Del) // 删除分类
+		masterCategoryGroup.POST("/increaseHeat", masterCategoryApi.IncreaseHeat)                          // 增加热度
 
 		// ==================== 课题管理模块 ====================
 		// 课题管理
@@ -77,7 +149,7 @@ func AuthorizedRouters() {
 		analysisApi := subject.NewAnalysis()
 		analysisGroup := api.Group("/subject/analysis")
 		// 注意：具体路由必须放在参数路由（/:id）之前
-		analysisGroup.POST("/save-with-ai", analysisApi.SaveWithAi)
+		analysisGroup.POST("/save-with-ai", aiLimit, analysisApi.SaveWithAi)
 		analysisGroup.GET("/list", analysisApi.List)
 		analysisGroup.GET("/my", analysisApi.ListByUser)
 		analysisGroup.GET("/current", analysisApi.GetCurrent)
@@ -101,8 +173,9 @@ func AuthorizedRouters() {
 		thinkingModelGroup.PUT("", thinkingModelApi.Update)
 		thinkingModelGroup.GET("/:id", thinkingModelApi.Get)
 		thinkingModelGroup.DELETE("", thinkingModelApi.Del)
-		thinkingModelGroup.POST("/publish", thinkingModelApi.Publish)
-		thinkingModelGroup.POST("/unpublish/:id", thinkingModelApi.Unpublish)
+		thinkingModelGroup.POST("/publish", middleware.RequirePermission("MODEL_PUBLISH"), thinkingModelApi.Publish)
+		thinkingModelGroup.POST("/unpublish/:id", middleware.RequirePermission("MODEL_PUBLISH"), thinkingModelApi.Unpublish)
+		thinkingModelGroup.POST("/share", middleware.RequirePermission("MODEL_SHARE"), thinkingModelApi.Share)
 		thinkingModelGroup.POST("/fork", thinkingModelApi.Fork)
 
 		// 模型分类管理
@@ -146,7 +219,7 @@ func AuthorizedRouters() {
 		// 分析记录管理
 		thinkingAnalysisApi := thinking.NewAnalysis()
 		thinkingAnalysisGroup := api.Group("/thinking/analysis")
-		thinkingAnalysisGroup.POST("/save-with-ai", thinkingAnalysisApi.SaveWithAi)
+		thinkingAnalysisGroup.POST("/save-with-ai", aiLimit, thinkingAnalysisApi.SaveWithAi)
 		thinkingAnalysisGroup.GET("/list", thinkingAnalysisApi.List)
 		thinkingAnalysisGroup.GET("/my", thinkingAnalysisApi.ListMy)
 		thinkingAnalysisGroup.GET("/current", thinkingAnalysisApi.GetCurrent)
@@ -192,10 +265,29 @@ func AuthorizedRouters() {
 func UnAuthorizedRouters() {
 	unAuthorizedRouters := func(router *gin.Engine) {
 		api := router.Group("")
+		loginLimit := middleware.RouteRateLimit("login", config.Config.RateLimit.Login)
+		mailLimit := middleware.RouteRateLimit("mail", config.Config.RateLimit.Mail)
 
-		// 用户注册（无需鉴权）
+		// 认证相关（无需鉴权）
 		userApi := iam.NewUser()
-		api.POST("/auth/register", userApi.Create)
+		authGroup := api.Group("/auth")
+		authGroup.POST("/register", middleware.RouteRateLimit("register", config.Config.RateLimit.Register), userApi.Register) // 用户注册
+		authGroup.GET("/captcha", userApi.Captcha)              // 获取人机验证码
+		authGroup.GET("/captcha/status", userApi.CaptchaStatus) // 是否需要人机验证
+		authGroup.POST("/login", loginLimit, userApi.Login)
+		authGroup.POST("/logout", userApi.Logout) // 登出时token可能已过期
+		authGroup.POST("/refresh", userApi.Refresh)
+		authGroup.POST("/forgot-password", mailLimit, userApi.ForgotPassword)         // 忘记密码
+		authGroup.POST("/reset-password", userApi.ResetPassword)                      // 重置密码
+		authGroup.POST("/verify-email", userApi.VerifyEmail)                          // 验证注册邮箱
+		authGroup.POST("/resend-verification", mailLimit, userApi.ResendVerification) // 重新发送验证邮件
+		authGroup.POST("/2fa/verify", loginLimit, userApi.VerifyTwoFactor)            // 两步验证登录
+
+		accountApi := iam.NewAccount()
+		authGroup.GET("/account-deletion/:jobId", accountApi.DeletionStatus) // 注销进度
+
+		// JWT公钥集合，供其他服务校验Token
+		api.GET("/.well-known/jwks.json", userApi.Jwks)
 	}
 	Routers = append(Routers, unAuthorizedRouters)
 }
@@ -203,7 +295,11 @@ func UnAuthorizedRouters() {
 // oauth2路由
 func Oauth2Routers() {
 	oauth2Routers := func(router *gin.Engine) {
-		// todo
+		oauthApi := iam.NewOauth()
+		oauthGroup := router.Group("/oauth2")
+		oauthGroup.GET("/providers", oauthApi.Providers)           // 可用的第三方登录方式
+		oauthGroup.GET("/:provider/authorize", oauthApi.Authorize) // 跳转到提供方授权页
+		oauthGroup.GET("/:provider/callback", oauthApi.Callback)   // 授权回调，返回登录Token
 	}
 	Routers = append(Routers, oauth2Routers)
 }